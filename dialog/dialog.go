@@ -0,0 +1,422 @@
+// Package dialog SIP dialogs RFC3261#section-12
+package dialog
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/staskobzar/gosip/sipmsg"
+)
+
+// ErrorDialog dialog error
+var ErrorDialog = errorNew("Dialog")
+
+// State of dialog
+type State uint8
+
+// Dialog states
+const (
+	Early State = iota + 1
+	Confirmed
+	Terminated
+)
+
+// default Max-Forwards of in-dialog requests
+const maxForwards = 70
+
+// Dialog peer-to-peer SIP relationship between two UAs (RFC3261#12)
+type Dialog struct {
+	mux          *sync.Mutex
+	state        State
+	uac          bool
+	callID       string
+	localTag     string
+	remoteTag    string
+	localURI     string
+	remoteURI    string
+	localSeq     uint
+	remoteSeq    uint
+	localTarget  string
+	remoteTarget string
+	routeSet     []string
+	secure       bool
+}
+
+// NewUAC creates dialog on UAC side from the request sent and
+// 1xx (except 100) or 2xx response with To tag (RFC3261#12.1.2)
+func NewUAC(req, resp *sipmsg.Message) (*Dialog, error) {
+	if err := validate(req, resp); err != nil {
+		return nil, err
+	}
+	d := &Dialog{
+		mux:         &sync.Mutex{},
+		uac:         true,
+		callID:      req.CallID,
+		localTag:    req.From.Tag(),
+		remoteTag:   resp.To.Tag(),
+		localURI:    req.From.Addr(),
+		remoteURI:   req.To.Addr(),
+		localSeq:    req.CSeq.Num,
+		localTarget: contact(req),
+		secure:      isSIPS(req.ReqLine.RequestURI()),
+	}
+	d.update(resp)
+	return d, nil
+}
+
+// NewUAS creates dialog on UAS side from the request received and
+// 1xx (except 100) or 2xx response with To tag sent (RFC3261#12.1.1)
+func NewUAS(req, resp *sipmsg.Message) (*Dialog, error) {
+	if err := validate(req, resp); err != nil {
+		return nil, err
+	}
+	d := &Dialog{
+		mux:          &sync.Mutex{},
+		state:        state(resp),
+		callID:       req.CallID,
+		localTag:     resp.To.Tag(),
+		remoteTag:    req.From.Tag(),
+		localURI:     req.To.Addr(),
+		remoteURI:    req.From.Addr(),
+		remoteSeq:    req.CSeq.Num,
+		localTarget:  contact(resp),
+		remoteTarget: contact(req),
+		secure:       isSIPS(req.ReqLine.RequestURI()),
+	}
+	for _, r := range req.RecRoutes {
+		d.routeSet = append(d.routeSet, r.Addr())
+	}
+	return d, nil
+}
+
+// ID returns dialog identifier (RFC3261#12)
+func (d *Dialog) ID() string {
+	return Key(d.callID, d.localTag, d.remoteTag)
+}
+
+// Key builds dialog identifier from Call-ID, local and remote tags
+func Key(callID, localTag, remoteTag string) string {
+	return callID + "|" + localTag + "|" + remoteTag
+}
+
+// MsgKey builds dialog identifier of the received message. For the
+// request local tag is To tag and for the response local tag is From tag.
+func MsgKey(msg *sipmsg.Message) string {
+	if msg.From == nil || msg.To == nil {
+		return ""
+	}
+	if msg.IsRequest() {
+		return Key(msg.CallID, msg.To.Tag(), msg.From.Tag())
+	}
+	return Key(msg.CallID, msg.From.Tag(), msg.To.Tag())
+}
+
+// Match returns true if received message belongs to the dialog
+func (d *Dialog) Match(msg *sipmsg.Message) bool {
+	return MsgKey(msg) == d.ID()
+}
+
+// Update updates UAC dialog with response to the dialog creating
+// request. 2xx response confirms early dialog and refreshes route set
+// and remote target. Non-2xx final response terminates early dialog.
+func (d *Dialog) Update(resp *sipmsg.Message) error {
+	if !resp.IsResponse() || !d.Match(resp) {
+		return ErrorDialog.msg("response does not match dialog")
+	}
+	d.mux.Lock()
+	defer d.mux.Unlock()
+	if d.state == Terminated {
+		return ErrorDialog.msg("dialog is terminated")
+	}
+	code := resp.Code()
+	if code >= 300 {
+		if d.state == Early {
+			d.state = Terminated
+		}
+		return nil
+	}
+	if d.state == Confirmed {
+		// target refresh by 2xx response to re-INVITE
+		if c := contact(resp); c != "" {
+			d.remoteTarget = c
+		}
+		return nil
+	}
+	d.update(resp)
+	return nil
+}
+
+// Recv processes in-dialog request received from remote UA.
+// Returns error if CSeq is lower than remote sequence number
+// (RFC3261#12.2.2). Request must be rejected with 500 in this case.
+func (d *Dialog) Recv(req *sipmsg.Message) error {
+	if !req.IsRequest() || !d.Match(req) {
+		return ErrorDialog.msg("request does not match dialog")
+	}
+	d.mux.Lock()
+	defer d.mux.Unlock()
+	if d.state == Terminated {
+		return ErrorDialog.msg("dialog is terminated")
+	}
+	method := req.ReqLine.Method()
+	if method == "ACK" || method == "CANCEL" {
+		return nil
+	}
+	if d.remoteSeq != 0 && req.CSeq.Num <= d.remoteSeq {
+		return ErrorDialog.msg("CSeq %d is out of order", req.CSeq.Num)
+	}
+	d.remoteSeq = req.CSeq.Num
+	if isTargetRefresh(method) {
+		if c := contact(req); c != "" {
+			d.remoteTarget = c
+		}
+	}
+	if method == "BYE" {
+		d.state = Terminated
+	}
+	return nil
+}
+
+// NewRequest creates in-dialog request (RFC3261#12.2.1.1). Local
+// sequence number is incremented. Request-URI and Route headers
+// are set from remote target and route set. Via header must be
+// added by the caller if via is nil.
+func (d *Dialog) NewRequest(method string, via *sipmsg.Via) (*sipmsg.Message, error) {
+	d.mux.Lock()
+	if d.state == Terminated {
+		d.mux.Unlock()
+		return nil, ErrorDialog.msg("dialog is terminated")
+	}
+	d.localSeq++
+	cseq := d.localSeq
+	if method == "BYE" {
+		d.state = Terminated
+	}
+	d.mux.Unlock()
+	return d.request(method, cseq, via, nil)
+}
+
+// NewACK creates ACK for 2xx response to INVITE (RFC3261#13.2.2.4).
+// ACK has the same CSeq number as INVITE, is sent to remote target
+// with dialog route set and must have Via with new branch. If via
+// is nil then INVITE top Via with new branch is used.
+// Credentials of INVITE are copied to ACK.
+func (d *Dialog) NewACK(invite *sipmsg.Message, via *sipmsg.Via) (*sipmsg.Message, error) {
+	if invite == nil || !invite.IsInvite() || invite.CSeq == nil {
+		return nil, ErrorDialog.msg("INVITE request expected")
+	}
+	if d.State() == Terminated {
+		return nil, ErrorDialog.msg("dialog is terminated")
+	}
+	if via == nil {
+		var err error
+		if via, err = ackVia(invite); err != nil {
+			return nil, err
+		}
+	}
+	var creds []*sipmsg.Header
+	invite.Headers.ForEach(func(h *sipmsg.Header) {
+		if h.ID() == sipmsg.SIPHdrAuthorization || h.ID() == sipmsg.SIPHdrProxyAuthorization {
+			creds = append(creds, h)
+		}
+	})
+	return d.request("ACK", invite.CSeq.Num, via, creds)
+}
+
+// ackVia creates Via with sent-by of INVITE top Via and new branch
+func ackVia(invite *sipmsg.Message) (*sipmsg.Via, error) {
+	if invite.Vias.Count() == 0 {
+		return nil, ErrorDialog.msg("INVITE has no Via header")
+	}
+	top := invite.Vias[0]
+	var port uint
+	if p := top.Port(); p != "" {
+		n, err := strconv.ParseUint(p, 10, 16)
+		if err != nil {
+			return nil, ErrorDialog.msg("invalid Via port %s", p)
+		}
+		port = uint(n)
+	}
+	return sipmsg.NewHdrVia(strings.ToUpper(top.Transport()), top.Host(), port, nil)
+}
+
+// CallID dialog Call-ID
+func (d *Dialog) CallID() string { return d.callID }
+
+// LocalTag dialog local tag
+func (d *Dialog) LocalTag() string { return d.localTag }
+
+// RemoteTag dialog remote tag
+func (d *Dialog) RemoteTag() string { return d.remoteTag }
+
+// LocalURI dialog local URI
+func (d *Dialog) LocalURI() string { return d.localURI }
+
+// RemoteURI dialog remote URI
+func (d *Dialog) RemoteURI() string { return d.remoteURI }
+
+// LocalSeq dialog local sequence number
+func (d *Dialog) LocalSeq() uint {
+	d.mux.Lock()
+	defer d.mux.Unlock()
+	return d.localSeq
+}
+
+// RemoteSeq dialog remote sequence number
+func (d *Dialog) RemoteSeq() uint {
+	d.mux.Lock()
+	defer d.mux.Unlock()
+	return d.remoteSeq
+}
+
+// RemoteTarget dialog remote target URI
+func (d *Dialog) RemoteTarget() string {
+	d.mux.Lock()
+	defer d.mux.Unlock()
+	return d.remoteTarget
+}
+
+// RouteSet dialog route set
+func (d *Dialog) RouteSet() []string {
+	d.mux.Lock()
+	defer d.mux.Unlock()
+	return append([]string(nil), d.routeSet...)
+}
+
+// State dialog state
+func (d *Dialog) State() State {
+	d.mux.Lock()
+	defer d.mux.Unlock()
+	return d.state
+}
+
+// IsUAC returns true if dialog was created by UAC
+func (d *Dialog) IsUAC() bool { return d.uac }
+
+// IsSecure returns true if dialog was created by sips request
+func (d *Dialog) IsSecure() bool { return d.secure }
+
+// Terminate terminates dialog
+func (d *Dialog) Terminate() {
+	d.mux.Lock()
+	defer d.mux.Unlock()
+	d.state = Terminated
+}
+
+// update sets UAC dialog state, route set and remote target
+// from response (RFC3261#12.1.2)
+func (d *Dialog) update(resp *sipmsg.Message) {
+	d.state = state(resp)
+	d.remoteTag = resp.To.Tag()
+	d.remoteTarget = contact(resp)
+	d.routeSet = d.routeSet[:0]
+	for _, r := range resp.RecRoutes.Reverse() {
+		d.routeSet = append(d.routeSet, r.Addr())
+	}
+}
+
+// request builds in-dialog request with Request-URI and Route
+// headers set according to RFC3261#12.2.1.1
+func (d *Dialog) request(method string, cseq uint, via *sipmsg.Via, hdrs []*sipmsg.Header) (*sipmsg.Message, error) {
+	d.mux.Lock()
+	ruri := d.remoteTarget
+	routes := append([]string(nil), d.routeSet...)
+	local := d.localTarget
+	d.mux.Unlock()
+
+	if ruri == "" {
+		return nil, ErrorDialog.msg("dialog has no remote target")
+	}
+	if len(routes) > 0 {
+		uri := sipmsg.URIParse([]byte(routes[0]))
+		if uri == nil {
+			return nil, ErrorDialog.msg("invalid route %s", routes[0])
+		}
+		if _, lr := uri.Param("lr"); !lr {
+			// strict router
+			routes = append(routes[1:], ruri)
+			ruri = uri.String()
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(method + " " + ruri + " SIP/2.0\r\n")
+	if via != nil {
+		b.WriteString(via.String())
+	}
+	b.WriteString("Max-Forwards: " + strconv.Itoa(maxForwards) + "\r\n")
+	for _, r := range routes {
+		b.WriteString("Route: <" + r + ">\r\n")
+	}
+	b.WriteString("From: <" + d.localURI + ">;tag=" + d.localTag + "\r\n")
+	b.WriteString("To: <" + d.remoteURI + ">")
+	if d.remoteTag != "" {
+		b.WriteString(";tag=" + d.remoteTag)
+	}
+	b.WriteString("\r\n")
+	b.WriteString("Call-ID: " + d.callID + "\r\n")
+	b.WriteString("CSeq: " + strconv.Itoa(int(cseq)) + " " + method + "\r\n")
+	if local != "" && isTargetRefresh(method) {
+		b.WriteString("Contact: <" + local + ">\r\n")
+	}
+	for _, h := range hdrs {
+		b.WriteString(h.Name() + ": " + h.Value() + "\r\n")
+	}
+	b.WriteString("Content-Length: 0\r\n\r\n")
+
+	msg, err := sipmsg.MsgParse([]byte(b.String()))
+	if err != nil {
+		return nil, ErrorDialog.msg("failed to create %s request: %s", method, err)
+	}
+	return msg, nil
+}
+
+// validate checks that request and response can create dialog
+func validate(req, resp *sipmsg.Message) error {
+	if req == nil || !req.IsRequest() || resp == nil || !resp.IsResponse() {
+		return ErrorDialog.msg("request and response expected")
+	}
+	method := req.ReqLine.Method()
+	if method != "INVITE" && method != "SUBSCRIBE" {
+		return ErrorDialog.msg("%s can not create dialog", method)
+	}
+	if code := resp.Code(); code < 101 || code > 299 {
+		return ErrorDialog.msg("response %d can not create dialog", code)
+	}
+	if req.From == nil || req.To == nil || req.CSeq == nil || resp.To == nil {
+		return ErrorDialog.msg("missing From, To or CSeq header")
+	}
+	if resp.To.Tag() == "" {
+		return ErrorDialog.msg("response has no To tag")
+	}
+	return nil
+}
+
+func state(resp *sipmsg.Message) State {
+	if resp.Code() < 200 {
+		return Early
+	}
+	return Confirmed
+}
+
+// contact returns first Contact URI of the message
+func contact(msg *sipmsg.Message) string {
+	if msg.Contacts.Count() == 0 || msg.Contacts.IsStar() {
+		return ""
+	}
+	return msg.Contacts.First().Location()
+}
+
+// isTargetRefresh returns true if method can update remote target
+func isTargetRefresh(method string) bool {
+	switch method {
+	case "INVITE", "UPDATE", "SUBSCRIBE", "NOTIFY", "REFER":
+		return true
+	}
+	return false
+}
+
+func isSIPS(uri string) bool {
+	return len(uri) > 5 && strings.EqualFold(uri[:5], "sips:")
+}
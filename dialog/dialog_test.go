@@ -0,0 +1,272 @@
+package dialog
+
+import (
+	"testing"
+
+	"github.com/staskobzar/gosip/sipmsg"
+	"github.com/stretchr/testify/assert"
+)
+
+var invite = "INVITE sip:bob@biloxi.example.com SIP/2.0\r\n" +
+	"Via: SIP/2.0/UDP client.atlanta.example.com:5060;branch=z9hG4bK74bf9\r\n" +
+	"Max-Forwards: 70\r\n" +
+	"From: Alice <sip:alice@atlanta.example.com>;tag=9fxced76sl\r\n" +
+	"To: Bob <sip:bob@biloxi.example.com>\r\n" +
+	"Call-ID: 3848276298220188511@atlanta.example.com\r\n" +
+	"CSeq: 1 INVITE\r\n" +
+	"Contact: <sip:alice@client.atlanta.example.com>\r\n" +
+	"Content-Length: 0\r\n\r\n"
+
+var ringing = "SIP/2.0 180 Ringing\r\n" +
+	"Via: SIP/2.0/UDP client.atlanta.example.com:5060;branch=z9hG4bK74bf9\r\n" +
+	"Record-Route: <sip:ss2.biloxi.example.com;lr>\r\n" +
+	"Record-Route: <sip:ss1.atlanta.example.com;lr>\r\n" +
+	"From: Alice <sip:alice@atlanta.example.com>;tag=9fxced76sl\r\n" +
+	"To: Bob <sip:bob@biloxi.example.com>;tag=8321234356\r\n" +
+	"Call-ID: 3848276298220188511@atlanta.example.com\r\n" +
+	"CSeq: 1 INVITE\r\n" +
+	"Contact: <sip:bob@client.biloxi.example.com>\r\n" +
+	"Content-Length: 0\r\n\r\n"
+
+func parse(t *testing.T, str string) *sipmsg.Message {
+	msg, err := sipmsg.MsgParse([]byte(str))
+	assert.Nil(t, err)
+	return msg
+}
+
+func TestDialogNewUAC(t *testing.T) {
+	req := parse(t, invite)
+	d, err := NewUAC(req, parse(t, ringing))
+	assert.Nil(t, err)
+	assert.Equal(t, Early, d.State())
+	assert.True(t, d.IsUAC())
+	assert.False(t, d.IsSecure())
+	assert.Equal(t, "3848276298220188511@atlanta.example.com", d.CallID())
+	assert.Equal(t, "9fxced76sl", d.LocalTag())
+	assert.Equal(t, "8321234356", d.RemoteTag())
+	assert.Equal(t, "sip:alice@atlanta.example.com", d.LocalURI())
+	assert.Equal(t, "sip:bob@biloxi.example.com", d.RemoteURI())
+	assert.Equal(t, uint(1), d.LocalSeq())
+	assert.Equal(t, uint(0), d.RemoteSeq())
+	assert.Equal(t, "sip:bob@client.biloxi.example.com", d.RemoteTarget())
+	assert.Equal(t, []string{"sip:ss1.atlanta.example.com;lr", "sip:ss2.biloxi.example.com;lr"}, d.RouteSet())
+
+	// 2xx confirms dialog and recomputes route set
+	ok := "SIP/2.0 200 OK\r\n" +
+		"Via: SIP/2.0/UDP client.atlanta.example.com:5060;branch=z9hG4bK74bf9\r\n" +
+		"Record-Route: <sip:ss1.atlanta.example.com;lr>\r\n" +
+		"From: Alice <sip:alice@atlanta.example.com>;tag=9fxced76sl\r\n" +
+		"To: Bob <sip:bob@biloxi.example.com>;tag=8321234356\r\n" +
+		"Call-ID: 3848276298220188511@atlanta.example.com\r\n" +
+		"CSeq: 1 INVITE\r\n" +
+		"Contact: <sip:bob@192.0.2.4>\r\n" +
+		"Content-Length: 0\r\n\r\n"
+	assert.Nil(t, d.Update(parse(t, ok)))
+	assert.Equal(t, Confirmed, d.State())
+	assert.Equal(t, "sip:bob@192.0.2.4", d.RemoteTarget())
+	assert.Equal(t, []string{"sip:ss1.atlanta.example.com;lr"}, d.RouteSet())
+
+	// response of other dialog
+	assert.NotNil(t, d.Update(req))
+}
+
+func TestDialogNewUACTerminated(t *testing.T) {
+	d, err := NewUAC(parse(t, invite), parse(t, ringing))
+	assert.Nil(t, err)
+	busy := "SIP/2.0 486 Busy Here\r\n" +
+		"From: Alice <sip:alice@atlanta.example.com>;tag=9fxced76sl\r\n" +
+		"To: Bob <sip:bob@biloxi.example.com>;tag=8321234356\r\n" +
+		"Call-ID: 3848276298220188511@atlanta.example.com\r\n" +
+		"CSeq: 1 INVITE\r\n\r\n"
+	assert.Nil(t, d.Update(parse(t, busy)))
+	assert.Equal(t, Terminated, d.State())
+	_, err = d.NewRequest("BYE", nil)
+	assert.NotNil(t, err)
+}
+
+func TestDialogNewError(t *testing.T) {
+	req := parse(t, invite)
+	resp, _ := req.NewResponse(180, "Ringing")
+	// no To tag
+	_, err := NewUAC(req, resp)
+	assert.NotNil(t, err)
+
+	resp.AddToTag()
+	_, err = NewUAS(req, resp)
+	assert.Nil(t, err)
+
+	trying, _ := req.NewResponse(100, "Trying")
+	_, err = NewUAS(req, trying)
+	assert.NotNil(t, err)
+
+	_, err = NewUAC(resp, req)
+	assert.NotNil(t, err)
+
+	bye := parse(t, "BYE sip:bob@biloxi.example.com SIP/2.0\r\n"+
+		"From: <sip:alice@atlanta.example.com>;tag=1\r\n"+
+		"To: <sip:bob@biloxi.example.com>\r\n"+
+		"Call-ID: 1@atlanta.example.com\r\n"+
+		"CSeq: 1 BYE\r\n\r\n")
+	resp, _ = bye.NewResponse(200, "OK")
+	resp.AddToTag()
+	_, err = NewUAS(bye, resp)
+	assert.NotNil(t, err)
+}
+
+func TestDialogNewUAS(t *testing.T) {
+	req := parse(t, "INVITE sips:bob@biloxi.example.com SIP/2.0\r\n"+
+		"Via: SIP/2.0/TLS ss2.biloxi.example.com:5061;branch=z9hG4bK721e4\r\n"+
+		"Via: SIP/2.0/TLS client.atlanta.example.com:5061;branch=z9hG4bK74bf9\r\n"+
+		"Record-Route: <sip:ss2.biloxi.example.com;lr>\r\n"+
+		"Record-Route: <sip:ss1.atlanta.example.com;lr>\r\n"+
+		"From: Alice <sips:alice@atlanta.example.com>;tag=9fxced76sl\r\n"+
+		"To: Bob <sips:bob@biloxi.example.com>\r\n"+
+		"Call-ID: 3848276298220188511@atlanta.example.com\r\n"+
+		"CSeq: 10 INVITE\r\n"+
+		"Contact: <sips:alice@client.atlanta.example.com>\r\n\r\n")
+	resp, _ := req.NewResponse(200, "OK")
+	resp.AddToTag()
+	resp.AddHeader("Contact", "<sips:bob@client.biloxi.example.com>")
+
+	d, err := NewUAS(req, resp)
+	assert.Nil(t, err)
+	assert.Equal(t, Confirmed, d.State())
+	assert.False(t, d.IsUAC())
+	assert.True(t, d.IsSecure())
+	assert.Equal(t, resp.To.Tag(), d.LocalTag())
+	assert.Equal(t, "9fxced76sl", d.RemoteTag())
+	assert.Equal(t, uint(10), d.RemoteSeq())
+	assert.Equal(t, "sips:alice@client.atlanta.example.com", d.RemoteTarget())
+	assert.Equal(t, []string{"sip:ss2.biloxi.example.com;lr", "sip:ss1.atlanta.example.com;lr"}, d.RouteSet())
+
+	bye, err := d.NewRequest("BYE", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "BYE sips:alice@client.atlanta.example.com SIP/2.0\r\n"+
+		"Max-Forwards: 70\r\n"+
+		"Route: <sip:ss2.biloxi.example.com;lr>\r\n"+
+		"Route: <sip:ss1.atlanta.example.com;lr>\r\n"+
+		"From: <sips:bob@biloxi.example.com>;tag="+d.LocalTag()+"\r\n"+
+		"To: <sips:alice@atlanta.example.com>;tag=9fxced76sl\r\n"+
+		"Call-ID: 3848276298220188511@atlanta.example.com\r\n"+
+		"CSeq: 1 BYE\r\n"+
+		"Content-Length: 0\r\n\r\n", bye.String())
+	assert.Equal(t, Terminated, d.State())
+}
+
+func TestDialogMatch(t *testing.T) {
+	d, err := NewUAC(parse(t, invite), parse(t, ringing))
+	assert.Nil(t, err)
+	assert.Equal(t, "3848276298220188511@atlanta.example.com|9fxced76sl|8321234356", d.ID())
+	assert.True(t, d.Match(parse(t, ringing)))
+
+	info := "INFO sip:alice@client.atlanta.example.com SIP/2.0\r\n" +
+		"From: Bob <sip:bob@biloxi.example.com>;tag=8321234356\r\n" +
+		"To: Alice <sip:alice@atlanta.example.com>;tag=9fxced76sl\r\n" +
+		"Call-ID: 3848276298220188511@atlanta.example.com\r\n" +
+		"CSeq: 5 INFO\r\n\r\n"
+	assert.True(t, d.Match(parse(t, info)))
+	assert.Equal(t, d.ID(), MsgKey(parse(t, info)))
+	assert.False(t, d.Match(parse(t, invite)))
+}
+
+func TestDialogRecv(t *testing.T) {
+	d, err := NewUAC(parse(t, invite), parse(t, ringing))
+	assert.Nil(t, err)
+
+	reinvite := "INVITE sip:alice@client.atlanta.example.com SIP/2.0\r\n" +
+		"From: Bob <sip:bob@biloxi.example.com>;tag=8321234356\r\n" +
+		"To: Alice <sip:alice@atlanta.example.com>;tag=9fxced76sl\r\n" +
+		"Call-ID: 3848276298220188511@atlanta.example.com\r\n" +
+		"CSeq: 5 INVITE\r\n" +
+		"Contact: <sip:bob@192.0.2.100>\r\n\r\n"
+	assert.Nil(t, d.Recv(parse(t, reinvite)))
+	assert.Equal(t, uint(5), d.RemoteSeq())
+	assert.Equal(t, "sip:bob@192.0.2.100", d.RemoteTarget())
+
+	// out of order CSeq
+	assert.NotNil(t, d.Recv(parse(t, reinvite)))
+	// other dialog
+	assert.NotNil(t, d.Recv(parse(t, invite)))
+
+	bye := "BYE sip:alice@client.atlanta.example.com SIP/2.0\r\n" +
+		"From: Bob <sip:bob@biloxi.example.com>;tag=8321234356\r\n" +
+		"To: Alice <sip:alice@atlanta.example.com>;tag=9fxced76sl\r\n" +
+		"Call-ID: 3848276298220188511@atlanta.example.com\r\n" +
+		"CSeq: 6 BYE\r\n\r\n"
+	assert.Nil(t, d.Recv(parse(t, bye)))
+	assert.Equal(t, Terminated, d.State())
+	assert.NotNil(t, d.Recv(parse(t, bye)))
+}
+
+func TestDialogNewRequest(t *testing.T) {
+	d, err := NewUAC(parse(t, invite), parse(t, ringing))
+	assert.Nil(t, err)
+
+	via, _ := sipmsg.NewHdrVia("UDP", "client.atlanta.example.com", 5060, nil)
+	req, err := d.NewRequest("INVITE", via)
+	assert.Nil(t, err)
+	assert.Equal(t, "INVITE sip:bob@client.biloxi.example.com SIP/2.0\r\n"+
+		"Via: SIP/2.0/UDP client.atlanta.example.com:5060;branch="+via.Branch()+"\r\n"+
+		"Max-Forwards: 70\r\n"+
+		"Route: <sip:ss1.atlanta.example.com;lr>\r\n"+
+		"Route: <sip:ss2.biloxi.example.com;lr>\r\n"+
+		"From: <sip:alice@atlanta.example.com>;tag=9fxced76sl\r\n"+
+		"To: <sip:bob@biloxi.example.com>;tag=8321234356\r\n"+
+		"Call-ID: 3848276298220188511@atlanta.example.com\r\n"+
+		"CSeq: 2 INVITE\r\n"+
+		"Contact: <sip:alice@client.atlanta.example.com>\r\n"+
+		"Content-Length: 0\r\n\r\n", req.String())
+
+	req, err = d.NewRequest("INFO", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, uint(3), req.CSeq.Num)
+	assert.Equal(t, 0, req.Contacts.Count())
+}
+
+func TestDialogStrictRoute(t *testing.T) {
+	resp := "SIP/2.0 200 OK\r\n" +
+		"Record-Route: <sip:p2.biloxi.example.com;lr>\r\n" +
+		"Record-Route: <sip:p1.atlanta.example.com>\r\n" +
+		"From: Alice <sip:alice@atlanta.example.com>;tag=9fxced76sl\r\n" +
+		"To: Bob <sip:bob@biloxi.example.com>;tag=8321234356\r\n" +
+		"Call-ID: 3848276298220188511@atlanta.example.com\r\n" +
+		"CSeq: 1 INVITE\r\n" +
+		"Contact: <sip:bob@client.biloxi.example.com>\r\n\r\n"
+	d, err := NewUAC(parse(t, invite), parse(t, resp))
+	assert.Nil(t, err)
+
+	req, err := d.NewRequest("BYE", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "sip:p1.atlanta.example.com", req.ReqLine.RequestURI())
+	assert.Equal(t, 2, req.Routes.Count())
+	assert.Equal(t, "sip:p2.biloxi.example.com;lr", req.Routes[0].Addr())
+	assert.Equal(t, "sip:bob@client.biloxi.example.com", req.Routes[1].Addr())
+}
+
+func TestDialogNewACK(t *testing.T) {
+	req := parse(t, invite)
+	req.AddHeader("Proxy-Authorization", `Digest username="alice", realm="atlanta.example.com", nonce="wf84f1ce", uri="sip:bob@biloxi.example.com", response="42ce3cef44b22f50c6a6071bc8"`)
+	d, err := NewUAC(req, parse(t, ringing))
+	assert.Nil(t, err)
+
+	via, _ := sipmsg.NewHdrVia("UDP", "client.atlanta.example.com", 5060, nil)
+	ack, err := d.NewACK(req, via)
+	assert.Nil(t, err)
+	assert.Equal(t, "ACK sip:bob@client.biloxi.example.com SIP/2.0\r\n"+
+		"Via: SIP/2.0/UDP client.atlanta.example.com:5060;branch="+via.Branch()+"\r\n"+
+		"Max-Forwards: 70\r\n"+
+		"Route: <sip:ss1.atlanta.example.com;lr>\r\n"+
+		"Route: <sip:ss2.biloxi.example.com;lr>\r\n"+
+		"From: <sip:alice@atlanta.example.com>;tag=9fxced76sl\r\n"+
+		"To: <sip:bob@biloxi.example.com>;tag=8321234356\r\n"+
+		"Call-ID: 3848276298220188511@atlanta.example.com\r\n"+
+		"CSeq: 1 ACK\r\n"+
+		`Proxy-Authorization: Digest username="alice", realm="atlanta.example.com", nonce="wf84f1ce", uri="sip:bob@biloxi.example.com", response="42ce3cef44b22f50c6a6071bc8"`+"\r\n"+
+		"Content-Length: 0\r\n\r\n", ack.String())
+	assert.NotEqual(t, req.Vias[0].Branch(), ack.Vias[0].Branch())
+	// local sequence is not changed
+	assert.Equal(t, uint(1), d.LocalSeq())
+
+	_, err = d.NewACK(ack, via)
+	assert.NotNil(t, err)
+}
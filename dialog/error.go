@@ -0,0 +1,23 @@
+package dialog
+
+import "fmt"
+
+type dialogError struct {
+	s string
+	e string
+}
+
+func errorNew(ctx string) *dialogError {
+	return &dialogError{s: ctx}
+}
+
+// msg returns new error of the same context. Package errors are
+// shared by dialogs running concurrently and are not modified.
+func (e *dialogError) msg(msg string, args ...interface{}) *dialogError {
+	txt := fmt.Sprintf(msg, args...)
+	return &dialogError{s: e.s, e: ": " + txt}
+}
+
+func (e *dialogError) Error() string {
+	return e.s + e.e
+}
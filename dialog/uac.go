@@ -0,0 +1,110 @@
+package dialog
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/staskobzar/gosip/sipmsg"
+	"github.com/staskobzar/gosip/transp"
+	"github.com/staskobzar/gosip/txn"
+)
+
+// time to keep ACK for 2xx retransmissions (64*T1)
+const ackTimeout = 64 * 500 * time.Millisecond
+
+// UACore UAC core part that owns ACKs for 2xx responses to INVITE.
+// 2xx retransmissions are not handled by client transaction that is
+// terminated on 2xx, so UAC core re-sends ACK (RFC3261#13.2.2.4).
+type UACore struct {
+	mux     *sync.Mutex
+	acks    map[string]*sentACK
+	send    func(msg *sipmsg.Message, addr *transp.Addr) error
+	timeout time.Duration
+}
+
+type sentACK struct {
+	msg     *sipmsg.Message
+	addr    *transp.Addr
+	expires time.Time
+}
+
+// NewUACore creates UAC core with transport send function
+func NewUACore(send func(msg *sipmsg.Message, addr *transp.Addr) error) *UACore {
+	return &UACore{
+		mux:     &sync.Mutex{},
+		acks:    make(map[string]*sentACK),
+		send:    send,
+		timeout: ackTimeout,
+	}
+}
+
+// SendACK sends ACK for 2xx response and keeps it to answer
+// 2xx retransmissions
+func (u *UACore) SendACK(ack *sipmsg.Message, addr *transp.Addr) error {
+	if ack == nil || !ack.IsRequest() || ack.ReqLine.Method() != "ACK" {
+		return ErrorDialog.msg("ACK request expected")
+	}
+	if ack.Vias.Count() == 0 {
+		return ErrorDialog.msg("ACK has no Via header")
+	}
+	key := ackKey(ack)
+	if key == "" {
+		return ErrorDialog.msg("invalid ACK request")
+	}
+	u.mux.Lock()
+	u.sweep()
+	u.acks[key] = &sentACK{ack, addr, time.Now().Add(u.timeout)}
+	u.mux.Unlock()
+	return u.send(ack, addr)
+}
+
+// Recv re-sends ACK if response is retransmission of 2xx to INVITE
+// that was already acknowledged. Returns true if response was handled.
+func (u *UACore) Recv(resp *sipmsg.Message) bool {
+	if resp == nil || !resp.IsResponse() || resp.CSeq == nil ||
+		resp.CSeq.Method != "INVITE" || resp.Code()/100 != 2 {
+		return false
+	}
+	u.mux.Lock()
+	u.sweep()
+	ack, ok := u.acks[ackKey(resp)]
+	u.mux.Unlock()
+	if !ok {
+		return false
+	}
+	u.send(ack.msg, ack.addr)
+	return true
+}
+
+// Handle passes unmatched response from transaction layer.
+// Can be used as unmatched callback of txn.NewLayer.
+func (u *UACore) Handle(tm *txn.Message) {
+	u.Recv(tm.Msg)
+}
+
+// Len returns number of stored ACKs
+func (u *UACore) Len() int {
+	u.mux.Lock()
+	defer u.mux.Unlock()
+	return len(u.acks)
+}
+
+// sweep removes expired ACKs. Must be called with mutex locked.
+func (u *UACore) sweep() {
+	now := time.Now()
+	for key, ack := range u.acks {
+		if now.After(ack.expires) {
+			delete(u.acks, key)
+		}
+	}
+}
+
+// ackKey ACK and 2xx response matching key from dialog ID and CSeq number
+func ackKey(msg *sipmsg.Message) string {
+	if msg.From == nil || msg.To == nil || msg.CSeq == nil {
+		return ""
+	}
+	return Key(msg.CallID, msg.From.Tag(), msg.To.Tag()) + "|" +
+		strconv.Itoa(int(msg.CSeq.Num))
+}
@@ -0,0 +1,84 @@
+package dialog
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/staskobzar/gosip/sipmsg"
+	"github.com/staskobzar/gosip/transp"
+	"github.com/staskobzar/gosip/txn"
+	"github.com/stretchr/testify/assert"
+)
+
+var ok200 = "SIP/2.0 200 OK\r\n" +
+	"Via: SIP/2.0/UDP client.atlanta.example.com:5060;branch=z9hG4bK74bf9\r\n" +
+	"From: Alice <sip:alice@atlanta.example.com>;tag=9fxced76sl\r\n" +
+	"To: Bob <sip:bob@biloxi.example.com>;tag=8321234356\r\n" +
+	"Call-ID: 3848276298220188511@atlanta.example.com\r\n" +
+	"CSeq: 1 INVITE\r\n" +
+	"Contact: <sip:bob@client.biloxi.example.com>\r\n" +
+	"Content-Length: 0\r\n\r\n"
+
+func TestDialogUACore(t *testing.T) {
+	var sent []*sipmsg.Message
+	var addrs []*transp.Addr
+	uac := NewUACore(func(msg *sipmsg.Message, addr *transp.Addr) error {
+		sent = append(sent, msg)
+		addrs = append(addrs, addr)
+		return nil
+	})
+
+	req := parse(t, invite)
+	resp := parse(t, ok200)
+	d, err := NewUAC(req, resp)
+	assert.Nil(t, err)
+
+	// 2xx before ACK is sent is passed to TU
+	assert.False(t, uac.Recv(resp))
+
+	ack, err := d.NewACK(req, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, ack.Vias.Count())
+	assert.Equal(t, req.Vias[0].Host(), ack.Vias[0].Host())
+	assert.True(t, strings.HasPrefix(ack.Vias[0].Branch(), sipmsg.BranchCookie))
+	assert.NotEqual(t, req.Vias[0].Branch(), ack.Vias[0].Branch())
+	addr := transp.UDPAddr("192.0.2.4:5060")
+	assert.Nil(t, uac.SendACK(ack, addr))
+	assert.Equal(t, 1, len(sent))
+	assert.Equal(t, 1, uac.Len())
+
+	// retransmitted 2xx
+	uac.Handle(&txn.Message{Msg: parse(t, ok200), Addr: addr})
+	assert.Equal(t, 2, len(sent))
+	assert.Equal(t, ack, sent[1])
+	assert.Equal(t, addr, addrs[1])
+
+	// other responses are not handled
+	ringing := parse(t, ringing)
+	assert.False(t, uac.Recv(ringing))
+	assert.False(t, uac.Recv(req))
+	assert.Equal(t, 2, len(sent))
+
+	assert.NotNil(t, uac.SendACK(req, addr))
+
+	// ACK without Via
+	noVia := ack.Clone()
+	noVia.PopVia()
+	assert.NotNil(t, uac.SendACK(noVia, addr))
+}
+
+func TestDialogUACoreExpire(t *testing.T) {
+	uac := NewUACore(func(msg *sipmsg.Message, addr *transp.Addr) error { return nil })
+	uac.timeout = 10 * time.Millisecond
+
+	req := parse(t, invite)
+	resp := parse(t, ok200)
+	d, _ := NewUAC(req, resp)
+	ack, _ := d.NewACK(req, nil)
+	assert.Nil(t, uac.SendACK(ack, nil))
+
+	time.Sleep(20 * time.Millisecond)
+	assert.False(t, uac.Recv(resp))
+	assert.Equal(t, 0, uac.Len())
+}
@@ -0,0 +1,23 @@
+package proxy
+
+import "fmt"
+
+type proxyError struct {
+	s string
+	e string
+}
+
+func errorNew(ctx string) *proxyError {
+	return &proxyError{s: ctx}
+}
+
+// msg returns new error of the same context. Package errors are
+// shared by proxy contexts running concurrently and are not modified.
+func (e *proxyError) msg(msg string, args ...interface{}) *proxyError {
+	txt := fmt.Sprintf(msg, args...)
+	return &proxyError{s: e.s, e: ": " + txt}
+}
+
+func (e *proxyError) Error() string {
+	return e.s + e.e
+}
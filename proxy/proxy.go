@@ -0,0 +1,334 @@
+// Package proxy SIP proxy core RFC3261#section-16
+package proxy
+
+import (
+	"crypto/md5"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/staskobzar/gosip/sipmsg"
+	"github.com/staskobzar/gosip/transp"
+)
+
+// ErrorProxy proxy error
+var ErrorProxy = errorNew("Proxy")
+
+// default Max-Forwards of forwarded requests (RFC3261#16.6)
+const defaultMaxFwd = 70
+
+// SendFunc sends SIP message to address with transport layer
+type SendFunc func(msg *sipmsg.Message, addr *transp.Addr) error
+
+// ResolveFunc returns ordered list of addresses of the URI (RFC3263)
+type ResolveFunc func(uri *sipmsg.URI) ([]*transp.Addr, error)
+
+// Locator returns targets of the Request-URI that belongs to the domain
+// proxy is responsible for (RFC3261#16.5). Returns false if address is
+// not known to location service.
+type Locator func(ruri string) ([]string, bool)
+
+// Proxy core that validates, routes and forwards requests. It is
+// used by stateless and transaction stateful proxies.
+type Proxy struct {
+	host    string
+	port    int
+	domains map[string]bool
+	rroute  bool
+	locate  Locator
+	resolve ResolveFunc
+}
+
+// request received request prepared for forwarding
+type request struct {
+	msg  *sipmsg.Message
+	fwd  *sipmsg.Message // copy of the request with pre-processed routing
+	ruri string
+	hash string
+}
+
+func newProxy(host string, port int) *Proxy {
+	return &Proxy{
+		host:    host,
+		port:    port,
+		domains: make(map[string]bool),
+		resolve: transp.NewResolver(nil).Resolve,
+	}
+}
+
+// AddDomain adds domain the proxy is responsible for. Targets
+// of the requests to the domain are provided by locator.
+func (p *Proxy) AddDomain(domain string) {
+	p.domains[strings.ToLower(domain)] = true
+}
+
+// SetLocator sets location service callback
+func (p *Proxy) SetLocator(locate Locator) {
+	p.locate = locate
+}
+
+// SetResolver sets callback that resolves next hop URI to address
+func (p *Proxy) SetResolver(resolve ResolveFunc) {
+	p.resolve = resolve
+}
+
+// SetRecordRoute enables Record-Route insertion to stay
+// on the path of subsequent requests in dialog
+func (p *Proxy) SetRecordRoute(on bool) {
+	p.rroute = on
+}
+
+// prepare validates request (RFC3261#16.3) and pre-processes
+// routing information (RFC3261#16.4). If request can not be
+// forwarded then returns response code and reason.
+func (p *Proxy) prepare(msg *sipmsg.Message) (*request, int, string) {
+	if msg.Vias.Count() == 0 || msg.CSeq == nil || msg.From == nil ||
+		msg.To == nil || msg.CallID == "" {
+		return nil, 400, "Bad Request"
+	}
+	uri := sipmsg.URIParse([]byte(msg.ReqLine.RequestURI()))
+	if uri == nil {
+		return nil, 400, "Bad Request"
+	}
+	if uri.ID() != sipmsg.URIsip && uri.ID() != sipmsg.URIsips {
+		return nil, 416, "Unsupported URI Scheme"
+	}
+	if msg.Headers.Find(sipmsg.SIPHdrMaxForwards) != nil && msg.MaxFwd == 0 {
+		return nil, 483, "Too Many Hops"
+	}
+	r := &request{
+		msg:  msg,
+		fwd:  msg.Clone(),
+		ruri: msg.ReqLine.RequestURI(),
+		hash: loopHash(msg),
+	}
+	for _, via := range msg.Vias {
+		if p.isOwnVia(via) && strings.HasSuffix(via.Branch(), "."+r.hash) {
+			return nil, 482, "Loop Detected"
+		}
+	}
+	if msg.Headers.Find(sipmsg.SIPHdrProxyRequire) != nil {
+		return nil, 420, "Bad Extension"
+	}
+
+	// previous hop is strict router and Request-URI is the value
+	// placed by the proxy to Record-Route
+	if p.isSelf(uri) && msg.Routes.Count() > 0 {
+		route, err := r.fwd.PopLastRoute()
+		if err != nil || r.fwd.SetRequestURI(route.Addr()) != nil {
+			return nil, 400, "Bad Request"
+		}
+		r.ruri = route.Addr()
+	}
+	if r.fwd.Routes.Count() > 0 && p.isSelf(r.fwd.Routes[0].AddrURI()) {
+		if _, err := r.fwd.PopRoute(); err != nil {
+			return nil, 400, "Bad Request"
+		}
+	}
+	return r, 0, ""
+}
+
+// targets returns target set of the request (RFC3261#16.5). If
+// target set is empty then returns response code and reason.
+func (p *Proxy) targets(r *request) ([]string, int, string) {
+	if r.fwd.Routes.Count() > 0 {
+		return []string{r.ruri}, 0, ""
+	}
+	uri := sipmsg.URIParse([]byte(r.ruri))
+	if uri == nil {
+		return nil, 400, "Bad Request"
+	}
+	if !p.domains[strings.ToLower(uri.Host())] && !p.isSelf(uri) {
+		return []string{r.ruri}, 0, ""
+	}
+	if p.locate == nil {
+		return nil, 404, "Not Found"
+	}
+	targets, ok := p.locate(r.ruri)
+	if !ok {
+		return nil, 404, "Not Found"
+	}
+	if len(targets) == 0 {
+		return nil, 480, "Temporarily Unavailable"
+	}
+	return targets, 0, ""
+}
+
+// forward creates request forwarded to the target (RFC3261#16.6)
+// and returns it with the next hop address
+func (p *Proxy) forward(r *request, target, branch string) (*sipmsg.Message, *transp.Addr, error) {
+	msg := r.fwd.Clone()
+	if err := msg.SetRequestURI(target); err != nil {
+		return nil, nil, err
+	}
+
+	maxfwd := uint(defaultMaxFwd)
+	if r.msg.Headers.Find(sipmsg.SIPHdrMaxForwards) != nil {
+		maxfwd = r.msg.MaxFwd - 1
+	}
+	msg.SetMaxForwards(maxfwd)
+
+	if p.rroute && r.msg.ReqLine.Method() != "REGISTER" {
+		if err := msg.PushRecordRoute(p.recordRoute(target)); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	next := target
+	if msg.Routes.Count() > 0 {
+		top := msg.Routes[0]
+		next = top.Addr()
+		uri := top.AddrURI()
+		if uri == nil {
+			return nil, nil, ErrorProxy.msg("invalid Route %q", next)
+		}
+		// next hop is strict router
+		if _, lr := uri.Param("lr"); !lr {
+			if err := msg.AppendRoute(target); err != nil {
+				return nil, nil, err
+			}
+			if _, err := msg.PopRoute(); err != nil {
+				return nil, nil, err
+			}
+			if err := msg.SetRequestURI(next); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
+	addrs, err := p.resolve(sipmsg.URIParse([]byte(next)))
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(addrs) == 0 {
+		return nil, nil, ErrorProxy.msg("no address for %q", next)
+	}
+	addr := addrs[0]
+	if _, err := msg.PushVia(addr.Proto().String(), p.host, uint(p.port), branch); err != nil {
+		return nil, nil, err
+	}
+	return msg, addr, nil
+}
+
+// response removes proxy Via from the response (RFC3261#16.7)
+func (p *Proxy) response(resp *sipmsg.Message) (*sipmsg.Message, error) {
+	if resp.Vias.Count() < 2 || !p.isOwnVia(resp.Vias[0]) {
+		return nil, ErrorProxy.msg("response top Via is not proxy Via")
+	}
+	fwd := resp.Clone()
+	if _, err := fwd.PopVia(); err != nil {
+		return nil, err
+	}
+	return fwd, nil
+}
+
+// branch returns Via branch of forwarded request. Loop detection
+// hash of the request is appended to the branch (RFC3261#16.6 step 8).
+func (r *request) branch(id string) string {
+	return id + "." + r.hash
+}
+
+func (p *Proxy) recordRoute(target string) string {
+	scheme := "sip"
+	if uri := sipmsg.URIParse([]byte(target)); uri != nil && uri.ID() == sipmsg.URIsips {
+		scheme = "sips"
+	}
+	return fmt.Sprintf("<%s:%s:%d;lr>", scheme, p.host, p.port)
+}
+
+// isSelf returns true if URI is the address of the proxy
+func (p *Proxy) isSelf(uri *sipmsg.URI) bool {
+	if uri == nil || !strings.EqualFold(uri.Host(), p.host) {
+		return false
+	}
+	port := 5060
+	if uri.ID() == sipmsg.URIsips {
+		port = 5061
+	}
+	if uri.Port() != "" {
+		port, _ = strconv.Atoi(uri.Port())
+	}
+	return port == p.port
+}
+
+// isOwnVia returns true if Via sent-by is the proxy address
+func (p *Proxy) isOwnVia(via *sipmsg.Via) bool {
+	if !strings.EqualFold(via.Host(), p.host) {
+		return false
+	}
+	port := 5060
+	if strings.EqualFold(via.Transport(), "TLS") {
+		port = 5061
+	}
+	if via.Port() != "" {
+		port, _ = strconv.Atoi(via.Port())
+	}
+	return port == p.port
+}
+
+// loopHash returns hash of the request fields that do not change
+// when request loops back to the proxy (RFC3261#16.3 step 4). To tag
+// and method are not used so that CANCEL and ACK of non-2xx response
+// have the same hash as INVITE.
+func loopHash(msg *sipmsg.Message) string {
+	h := md5.New()
+	fmt.Fprintf(h, "%s|%s|%s|%d", msg.ReqLine.RequestURI(),
+		msg.From.Tag(), msg.CallID, msg.CSeq.Num)
+	msg.Headers.ForEach(func(hdr *sipmsg.Header) {
+		switch hdr.ID() {
+		case sipmsg.SIPHdrRoute, sipmsg.SIPHdrProxyRequire, sipmsg.SIPHdrProxyAuthorization:
+			fmt.Fprintf(h, "|%s", strings.TrimSpace(hdr.Value()))
+		}
+	})
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
+
+// viaAddr returns address the response is sent to from
+// the Via header (RFC3261#18.2.2, RFC3581#4)
+func viaAddr(via *sipmsg.Via) *transp.Addr {
+	host := via.Host()
+	if received := via.Received(); received != "" {
+		host = received
+	}
+	proto := strings.ToUpper(via.Transport())
+	port := via.Port()
+	if rport, ok := via.Param("rport"); ok && rport != "" {
+		port = rport
+	}
+	if port == "" {
+		port = "5060"
+		if proto == "TLS" {
+			port = "5061"
+		}
+	}
+	address := net.JoinHostPort(strings.Trim(host, "[]"), port)
+	switch proto {
+	case "TCP":
+		return transp.TCPAddr(address)
+	case "TLS":
+		return transp.TLSAddr(address)
+	case "WS":
+		return transp.WSAddr(address)
+	case "WSS":
+		return transp.WSSAddr(address)
+	}
+	return transp.UDPAddr(address)
+}
+
+// newResponse creates response to request with To tag
+// and Unsupported headers for 420 response
+func newResponse(req *sipmsg.Message, code int, reason string) (*sipmsg.Message, error) {
+	resp, err := req.NewResponseTag(code, reason)
+	if err != nil {
+		return nil, err
+	}
+	if code == 420 {
+		for _, h := range req.Headers.FindAll(sipmsg.SIPHdrProxyRequire) {
+			if err := resp.AddHeader("Unsupported", strings.TrimSpace(h.Value())); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return resp, nil
+}
@@ -0,0 +1,222 @@
+package proxy
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/staskobzar/gosip/sipmsg"
+	"github.com/staskobzar/gosip/transp"
+	"github.com/stretchr/testify/assert"
+)
+
+type sent struct {
+	msg  *sipmsg.Message
+	addr *transp.Addr
+}
+
+func parseMsg(t *testing.T, lines ...string) *sipmsg.Message {
+	msg, err := sipmsg.MsgParse([]byte(strings.Join(lines, "\r\n") + "\r\n\r\n"))
+	assert.Nil(t, err)
+	return msg
+}
+
+func invite(t *testing.T, ruri string, hdrs ...string) *sipmsg.Message {
+	lines := []string{
+		"INVITE " + ruri + " SIP/2.0",
+		"Via: SIP/2.0/UDP pc33.atlanta.com;branch=z9hG4bK776asdhds;received=192.0.2.101",
+		"Max-Forwards: 70",
+		"To: Bob <sip:bob@biloxi.com>",
+		"From: Alice <sip:alice@atlanta.com>;tag=1928301774",
+		"Call-ID: a84b4c76e66710@pc33.atlanta.com",
+		"CSeq: 314159 INVITE",
+		"Contact: <sip:alice@pc33.atlanta.com>",
+	}
+	lines = append(lines, hdrs...)
+	return parseMsg(t, append(lines, "Content-Length: 0")...)
+}
+
+// resolver maps host of URI to address
+func resolver(hosts map[string]string) ResolveFunc {
+	return func(uri *sipmsg.URI) ([]*transp.Addr, error) {
+		addr, ok := hosts[uri.Host()]
+		if !ok {
+			return nil, ErrorProxy.msg("unknown host %s", uri.Host())
+		}
+		return []*transp.Addr{transp.UDPAddr(addr)}, nil
+	}
+}
+
+func newStateless(t *testing.T) (*Stateless, *[]sent) {
+	out := make([]sent, 0)
+	s := NewStateless("proxy.atlanta.com", 5060, func(msg *sipmsg.Message, addr *transp.Addr) error {
+		out = append(out, sent{msg, addr})
+		return nil
+	})
+	s.SetResolver(resolver(map[string]string{
+		"biloxi.com":        "10.0.0.2:5060",
+		"ss1.biloxi.com":    "10.0.0.3:5060",
+		"strict.biloxi.com": "10.0.0.4:5060",
+		"pc33.biloxi.com":   "10.0.0.5:5060",
+	}))
+	return s, &out
+}
+
+func TestProxyStatelessForward(t *testing.T) {
+	s, out := newStateless(t)
+	s.SetRecordRoute(true)
+	src := transp.UDPAddr("192.0.2.101:5060")
+
+	req := invite(t, "sip:bob@biloxi.com", "Route: <sip:proxy.atlanta.com;lr>")
+	assert.Nil(t, s.Recv(req, src))
+	assert.Len(t, *out, 1)
+	fwd := (*out)[0]
+	assert.Equal(t, "10.0.0.2:5060", fwd.addr.String())
+	assert.Equal(t, "sip:bob@biloxi.com", fwd.msg.ReqLine.RequestURI())
+	assert.EqualValues(t, 69, fwd.msg.MaxFwd)
+	assert.Equal(t, 0, fwd.msg.Routes.Count())
+	assert.Equal(t, 1, fwd.msg.RecRoutes.Count())
+	assert.Equal(t, "sip:proxy.atlanta.com:5060;lr", fwd.msg.RecRoutes[0].Addr())
+	assert.Equal(t, 2, fwd.msg.Vias.Count())
+	assert.Equal(t, "proxy.atlanta.com", fwd.msg.Vias[0].Host())
+	assert.Equal(t, "UDP", fwd.msg.Vias[0].Transport())
+	assert.True(t, strings.HasPrefix(fwd.msg.Vias[0].Branch(), sipmsg.BranchCookie))
+
+	// retransmission and CANCEL have the same branch
+	assert.Nil(t, s.Recv(req, src))
+	assert.Equal(t, fwd.msg.Vias[0].Branch(), (*out)[1].msg.Vias[0].Branch())
+	cancel, _ := req.NewCANCEL()
+	assert.Nil(t, s.Recv(cancel, src))
+	assert.Equal(t, fwd.msg.Vias[0].Branch(), (*out)[2].msg.Vias[0].Branch())
+
+	// response is sent to the address of the next Via
+	resp, _ := fwd.msg.NewResponse(180, "Ringing")
+	assert.Nil(t, s.Recv(resp, nil))
+	assert.Len(t, *out, 4)
+	back := (*out)[3]
+	assert.Equal(t, "192.0.2.101:5060", back.addr.String())
+	assert.Equal(t, 1, back.msg.Vias.Count())
+	assert.Equal(t, "z9hG4bK776asdhds", back.msg.Vias[0].Branch())
+
+	// response without proxy Via is dropped
+	assert.NotNil(t, s.Recv(back.msg, nil))
+}
+
+func TestProxyStatelessReject(t *testing.T) {
+	s, out := newStateless(t)
+	src := transp.UDPAddr("192.0.2.101:5060")
+	code := func() int {
+		last := (*out)[len(*out)-1]
+		assert.Equal(t, src.String(), last.addr.String())
+		return last.msg.Code()
+	}
+
+	req := invite(t, "sip:bob@biloxi.com")
+	req.MaxFwd = 0
+	assert.Nil(t, s.Recv(req, src))
+	assert.Equal(t, 483, code())
+
+	assert.Nil(t, s.Recv(invite(t, "tel:+15551234"), src))
+	assert.Equal(t, 416, code())
+
+	assert.Nil(t, s.Recv(invite(t, "sip:bob@biloxi.com", "Proxy-Require: foo"), src))
+	assert.Equal(t, 420, code())
+	assert.NotNil(t, (*out)[len(*out)-1].msg.Headers.Find(sipmsg.SIPHdrUnsupported))
+
+	// domain of the proxy without location service
+	s.AddDomain("atlanta.com")
+	assert.Nil(t, s.Recv(invite(t, "sip:carol@atlanta.com"), src))
+	assert.Equal(t, 404, code())
+
+	s.SetLocator(func(ruri string) ([]string, bool) {
+		if ruri == "sip:carol@atlanta.com" {
+			return nil, true
+		}
+		return []string{"sip:dave@pc33.biloxi.com"}, ruri == "sip:dave@atlanta.com"
+	})
+	assert.Nil(t, s.Recv(invite(t, "sip:carol@atlanta.com"), src))
+	assert.Equal(t, 480, code())
+	assert.Nil(t, s.Recv(invite(t, "sip:erin@atlanta.com"), src))
+	assert.Equal(t, 404, code())
+	assert.Nil(t, s.Recv(invite(t, "sip:dave@atlanta.com"), src))
+	fwd := (*out)[len(*out)-1]
+	assert.Equal(t, "sip:dave@pc33.biloxi.com", fwd.msg.ReqLine.RequestURI())
+	assert.Equal(t, "10.0.0.5:5060", fwd.addr.String())
+
+	// no responses to ACK
+	n := len(*out)
+	ack := parseMsg(t, "ACK tel:+15551234 SIP/2.0",
+		"Via: SIP/2.0/UDP pc33.atlanta.com;branch=z9hG4bK776asdhds",
+		"To: Bob <sip:bob@biloxi.com>;tag=a6c85cf",
+		"From: Alice <sip:alice@atlanta.com>;tag=1928301774",
+		"Call-ID: a84b4c76e66710@pc33.atlanta.com",
+		"CSeq: 314159 ACK")
+	assert.Nil(t, s.Recv(ack, src))
+	assert.Len(t, *out, n)
+}
+
+func TestProxyLoopDetection(t *testing.T) {
+	s, out := newStateless(t)
+	src := transp.UDPAddr("192.0.2.101:5060")
+
+	assert.Nil(t, s.Recv(invite(t, "sip:bob@biloxi.com"), src))
+	fwd := (*out)[0].msg
+	// request comes back with the same Request-URI
+	looped := parseMsg(t, "INVITE sip:bob@biloxi.com SIP/2.0",
+		"Via: SIP/2.0/UDP ss1.biloxi.com;branch=z9hG4bKloop",
+		"Via: "+strings.TrimSpace(fwd.Headers.Find(sipmsg.SIPHdrVia).Value()),
+		"Max-Forwards: 68",
+		"To: Bob <sip:bob@biloxi.com>",
+		"From: Alice <sip:alice@atlanta.com>;tag=1928301774",
+		"Call-ID: a84b4c76e66710@pc33.atlanta.com",
+		"CSeq: 314159 INVITE",
+		"Content-Length: 0")
+	assert.Nil(t, s.Recv(looped, src))
+	assert.Equal(t, 482, (*out)[1].msg.Code())
+
+	// spiral with different Request-URI is forwarded
+	spiral, err := sipmsg.MsgParse([]byte(strings.Replace(looped.String(),
+		"INVITE sip:bob@biloxi.com", "INVITE sip:bob@pc33.biloxi.com", 1)))
+	assert.Nil(t, err)
+	assert.Nil(t, s.Recv(spiral, src))
+	assert.True(t, (*out)[2].msg.IsRequest())
+	assert.Equal(t, 3, (*out)[2].msg.Vias.Count())
+}
+
+func TestProxyRouting(t *testing.T) {
+	s, out := newStateless(t)
+	s.SetRecordRoute(true)
+	src := transp.UDPAddr("192.0.2.101:5060")
+
+	// loose routing: next hop is top Route
+	req := invite(t, "sip:bob@biloxi.com",
+		"Route: <sip:proxy.atlanta.com;lr>, <sip:ss1.biloxi.com;lr>")
+	assert.Nil(t, s.Recv(req, src))
+	fwd := (*out)[0]
+	assert.Equal(t, "10.0.0.3:5060", fwd.addr.String())
+	assert.Equal(t, "sip:bob@biloxi.com", fwd.msg.ReqLine.RequestURI())
+	assert.Equal(t, 1, fwd.msg.Routes.Count())
+	assert.Equal(t, "sip:ss1.biloxi.com;lr", fwd.msg.Routes[0].Addr())
+
+	// next hop is strict router
+	req = invite(t, "sip:bob@biloxi.com", "Route: <sip:strict.biloxi.com>, <sip:ss1.biloxi.com;lr>")
+	assert.Nil(t, s.Recv(req, src))
+	fwd = (*out)[1]
+	assert.Equal(t, "10.0.0.4:5060", fwd.addr.String())
+	assert.Equal(t, "sip:strict.biloxi.com", fwd.msg.ReqLine.RequestURI())
+	assert.Equal(t, 2, fwd.msg.Routes.Count())
+	assert.Equal(t, "sip:ss1.biloxi.com;lr", fwd.msg.Routes[0].Addr())
+	assert.Equal(t, "sip:bob@biloxi.com", fwd.msg.Routes[1].Addr())
+
+	// previous hop is strict router: Request-URI is proxy Record-Route
+	req = invite(t, "sip:proxy.atlanta.com:5060;lr",
+		"Route: <sip:ss1.biloxi.com;lr>", "Route: <sip:bob@pc33.biloxi.com>")
+	assert.Nil(t, s.Recv(req, src))
+	fwd = (*out)[2]
+	assert.Equal(t, "10.0.0.3:5060", fwd.addr.String())
+	assert.Equal(t, "sip:bob@pc33.biloxi.com", fwd.msg.ReqLine.RequestURI())
+	assert.Equal(t, 1, fwd.msg.Routes.Count())
+	assert.Equal(t, "sip:ss1.biloxi.com;lr", fwd.msg.Routes[0].Addr())
+
+	// unknown next hop
+	assert.NotNil(t, s.Recv(invite(t, "sip:bob@unknown.com"), src))
+}
@@ -0,0 +1,445 @@
+package proxy
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/staskobzar/gosip/sipmsg"
+	"github.com/staskobzar/gosip/transp"
+	"github.com/staskobzar/gosip/txn"
+)
+
+// Forking defines how stateful proxy forwards request to target set
+type Forking uint8
+
+const (
+	// ForkParallel forwards request to all targets at once
+	ForkParallel Forking = iota
+	// ForkSequential forwards request to the next target
+	// when previous target returned failure response
+	ForkSequential
+)
+
+// default proxy INVITE transaction timeout. Must be greater than 3 minutes.
+const defaultTimerC = 3*time.Minute + 30*time.Second
+
+// Stateful transaction stateful proxy (RFC3261#16). Requests passed to
+// TU by server transactions are forwarded to the targets with client
+// transactions. Responses are collected in response context and the
+// best final response is sent back with server transaction.
+type Stateful struct {
+	*Proxy
+	mux      *sync.Mutex
+	layer    *txn.Layer
+	send     SendFunc
+	forking  Forking
+	timerC   time.Duration
+	contexts map[string]*respContext
+	branches map[string]*respContext
+}
+
+// respContext response context of forwarded request (RFC3261#16.7)
+type respContext struct {
+	req        *request
+	addr       *transp.Addr
+	key        string
+	pending    []string
+	clients    map[string]*txn.Client
+	timers     map[string]*branchTimer
+	best       *sipmsg.Message
+	challenges []*sipmsg.Message
+	final      bool
+	cancelled  bool
+}
+
+// branchTimer timer C of INVITE branch (RFC3261#16.6 step 11)
+type branchTimer struct {
+	*time.Timer
+	provisional bool
+}
+
+// actions are calls to transaction layer and transport collected
+// with the lock held and made when the lock is released
+type actions []func() error
+
+func (acts *actions) add(f func() error) { *acts = append(*acts, f) }
+
+// run makes calls in order and returns the first error
+func (acts actions) run() error {
+	var err error
+	for _, f := range acts {
+		if e := f(); e != nil && err == nil {
+			err = e
+		}
+	}
+	return err
+}
+
+// NewStateful creates transaction stateful proxy with address used in
+// Via and Record-Route headers. Send callback is used to forward ACK
+// for 2xx responses and responses that do not match client transaction.
+func NewStateful(layer *txn.Layer, host string, port int, send SendFunc) *Stateful {
+	return &Stateful{
+		Proxy:    newProxy(host, port),
+		mux:      &sync.Mutex{},
+		layer:    layer,
+		send:     send,
+		timerC:   defaultTimerC,
+		contexts: make(map[string]*respContext),
+		branches: make(map[string]*respContext),
+	}
+}
+
+// SetForking sets forking mode. Default mode is parallel.
+func (s *Stateful) SetForking(mode Forking) {
+	s.forking = mode
+}
+
+// SetTimerC sets timeout of INVITE branch that does not
+// receive final response (RFC3261#16.6 step 11)
+func (s *Stateful) SetTimerC(timeout time.Duration) {
+	s.timerC = timeout
+}
+
+// Request handles request passed to TU by server transaction
+func (s *Stateful) Request(tm *txn.Message) error {
+	msg := tm.Msg
+	if msg == nil || !msg.IsRequest() || msg.Vias.Count() == 0 {
+		return ErrorProxy.msg("sip request expected")
+	}
+	switch msg.ReqLine.Method() {
+	case "ACK":
+		return s.statelessRequest(s.send, msg, tm.Addr)
+	case "CANCEL":
+		return s.cancel(tm)
+	}
+
+	r, code, reason := s.prepare(msg)
+	var targets []string
+	if code == 0 {
+		targets, code, reason = s.targets(r)
+	}
+	if code != 0 {
+		return s.respond(msg, tm.Addr, code, reason)
+	}
+
+	ctx := &respContext{
+		req:     r,
+		addr:    tm.Addr,
+		key:     contextKey(msg),
+		clients: make(map[string]*txn.Client),
+		timers:  make(map[string]*branchTimer),
+	}
+	if s.forking == ForkSequential {
+		ctx.pending = targets[1:]
+		targets = targets[:1]
+	}
+
+	var acts actions
+	s.mux.Lock()
+	s.contexts[ctx.key] = ctx
+	for _, target := range targets {
+		s.fork(ctx, target, &acts)
+	}
+	err := s.complete(ctx, &acts)
+	s.mux.Unlock()
+	if e := acts.run(); err == nil {
+		err = e
+	}
+	return err
+}
+
+// Response handles response passed to TU by client transaction.
+// Returns false if response does not belong to response context.
+func (s *Stateful) Response(tm *txn.Message) bool {
+	resp := tm.Msg
+	if resp == nil || !resp.IsResponse() || resp.Vias.Count() == 0 {
+		return false
+	}
+	branch := resp.Vias[0].Branch()
+
+	var acts actions
+	s.mux.Lock()
+	defer func() {
+		s.mux.Unlock()
+		acts.run()
+	}()
+	ctx, ok := s.branches[branch]
+	if !ok {
+		return false
+	}
+	fwd, err := s.response(resp)
+	if err != nil {
+		return true
+	}
+
+	code := resp.Code()
+	switch {
+	case code == 100:
+		return true
+	case code < 200:
+		if _, ok := ctx.timers[branch]; ok {
+			s.startTimerC(ctx, branch, true)
+		}
+		if !ctx.final {
+			acts.add(s.respondAction(ctx, fwd))
+		}
+		return true
+	case code < 300:
+		s.done(ctx, branch)
+		if ctx.final {
+			// all 2xx to INVITE are forwarded (RFC3261#16.7 step 5)
+			if addr := viaAddr(fwd.Vias[0]); addr != nil {
+				acts.add(func() error { return s.send(fwd, addr) })
+			}
+		} else {
+			ctx.final = true
+			acts.add(s.respondAction(ctx, fwd))
+			s.cancelBranches(ctx, &acts)
+		}
+	default:
+		s.done(ctx, branch)
+		ctx.collect(fwd)
+		if code >= 600 {
+			s.cancelBranches(ctx, &acts)
+		}
+	}
+	s.complete(ctx, &acts)
+	return true
+}
+
+// Unmatched forwards statelessly ACK and responses that do not
+// match transactions. Can be used as layer unmatched callback.
+func (s *Stateful) Unmatched(tm *txn.Message) {
+	if tm.Msg == nil {
+		return
+	}
+	if tm.Msg.IsResponse() {
+		s.statelessResponse(s.send, tm.Msg)
+		return
+	}
+	s.statelessRequest(s.send, tm.Msg, tm.Addr)
+}
+
+// cancel responds to CANCEL and cancels all pending client
+// transactions of the INVITE (RFC3261#16.10)
+func (s *Stateful) cancel(tm *txn.Message) error {
+	var acts actions
+	s.mux.Lock()
+	ctx, ok := s.contexts[contextKey(tm.Msg)]
+	if ok {
+		s.cancelBranches(ctx, &acts)
+	}
+	s.mux.Unlock()
+	if !ok {
+		return s.respond(tm.Msg, tm.Addr, 481, "Call/Transaction Does Not Exist")
+	}
+	if err := s.respond(tm.Msg, tm.Addr, 200, "OK"); err != nil {
+		return err
+	}
+	return acts.run()
+}
+
+// fork forwards request to the target. Client transaction is reserved
+// in the context and created when the lock is released. Must be called with lock.
+func (s *Stateful) fork(ctx *respContext, target string, acts *actions) {
+	branch := ctx.req.branch(sipmsg.NewBranch())
+	msg, addr, err := s.forward(ctx.req, target, branch)
+	if err != nil {
+		ctx.unavailable()
+		return
+	}
+	ctx.clients[branch] = nil
+	s.branches[branch] = ctx
+	if ctx.req.msg.ReqLine.Method() == "INVITE" {
+		s.startTimerC(ctx, branch, false)
+	}
+	acts.add(func() error {
+		cl, err := s.layer.Request(&txn.Message{Msg: msg, Addr: addr})
+		var next actions
+		s.mux.Lock()
+		if _, ok := ctx.clients[branch]; ok {
+			if err != nil {
+				s.done(ctx, branch)
+				ctx.unavailable()
+				s.complete(ctx, &next)
+			} else {
+				ctx.clients[branch] = cl
+				if ctx.cancelled {
+					next.add(s.cancelAction(cl))
+				}
+			}
+		}
+		s.mux.Unlock()
+		return next.run()
+	})
+}
+
+// done removes completed client transaction from the context
+func (s *Stateful) done(ctx *respContext, branch string) {
+	if t, ok := ctx.timers[branch]; ok {
+		t.Stop()
+		delete(ctx.timers, branch)
+	}
+	delete(ctx.clients, branch)
+	delete(s.branches, branch)
+}
+
+// startTimerC starts or restarts on provisional response timer C
+// of INVITE branch. Must be called with lock.
+func (s *Stateful) startTimerC(ctx *respContext, branch string, provisional bool) {
+	if t, ok := ctx.timers[branch]; ok {
+		t.Stop()
+	}
+	t := &branchTimer{provisional: provisional}
+	t.Timer = time.AfterFunc(s.timerC, func() { s.expire(ctx, branch, t) })
+	ctx.timers[branch] = t
+}
+
+// expire handles timer C of the branch (RFC3261#16.8). Branch that received
+// provisional response is cancelled. Branch is completed with 408 response.
+func (s *Stateful) expire(ctx *respContext, branch string, t *branchTimer) {
+	var acts actions
+	s.mux.Lock()
+	if ctx.timers[branch] != t {
+		// timer was restarted or branch is completed
+		s.mux.Unlock()
+		return
+	}
+	if cl := ctx.clients[branch]; cl != nil && t.provisional {
+		acts.add(s.cancelAction(cl))
+	}
+	s.done(ctx, branch)
+	if resp, err := newResponse(ctx.req.msg, 408, "Request Timeout"); err == nil {
+		ctx.collect(resp)
+	}
+	s.complete(ctx, &acts)
+	s.mux.Unlock()
+	acts.run()
+}
+
+// cancelBranches stops forwarding to pending targets and cancels
+// INVITE client transactions. Reserved transactions are cancelled
+// when they are created.
+func (s *Stateful) cancelBranches(ctx *respContext, acts *actions) {
+	ctx.pending = nil
+	if ctx.req.msg.ReqLine.Method() != "INVITE" {
+		return
+	}
+	ctx.cancelled = true
+	for _, cl := range ctx.clients {
+		if cl != nil {
+			acts.add(s.cancelAction(cl))
+		}
+	}
+}
+
+func (s *Stateful) cancelAction(cl *txn.Client) func() error {
+	return func() error {
+		// transaction may be already completed
+		s.layer.Cancel(cl)
+		return nil
+	}
+}
+
+// complete forwards request to the next target when all client
+// transactions are completed or sends the best response if
+// there are no more targets. Must be called with lock.
+func (s *Stateful) complete(ctx *respContext, acts *actions) error {
+	for len(ctx.clients) == 0 && len(ctx.pending) > 0 && !ctx.final {
+		target := ctx.pending[0]
+		ctx.pending = ctx.pending[1:]
+		s.fork(ctx, target, acts)
+	}
+	if len(ctx.clients) > 0 {
+		return nil
+	}
+	delete(s.contexts, ctx.key)
+	if ctx.final {
+		return nil
+	}
+	ctx.final = true
+	resp, err := ctx.bestResponse()
+	if err != nil {
+		return err
+	}
+	acts.add(s.respondAction(ctx, resp))
+	return nil
+}
+
+func (s *Stateful) respond(req *sipmsg.Message, addr *transp.Addr, code int, reason string) error {
+	resp, err := newResponse(req, code, reason)
+	if err != nil {
+		return err
+	}
+	return s.layer.Respond(req, &txn.Message{Msg: resp, Addr: addr})
+}
+
+func (s *Stateful) respondAction(ctx *respContext, resp *sipmsg.Message) func() error {
+	return func() error {
+		return s.layer.Respond(ctx.req.msg, &txn.Message{Msg: resp, Addr: ctx.addr})
+	}
+}
+
+// unavailable collects 503 response for the target that
+// request could not be forwarded to
+func (ctx *respContext) unavailable() {
+	if resp, err := newResponse(ctx.req.msg, 503, "Service Unavailable"); err == nil {
+		ctx.collect(resp)
+	}
+}
+
+// collect keeps final response if it is better than current best
+func (ctx *respContext) collect(resp *sipmsg.Message) {
+	if code := resp.Code(); code == 401 || code == 407 {
+		ctx.challenges = append(ctx.challenges, resp)
+	}
+	if ctx.best == nil {
+		ctx.best = resp
+		return
+	}
+	class, best := resp.Code()/100, ctx.best.Code()/100
+	if best != 6 && (class == 6 || class < best) {
+		ctx.best = resp
+	}
+}
+
+// bestResponse returns response to forward when all client
+// transactions are completed (RFC3261#16.7 step 6 and 7)
+func (ctx *respContext) bestResponse() (*sipmsg.Message, error) {
+	if ctx.best == nil {
+		return newResponse(ctx.req.msg, 408, "Request Timeout")
+	}
+	code := ctx.best.Code()
+	if code == 503 {
+		return newResponse(ctx.req.msg, 500, "Server Internal Error")
+	}
+	if code != 401 && code != 407 {
+		return ctx.best, nil
+	}
+	// aggregate challenges of all 401 and 407 responses
+	for _, resp := range ctx.challenges {
+		if resp == ctx.best {
+			continue
+		}
+		for _, h := range resp.Headers.FindAll(sipmsg.SIPHdrWWWAuthenticate) {
+			if err := ctx.best.AddHeader(h.Name(), strings.TrimSpace(h.Value())); err != nil {
+				return nil, err
+			}
+		}
+		for _, h := range resp.Headers.FindAll(sipmsg.SIPHdrProxyAuthenticate) {
+			if err := ctx.best.AddHeader(h.Name(), strings.TrimSpace(h.Value())); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return ctx.best, nil
+}
+
+// contextKey returns response context key of the server transaction
+// request. CANCEL has the same key as INVITE it cancels.
+func contextKey(msg *sipmsg.Message) string {
+	via := msg.Vias[0]
+	return via.Branch() + "|" + strings.ToLower(via.Host()) + ":" + via.Port()
+}
@@ -0,0 +1,228 @@
+package proxy
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/staskobzar/gosip/sipmsg"
+	"github.com/staskobzar/gosip/transp"
+	"github.com/staskobzar/gosip/txn"
+	"github.com/stretchr/testify/assert"
+)
+
+type statefulEnv struct {
+	t     *testing.T
+	tu    chan *txn.Message
+	tr    chan *txn.Message
+	layer *txn.Layer
+	p     *Stateful
+	src   *transp.Addr
+}
+
+func newStatefulEnv(t *testing.T, targets ...string) *statefulEnv {
+	env := &statefulEnv{
+		t:   t,
+		tu:  make(chan *txn.Message, 8),
+		tr:  make(chan *txn.Message, 32),
+		src: transp.UDPAddr("192.0.2.101:5060"),
+	}
+	env.layer = txn.NewLayer(env.tu, env.tr, func(tm *txn.Message) { env.p.Unmatched(tm) })
+	env.p = NewStateful(env.layer, "proxy.atlanta.com", 5060, func(msg *sipmsg.Message, addr *transp.Addr) error {
+		env.tr <- &txn.Message{Msg: msg, Addr: addr}
+		return nil
+	})
+	env.p.AddDomain("biloxi.com")
+	env.p.SetLocator(func(ruri string) ([]string, bool) { return targets, true })
+	env.p.SetResolver(resolver(map[string]string{
+		"pc1.biloxi.com": "10.0.0.1:5060",
+		"pc2.biloxi.com": "10.0.0.2:5060",
+	}))
+	return env
+}
+
+// request passes request from UAC through transaction layer to proxy
+func (env *statefulEnv) request(msg *sipmsg.Message) {
+	assert.Nil(env.t, env.layer.Recv(&txn.Message{Msg: msg, Addr: env.src}))
+	assert.Nil(env.t, env.p.Request(<-env.tu))
+}
+
+// reply passes response from downstream through transaction layer to proxy
+func (env *statefulEnv) reply(req *sipmsg.Message, code int, hdrs ...string) {
+	resp, _ := req.NewResponse(code, "Reason")
+	if code > 100 {
+		resp.AddToTag()
+	}
+	for i := 0; i < len(hdrs); i += 2 {
+		assert.Nil(env.t, resp.AddHeader(hdrs[i], hdrs[i+1]))
+	}
+	assert.Nil(env.t, env.layer.Recv(&txn.Message{Msg: resp, Addr: transp.UDPAddr("10.0.0.1:5060")}))
+	select {
+	case tm := <-env.tu:
+		assert.True(env.t, env.p.Response(tm))
+	case <-time.After(time.Second):
+		env.t.Fatal("response is not passed to TU")
+	}
+}
+
+// next returns next sent request with method or response with code
+func (env *statefulEnv) next(method string, code int) *txn.Message {
+	for {
+		select {
+		case tm := <-env.tr:
+			msg := tm.Msg
+			if (msg.IsRequest() && msg.ReqLine.Method() == method) ||
+				(msg.IsResponse() && msg.Code() == code && msg.CSeq.Method != "CANCEL") {
+				return tm
+			}
+		case <-time.After(2 * time.Second):
+			env.t.Fatalf("no %s %d message sent", method, code)
+			return nil
+		}
+	}
+}
+
+func TestProxyStatefulParallel(t *testing.T) {
+	env := newStatefulEnv(t, "sip:bob@pc1.biloxi.com", "sip:bob@pc2.biloxi.com")
+	defer env.layer.Close()
+	env.p.SetRecordRoute(true)
+
+	env.request(invite(t, "sip:bob@biloxi.com"))
+	out1, out2 := env.next("INVITE", 0), env.next("INVITE", 0)
+	if out1.Addr.String() != "10.0.0.1:5060" {
+		out1, out2 = out2, out1
+	}
+	assert.Equal(t, "sip:bob@pc1.biloxi.com", out1.Msg.ReqLine.RequestURI())
+	assert.Equal(t, "sip:bob@pc2.biloxi.com", out2.Msg.ReqLine.RequestURI())
+	assert.NotEqual(t, out1.Msg.Vias[0].Branch(), out2.Msg.Vias[0].Branch())
+	assert.Equal(t, 1, out1.Msg.RecRoutes.Count())
+
+	env.reply(out1.Msg, 180)
+	resp := env.next("", 180).Msg
+	assert.Equal(t, 1, resp.Vias.Count())
+	assert.Equal(t, "z9hG4bK776asdhds", resp.Vias[0].Branch())
+
+	// 2xx is forwarded and other branches are cancelled
+	env.reply(out2.Msg, 200)
+	assert.Equal(t, 1, env.next("", 200).Msg.Vias.Count())
+	cancel := env.next("CANCEL", 0)
+	assert.Equal(t, out1.Msg.Vias[0].Branch(), cancel.Msg.Vias[0].Branch())
+	env.reply(out1.Msg, 487)
+	assert.Len(t, env.p.contexts, 0)
+	assert.Len(t, env.p.branches, 0)
+
+	// 2xx retransmission is forwarded statelessly
+	ok, _ := out2.Msg.NewResponse(200, "OK")
+	env.layer.Recv(&txn.Message{Msg: ok, Addr: transp.UDPAddr("10.0.0.2:5060")})
+	fwd := env.next("", 200)
+	assert.Equal(t, "192.0.2.101:5060", fwd.Addr.String())
+}
+
+func TestProxyStatefulBestResponse(t *testing.T) {
+	env := newStatefulEnv(t, "sip:bob@pc1.biloxi.com", "sip:bob@pc2.biloxi.com")
+	defer env.layer.Close()
+
+	env.request(invite(t, "sip:bob@biloxi.com"))
+	out1, out2 := env.next("INVITE", 0), env.next("INVITE", 0)
+	env.reply(out1.Msg, 401, "WWW-Authenticate", `Digest realm="pc1.biloxi.com", nonce="abc"`)
+	env.reply(out2.Msg, 407, "Proxy-Authenticate", `Digest realm="pc2.biloxi.com", nonce="def"`)
+	resp := env.next("", 401).Msg
+	assert.Len(t, resp.Headers.FindAll(sipmsg.SIPHdrWWWAuthenticate), 1)
+	assert.Len(t, resp.Headers.FindAll(sipmsg.SIPHdrProxyAuthenticate), 1)
+
+	// 503 is forwarded as 500
+	msg := invite(t, "sip:bob@biloxi.com")
+	env.p.SetLocator(func(string) ([]string, bool) { return []string{"sip:bob@pc1.biloxi.com"}, true })
+	req, _ := sipmsg.MsgParse([]byte(replaceBranch(msg, "z9hG4bK503")))
+	env.request(req)
+	env.reply(env.next("INVITE", 0).Msg, 503)
+	assert.Equal(t, "z9hG4bK503", env.next("", 500).Msg.Vias[0].Branch())
+
+	// next hop can not be resolved
+	req, _ = sipmsg.MsgParse([]byte(replaceBranch(invite(t, "sip:bob@unknown.com"), "z9hG4bKnohost")))
+	env.request(req)
+	assert.Equal(t, "z9hG4bKnohost", env.next("", 500).Msg.Vias[0].Branch())
+}
+
+func TestProxyStatefulSequential(t *testing.T) {
+	env := newStatefulEnv(t, "sip:bob@pc1.biloxi.com", "sip:bob@pc2.biloxi.com")
+	defer env.layer.Close()
+	env.p.SetForking(ForkSequential)
+
+	env.request(invite(t, "sip:bob@biloxi.com"))
+	out := env.next("INVITE", 0)
+	assert.Equal(t, "sip:bob@pc1.biloxi.com", out.Msg.ReqLine.RequestURI())
+	env.reply(out.Msg, 503)
+	out = env.next("INVITE", 0)
+	assert.Equal(t, "sip:bob@pc2.biloxi.com", out.Msg.ReqLine.RequestURI())
+	env.reply(out.Msg, 486)
+	env.next("", 486)
+}
+
+func TestProxyStatefulTimerC(t *testing.T) {
+	env := newStatefulEnv(t, "sip:bob@pc1.biloxi.com", "sip:bob@pc2.biloxi.com")
+	defer env.layer.Close()
+	env.p.SetForking(ForkSequential)
+	env.p.SetTimerC(100 * time.Millisecond)
+
+	// branch with provisional response is cancelled
+	// and next target is tried
+	env.request(invite(t, "sip:bob@biloxi.com"))
+	out := env.next("INVITE", 0)
+	env.reply(out.Msg, 180)
+	env.next("", 180)
+	cancel := env.next("CANCEL", 0)
+	assert.Equal(t, out.Msg.Vias[0].Branch(), cancel.Msg.Vias[0].Branch())
+	out = env.next("INVITE", 0)
+	assert.Equal(t, "sip:bob@pc2.biloxi.com", out.Msg.ReqLine.RequestURI())
+
+	// branch without response times out with 408
+	env.next("", 408)
+	env.p.mux.Lock()
+	assert.Len(t, env.p.contexts, 0)
+	assert.Len(t, env.p.branches, 0)
+	env.p.mux.Unlock()
+}
+
+func TestProxyStatefulCancel(t *testing.T) {
+	env := newStatefulEnv(t, "sip:bob@pc1.biloxi.com")
+	defer env.layer.Close()
+
+	req := invite(t, "sip:bob@biloxi.com")
+	env.request(req)
+	out := env.next("INVITE", 0)
+	env.reply(out.Msg, 180)
+	env.next("", 180)
+
+	cancel, _ := req.NewCANCEL()
+	env.request(cancel)
+	// response to CANCEL and CANCEL of the client transaction
+	var ok, sent bool
+	for !ok || !sent {
+		select {
+		case tm := <-env.tr:
+			if tm.Msg.IsRequest() {
+				sent = sent || tm.Msg.ReqLine.Method() == "CANCEL"
+			} else if tm.Msg.CSeq.Method == "CANCEL" {
+				assert.Equal(t, 200, tm.Msg.Code())
+				ok = true
+			}
+		case <-time.After(time.Second):
+			t.Fatal("CANCEL is not processed")
+		}
+	}
+	env.reply(out.Msg, 487)
+	env.next("", 487)
+
+	// CANCEL without INVITE
+	cancel, _ = invite(t, "sip:alice@biloxi.com").NewCANCEL()
+	cancel, _ = sipmsg.MsgParse([]byte(replaceBranch(cancel, "z9hG4bK481")))
+	assert.Nil(t, env.layer.Recv(&txn.Message{Msg: cancel, Addr: env.src}))
+	assert.Nil(t, env.p.Request(<-env.tu))
+	tm := <-env.tr
+	assert.Equal(t, 481, tm.Msg.Code())
+}
+
+func replaceBranch(msg *sipmsg.Message, branch string) string {
+	return strings.Replace(msg.String(), msg.Vias[0].Branch(), branch, 1)
+}
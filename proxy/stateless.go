@@ -0,0 +1,86 @@
+package proxy
+
+import (
+	"crypto/md5"
+	"fmt"
+
+	"github.com/staskobzar/gosip/sipmsg"
+	"github.com/staskobzar/gosip/transp"
+)
+
+// Stateless proxy forwards requests and responses without
+// transactions (RFC3261#16.11). Request is forwarded to the
+// first target only.
+type Stateless struct {
+	*Proxy
+	send SendFunc
+}
+
+// NewStateless creates stateless proxy with address used in Via and
+// Record-Route headers. Messages are sent with send callback.
+func NewStateless(host string, port int, send SendFunc) *Stateless {
+	return &Stateless{
+		Proxy: newProxy(host, port),
+		send:  send,
+	}
+}
+
+// Handle forwards message received by transport.
+// Can be used as transport handler (transp.Handler).
+func (s *Stateless) Handle(msg *sipmsg.Message, addr *transp.Addr) {
+	s.Recv(msg, addr)
+}
+
+// Recv forwards request to the target or response to
+// the address of the next Via header
+func (s *Stateless) Recv(msg *sipmsg.Message, addr *transp.Addr) error {
+	if msg == nil {
+		return ErrorProxy.msg("invalid sip message")
+	}
+	if msg.IsResponse() {
+		return s.statelessResponse(s.send, msg)
+	}
+	return s.statelessRequest(s.send, msg, addr)
+}
+
+// statelessRequest forwards request to the first target or sends
+// response to the source address if request can not be forwarded
+func (p *Proxy) statelessRequest(send SendFunc, msg *sipmsg.Message, addr *transp.Addr) error {
+	r, code, reason := p.prepare(msg)
+	var targets []string
+	if code == 0 {
+		targets, code, reason = p.targets(r)
+	}
+	if code != 0 {
+		if msg.ReqLine.Method() == "ACK" {
+			return nil
+		}
+		resp, err := newResponse(msg, code, reason)
+		if err != nil {
+			return err
+		}
+		return send(resp, addr)
+	}
+
+	// branch must be the same for retransmissions and CANCEL
+	// of the request (RFC3261#16.11)
+	id := fmt.Sprintf("%x", md5.Sum([]byte(msg.Vias[0].Branch()+msg.Vias[0].Host())))
+	fwd, next, err := p.forward(r, targets[0], r.branch(sipmsg.BranchCookie+id[:16]))
+	if err != nil {
+		return err
+	}
+	return send(fwd, next)
+}
+
+// statelessResponse sends response to the address of the next Via
+func (p *Proxy) statelessResponse(send SendFunc, msg *sipmsg.Message) error {
+	resp, err := p.response(msg)
+	if err != nil {
+		return err
+	}
+	addr := viaAddr(resp.Vias[0])
+	if addr == nil {
+		return ErrorProxy.msg("invalid response Via address")
+	}
+	return send(resp, addr)
+}
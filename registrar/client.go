@@ -0,0 +1,381 @@
+package registrar
+
+import (
+	"crypto/rand"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/staskobzar/gosip/sipmsg"
+	"github.com/staskobzar/gosip/transp"
+	"github.com/staskobzar/gosip/txn"
+)
+
+// ErrorClient registration client error
+var ErrorClient = errorNew("Registration Client")
+
+// registration failure back off (RFC5626#4.5)
+const (
+	retryBase = 30 * time.Second
+	retryMax  = 1800 * time.Second
+)
+
+// Client registration agent (RFC3261#10.2). Binds contact to
+// address-of-record and refreshes binding before it expires.
+// Responses passed to TU must be given to Recv.
+type Client struct {
+	mux        *sync.Mutex
+	layer      *txn.Layer
+	addr       *transp.Addr
+	ruri       string
+	aor        string
+	contact    string
+	expires    uint
+	creds      sipmsg.CredentialsFunc
+	counter    *sipmsg.NonceCounter
+	callID     string
+	tag        string
+	cseq       uint
+	req        *sipmsg.Message
+	timer      *time.Timer
+	registered bool
+	// request is waiting for final response
+	pending bool
+	// pending request removes binding
+	unregister bool
+	granted    time.Time
+	failures   uint
+	closing    bool
+	done       chan struct{}
+	retryBase  time.Duration
+	retryMax   time.Duration
+}
+
+// NewClient creates registration client that sends REGISTER requests
+// for address-of-record with transaction layer to registrar address.
+// Request-URI is the domain of address-of-record.
+func NewClient(layer *txn.Layer, addr *transp.Addr, aor, contact string) (*Client, error) {
+	uri := sipmsg.URIParse([]byte(aor))
+	if uri == nil || AOR(aor) == "" {
+		return nil, ErrorClient.msg("invalid address-of-record %q", aor)
+	}
+	if sipmsg.URIParse([]byte(contact)) == nil {
+		return nil, ErrorClient.msg("invalid contact %q", contact)
+	}
+	if addr == nil {
+		return nil, ErrorClient.msg("invalid registrar address")
+	}
+	ruri := strings.ToLower(uri.Scheme()) + ":" + uri.Host()
+	if port := uri.Port(); port != "" {
+		ruri += ":" + port
+	}
+	return &Client{
+		mux:       &sync.Mutex{},
+		layer:     layer,
+		addr:      addr,
+		ruri:      ruri,
+		aor:       aor,
+		contact:   contact,
+		expires:   defaultExpires,
+		counter:   sipmsg.NewNonceCounter(),
+		callID:    randomHex(16),
+		tag:       randomHex(4),
+		done:      make(chan struct{}),
+		retryBase: retryBase,
+		retryMax:  retryMax,
+	}, nil
+}
+
+// SetExpires sets requested registration interval in seconds
+func (c *Client) SetExpires(expires uint) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	c.expires = expires
+}
+
+// SetCredentials sets callback that provides credentials
+// to answer authentication challenges
+func (c *Client) SetCredentials(creds sipmsg.CredentialsFunc) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	c.creds = creds
+}
+
+// Register sends REGISTER request
+func (c *Client) Register() error {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	if c.closing {
+		return ErrorClient.msg("client is closed")
+	}
+	return c.send(c.expires)
+}
+
+// Close stops refreshing and removes binding sending REGISTER with
+// Expires: 0. If REGISTER is in progress then binding is removed when
+// it is completed. Done channel is closed when de-registration is completed.
+func (c *Client) Close() error {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	if c.closing {
+		return nil
+	}
+	c.closing = true
+	c.stopTimer()
+	if c.pending {
+		return nil
+	}
+	if !c.registered {
+		c.closeDone()
+		return nil
+	}
+	return c.send(0)
+}
+
+// Done returns channel that is closed when client is closed
+// and binding is removed
+func (c *Client) Done() <-chan struct{} { return c.done }
+
+// IsRegistered returns true if binding is active
+func (c *Client) IsRegistered() bool {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	return c.registered
+}
+
+// Expires returns time when current binding expires
+func (c *Client) Expires() time.Time {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	return c.granted
+}
+
+// Recv handles response to REGISTER request sent by the client.
+// Returns false if response does not belong to the client.
+func (c *Client) Recv(tm *txn.Message) bool {
+	resp := tm.Msg
+	if resp == nil || !resp.IsResponse() || resp.CSeq == nil ||
+		resp.CallID != c.callID || resp.CSeq.Method != "REGISTER" {
+		return false
+	}
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	if resp.CSeq.Num != c.cseq {
+		// response to previous request
+		return true
+	}
+
+	code := resp.Code()
+	if code >= 200 {
+		c.pending = false
+	}
+	switch {
+	case code < 200:
+	case code < 300:
+		c.success(resp)
+	case code == 401 || code == 407:
+		if c.creds == nil {
+			c.failure(resp)
+			break
+		}
+		req, err := c.req.NewAuthorized(resp, c.creds, c.counter)
+		if err != nil {
+			c.failure(resp)
+			break
+		}
+		if err := c.request(req); err != nil {
+			c.failure(nil)
+		}
+	case code == 423 && !c.closing:
+		h := resp.Headers.Find(sipmsg.SIPHdrMinExpires)
+		if h == nil {
+			c.failure(resp)
+			break
+		}
+		min, err := strconv.ParseUint(strings.TrimSpace(h.Value()), 10, 32)
+		if err != nil || uint(min) <= c.expires {
+			c.failure(resp)
+			break
+		}
+		c.expires = uint(min)
+		if err := c.send(c.expires); err != nil {
+			c.failure(nil)
+		}
+	default:
+		c.failure(resp)
+	}
+	return true
+}
+
+// success updates binding state from 2xx response
+func (c *Client) success(resp *sipmsg.Message) {
+	c.failures = 0
+	if c.closing {
+		// binding created while client is closing is removed
+		if !c.unregister && c.grantedExpires(resp) > 0 && c.send(0) == nil {
+			return
+		}
+		c.registered = false
+		c.granted = time.Time{}
+		c.closeDone()
+		return
+	}
+	granted := c.grantedExpires(resp)
+	if granted == 0 {
+		c.registered = false
+		c.granted = time.Time{}
+		return
+	}
+	c.registered = true
+	c.granted = time.Now().Add(time.Duration(granted) * time.Second)
+	c.schedule(refreshIn(granted))
+}
+
+// failure schedules retry using Retry-After or exponential back off.
+// Response is nil if request was not sent.
+func (c *Client) failure(resp *sipmsg.Message) {
+	c.registered = false
+	c.granted = time.Time{}
+	if c.closing {
+		c.closeDone()
+		return
+	}
+	c.failures++
+	c.schedule(c.retryIn(resp))
+}
+
+// grantedExpires returns registration interval granted by registrar.
+// Contact expires parameter has precedence over Expires header.
+func (c *Client) grantedExpires(resp *sipmsg.Message) uint {
+	for i := 0; i < resp.Contacts.Count(); i++ {
+		var cnt *sipmsg.Contact
+		if i == 0 {
+			cnt = resp.Contacts.First()
+		} else {
+			cnt = resp.Contacts.Next()
+		}
+		if !strings.EqualFold(cnt.Location(), c.contact) {
+			continue
+		}
+		if val, ok := cnt.Param("expires"); ok {
+			if n, err := strconv.ParseUint(val, 10, 32); err == nil {
+				return uint(n)
+			}
+		}
+	}
+	if resp.Headers.Find(sipmsg.SIPHdrExpires) != nil {
+		return resp.Expires
+	}
+	return c.expires
+}
+
+// retryIn returns delay of the next registration attempt
+func (c *Client) retryIn(resp *sipmsg.Message) time.Duration {
+	var h *sipmsg.Header
+	if resp != nil {
+		h = resp.Headers.Find(sipmsg.SIPHdrRetryAfter)
+	}
+	if h != nil {
+		val := strings.TrimSpace(h.Value())
+		end := strings.IndexFunc(val, func(r rune) bool { return r < '0' || r > '9' })
+		if end > 0 {
+			val = val[:end]
+		}
+		if n, err := strconv.ParseUint(val, 10, 32); err == nil && n > 0 {
+			return time.Duration(n) * time.Second
+		}
+	}
+	d := c.retryBase
+	for i := uint(1); i < c.failures && d < c.retryMax; i++ {
+		d *= 2
+	}
+	if d > c.retryMax {
+		d = c.retryMax
+	}
+	return d
+}
+
+// refreshIn returns time to refresh binding before it expires
+func refreshIn(granted uint) time.Duration {
+	d := time.Duration(granted) * time.Second
+	if granted <= 60 {
+		return d / 2
+	}
+	return d - 30*time.Second
+}
+
+func (c *Client) closeDone() {
+	select {
+	case <-c.done:
+	default:
+		close(c.done)
+	}
+}
+
+func (c *Client) schedule(d time.Duration) {
+	c.stopTimer()
+	c.timer = time.AfterFunc(d, func() {
+		c.mux.Lock()
+		defer c.mux.Unlock()
+		if c.closing {
+			return
+		}
+		if err := c.send(c.expires); err != nil {
+			c.failure(nil)
+		}
+	})
+}
+
+func (c *Client) stopTimer() {
+	if c.timer != nil {
+		c.timer.Stop()
+		c.timer = nil
+	}
+}
+
+// send creates and sends REGISTER request. Must be called with lock.
+func (c *Client) send(expires uint) error {
+	uri := sipmsg.URIParse([]byte(c.contact))
+	port, _ := strconv.Atoi(uri.Port())
+	via, err := sipmsg.NewHdrVia(c.addr.Proto().String(), uri.Host(), uint(port), nil)
+	if err != nil {
+		return err
+	}
+	c.cseq++
+	c.unregister = expires == 0
+
+	var b strings.Builder
+	b.WriteString("REGISTER " + c.ruri + " SIP/2.0\r\n")
+	b.WriteString(via.String())
+	b.WriteString("Max-Forwards: 70\r\n")
+	b.WriteString("From: <" + c.aor + ">;tag=" + c.tag + "\r\n")
+	b.WriteString("To: <" + c.aor + ">\r\n")
+	b.WriteString("Call-ID: " + c.callID + "\r\n")
+	b.WriteString("CSeq: " + strconv.Itoa(int(c.cseq)) + " REGISTER\r\n")
+	b.WriteString("Contact: <" + c.contact + ">\r\n")
+	b.WriteString("Expires: " + strconv.Itoa(int(expires)) + "\r\n")
+	b.WriteString("Content-Length: 0\r\n\r\n")
+
+	req, err := sipmsg.MsgParse([]byte(b.String()))
+	if err != nil {
+		return ErrorClient.msg("failed to create REGISTER: %s", err)
+	}
+	return c.request(req)
+}
+
+// request sends request with new client transaction
+func (c *Client) request(req *sipmsg.Message) error {
+	c.req = req
+	c.cseq = req.CSeq.Num
+	_, err := c.layer.Request(&txn.Message{Msg: req, Addr: c.addr})
+	c.pending = err == nil
+	return err
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	rand.Read(b)
+	return fmt.Sprintf("%x", b)
+}
@@ -0,0 +1,211 @@
+package registrar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/staskobzar/gosip/sipmsg"
+	"github.com/staskobzar/gosip/transp"
+	"github.com/staskobzar/gosip/txn"
+	"github.com/stretchr/testify/assert"
+)
+
+type clientEnv struct {
+	t     *testing.T
+	tu    chan *txn.Message
+	tr    chan *txn.Message
+	layer *txn.Layer
+	cl    *Client
+}
+
+func newClientEnv(t *testing.T) *clientEnv {
+	env := &clientEnv{
+		t:  t,
+		tu: make(chan *txn.Message),
+		tr: make(chan *txn.Message, 8),
+	}
+	env.layer = txn.NewLayer(env.tu, env.tr, nil)
+	cl, err := NewClient(env.layer, transp.UDPAddr("10.0.0.1:5060"),
+		"sip:bob@biloxi.com", "sip:bob@192.0.2.4:5062")
+	assert.Nil(t, err)
+	env.cl = cl
+	return env
+}
+
+// reply passes response to the request through transaction layer to client
+func (env *clientEnv) reply(resp *sipmsg.Message) {
+	go env.layer.Recv(&txn.Message{Msg: resp, Addr: transp.UDPAddr("10.0.0.1:5060")})
+	tm := <-env.tu
+	assert.True(env.t, env.cl.Recv(tm))
+}
+
+func TestRegistrarClientRegister(t *testing.T) {
+	env := newClientEnv(t)
+	defer env.layer.Close()
+	r := New(nil)
+
+	assert.Nil(t, env.cl.Register())
+	tm := <-env.tr
+	req := tm.Msg
+	assert.Equal(t, "sip:biloxi.com", req.ReqLine.RequestURI())
+	assert.Equal(t, "UDP", req.Vias[0].Transport())
+	assert.Equal(t, "192.0.2.4", req.Vias[0].Host())
+	assert.Equal(t, "sip:bob@biloxi.com", req.To.Addr())
+	assert.Equal(t, "sip:bob@192.0.2.4:5062", req.Contacts.First().Location())
+	assert.EqualValues(t, 3600, req.Expires)
+	assert.EqualValues(t, 1, req.CSeq.Num)
+
+	resp, _ := r.Register(req)
+	env.reply(resp)
+	assert.True(t, env.cl.IsRegistered())
+	assert.WithinDuration(t, time.Now().Add(time.Hour), env.cl.Expires(), time.Second)
+
+	// response for other client
+	other, _ := req.NewResponse(200, "OK")
+	other.CallID = "other"
+	assert.False(t, env.cl.Recv(&txn.Message{Msg: other}))
+
+	// de-register
+	assert.Nil(t, env.cl.Close())
+	tm = <-env.tr
+	assert.EqualValues(t, 0, tm.Msg.Expires)
+	assert.EqualValues(t, 2, tm.Msg.CSeq.Num)
+	assert.Equal(t, req.CallID, tm.Msg.CallID)
+	resp, _ = r.Register(tm.Msg)
+	env.reply(resp)
+	<-env.cl.Done()
+	assert.False(t, env.cl.IsRegistered())
+	assert.Equal(t, 0, r.store.(*MemoryStore).Len())
+	assert.NotNil(t, env.cl.Register())
+}
+
+func TestRegistrarClientChallenge(t *testing.T) {
+	env := newClientEnv(t)
+	defer env.layer.Close()
+	r := New(nil)
+	r.SetExpires(600, 3600, 600)
+	auth := sipmsg.NewAuthenticator("biloxi.com", []byte("secret"),
+		sipmsg.HA1Func(func(user, realm string, algo sipmsg.AlgoType) (string, bool) {
+			return sipmsg.DigestHA1(algo, user, realm, "zanzibar"), true
+		}))
+	env.cl.SetExpires(60)
+	env.cl.SetCredentials(func(realm string) (string, string, bool) {
+		return "bob", "zanzibar", true
+	})
+
+	assert.Nil(t, env.cl.Register())
+	tm := <-env.tr
+	_, resp, _ := auth.Authenticate(tm.Msg)
+	env.reply(resp)
+
+	tm = <-env.tr
+	assert.EqualValues(t, 2, tm.Msg.CSeq.Num)
+	cr, _, _ := auth.Authenticate(tm.Msg)
+	assert.NotNil(t, cr)
+
+	// interval too brief
+	resp, _ = r.Register(tm.Msg)
+	assert.Equal(t, 423, resp.Code())
+	env.reply(resp)
+	tm = <-env.tr
+	assert.EqualValues(t, 600, tm.Msg.Expires)
+	assert.EqualValues(t, 3, tm.Msg.CSeq.Num)
+
+	resp, _ = r.Register(tm.Msg)
+	env.reply(resp)
+	assert.True(t, env.cl.IsRegistered())
+	env.cl.Close()
+}
+
+func TestRegistrarClientFailure(t *testing.T) {
+	env := newClientEnv(t)
+	defer env.layer.Close()
+	env.cl.retryBase = 10 * time.Millisecond
+
+	assert.Nil(t, env.cl.Register())
+	tm := <-env.tr
+	resp, _ := tm.Msg.NewResponse(503, "Service Unavailable")
+	env.reply(resp)
+	assert.False(t, env.cl.IsRegistered())
+
+	// retry after back off
+	tm = <-env.tr
+	assert.EqualValues(t, 2, tm.Msg.CSeq.Num)
+
+	// challenge without credentials
+	resp, _ = tm.Msg.NewResponse(401, "Unauthorized")
+	env.reply(resp)
+	tm = <-env.tr
+	assert.EqualValues(t, 3, tm.Msg.CSeq.Num)
+
+	// closed while request is in progress
+	assert.Nil(t, env.cl.Close())
+	resp, _ = tm.Msg.NewResponse(503, "Service Unavailable")
+	env.reply(resp)
+	<-env.cl.Done()
+}
+
+func TestRegistrarClientCloseInProgress(t *testing.T) {
+	env := newClientEnv(t)
+	defer env.layer.Close()
+	r := New(nil)
+
+	assert.Nil(t, env.cl.Register())
+	tm := <-env.tr
+	assert.Nil(t, env.cl.Close())
+	select {
+	case <-env.cl.Done():
+		t.Fatal("closed before REGISTER is completed")
+	default:
+	}
+
+	// binding is removed when REGISTER is completed
+	resp, _ := r.Register(tm.Msg)
+	env.reply(resp)
+	tm = <-env.tr
+	assert.EqualValues(t, 0, tm.Msg.Expires)
+	assert.EqualValues(t, 2, tm.Msg.CSeq.Num)
+	resp, _ = r.Register(tm.Msg)
+	env.reply(resp)
+	<-env.cl.Done()
+	assert.False(t, env.cl.IsRegistered())
+	assert.Equal(t, 0, r.store.(*MemoryStore).Len())
+}
+
+func TestRegistrarClientRetryIn(t *testing.T) {
+	env := newClientEnv(t)
+	defer env.layer.Close()
+	req := register(t, "1")
+	resp, _ := req.NewResponse(503, "Service Unavailable")
+
+	env.cl.failures = 1
+	assert.Equal(t, 30*time.Second, env.cl.retryIn(resp))
+	env.cl.failures = 3
+	assert.Equal(t, 120*time.Second, env.cl.retryIn(resp))
+	env.cl.failures = 20
+	assert.Equal(t, 1800*time.Second, env.cl.retryIn(resp))
+
+	resp.AddHeader("Retry-After", "18000;duration=3600")
+	assert.Equal(t, 18000*time.Second, env.cl.retryIn(resp))
+
+	assert.Equal(t, 15*time.Second, refreshIn(30))
+	assert.Equal(t, 3570*time.Second, refreshIn(3600))
+}
+
+func TestRegistrarClientGranted(t *testing.T) {
+	env := newClientEnv(t)
+	defer env.layer.Close()
+	req := register(t, "1")
+
+	resp, _ := req.NewResponse(200, "OK")
+	assert.EqualValues(t, 3600, env.cl.grantedExpires(resp))
+	resp.AddHeader("Expires", "1800")
+	assert.EqualValues(t, 1800, env.cl.grantedExpires(resp))
+	resp.AddHeader("Contact", "<sip:bob@10.0.0.1>;expires=60, <sip:bob@192.0.2.4:5062>;expires=900")
+	assert.EqualValues(t, 900, env.cl.grantedExpires(resp))
+
+	_, err := NewClient(env.layer, transp.UDPAddr("10.0.0.1:5060"), "tel:1234", "sip:bob@192.0.2.4")
+	assert.NotNil(t, err)
+	_, err = NewClient(env.layer, nil, "sip:bob@biloxi.com", "sip:bob@192.0.2.4")
+	assert.NotNil(t, err)
+}
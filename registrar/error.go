@@ -0,0 +1,23 @@
+package registrar
+
+import "fmt"
+
+type registrarError struct {
+	s string
+	e string
+}
+
+func errorNew(ctx string) *registrarError {
+	return &registrarError{s: ctx}
+}
+
+// msg returns new error of the same context. Package errors are
+// shared by registrars running concurrently and are not modified.
+func (e *registrarError) msg(msg string, args ...interface{}) *registrarError {
+	txt := fmt.Sprintf(msg, args...)
+	return &registrarError{s: e.s, e: ": " + txt}
+}
+
+func (e *registrarError) Error() string {
+	return e.s + e.e
+}
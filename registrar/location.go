@@ -0,0 +1,107 @@
+package registrar
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/staskobzar/gosip/sipmsg"
+)
+
+// Binding of address-of-record to contact address (RFC3261#10.3)
+type Binding struct {
+	AOR     string
+	Contact string
+	Expires time.Time
+	Q       float64
+	CallID  string
+	CSeq    uint
+}
+
+// Store location service that keeps bindings. Expired bindings
+// are filtered and removed by registrar.
+type Store interface {
+	// Bindings returns all bindings of address-of-record
+	Bindings(aor string) ([]*Binding, error)
+	// Put adds new binding or replaces binding with the same contact
+	Put(b *Binding) error
+	// Remove removes binding of address-of-record contact
+	Remove(aor, contact string) error
+}
+
+// MemoryStore in-memory location service
+type MemoryStore struct {
+	mux  *sync.Mutex
+	aors map[string]map[string]*Binding
+}
+
+// NewMemoryStore creates in-memory location service
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		mux:  &sync.Mutex{},
+		aors: make(map[string]map[string]*Binding),
+	}
+}
+
+// Bindings returns copy of address-of-record bindings
+func (s *MemoryStore) Bindings(aor string) ([]*Binding, error) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	list := make([]*Binding, 0, len(s.aors[aor]))
+	for _, b := range s.aors[aor] {
+		cp := *b
+		list = append(list, &cp)
+	}
+	return list, nil
+}
+
+// Put adds or replaces binding
+func (s *MemoryStore) Put(b *Binding) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	contacts, ok := s.aors[b.AOR]
+	if !ok {
+		contacts = make(map[string]*Binding)
+		s.aors[b.AOR] = contacts
+	}
+	cp := *b
+	contacts[b.Contact] = &cp
+	return nil
+}
+
+// Remove removes binding
+func (s *MemoryStore) Remove(aor, contact string) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	delete(s.aors[aor], contact)
+	if len(s.aors[aor]) == 0 {
+		delete(s.aors, aor)
+	}
+	return nil
+}
+
+// Len returns number of addresses-of-record
+func (s *MemoryStore) Len() int {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	return len(s.aors)
+}
+
+// AOR returns canonical address-of-record of the URI
+// in form of "sip:user@domain" (RFC3261#10.3). Returns
+// empty string if URI is not valid SIP or SIPS URI.
+func AOR(addr string) string {
+	uri := sipmsg.URIParse([]byte(addr))
+	if uri == nil || uri.Host() == "" {
+		return ""
+	}
+	scheme := strings.ToLower(uri.Scheme())
+	if scheme != "sip" && scheme != "sips" {
+		return ""
+	}
+	aor := scheme + ":"
+	if user := uri.User(); user != "" {
+		aor += user + "@"
+	}
+	return aor + strings.ToLower(uri.Host())
+}
@@ -0,0 +1,230 @@
+// Package registrar SIP registrar RFC3261#section-10.3
+package registrar
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/staskobzar/gosip/sipmsg"
+	"github.com/staskobzar/gosip/txn"
+)
+
+// ErrorRegistrar registrar error
+var ErrorRegistrar = errorNew("Registrar")
+
+// default registration expiration limits in seconds
+const (
+	defaultExpires = 3600
+	minExpires     = 60
+	maxExpires     = 86400
+)
+
+// Registrar accepts REGISTER requests and keeps bindings
+// in location service
+type Registrar struct {
+	mux        *sync.Mutex
+	store      Store
+	minExpires uint
+	maxExpires uint
+	defExpires uint
+	now        func() time.Time
+}
+
+// New creates registrar with location service store.
+// If store is nil, then in-memory store is used.
+func New(store Store) *Registrar {
+	if store == nil {
+		store = NewMemoryStore()
+	}
+	return &Registrar{
+		mux:        &sync.Mutex{},
+		store:      store,
+		minExpires: minExpires,
+		maxExpires: maxExpires,
+		defExpires: defaultExpires,
+		now:        time.Now,
+	}
+}
+
+// SetExpires sets minimal, maximal and default expiration
+// interval of bindings in seconds
+func (r *Registrar) SetExpires(min, max, def uint) {
+	r.minExpires, r.maxExpires, r.defExpires = min, max, def
+}
+
+// Handle processes REGISTER request passed to TU by server
+// transaction and sends response with transaction layer
+func (r *Registrar) Handle(layer *txn.Layer, tm *txn.Message) error {
+	resp, err := r.Register(tm.Msg)
+	if err != nil {
+		return err
+	}
+	return layer.Respond(tm.Msg, &txn.Message{Msg: resp, Addr: tm.Addr})
+}
+
+// Register processes REGISTER request (RFC3261#10.3) and
+// returns response with current bindings
+func (r *Registrar) Register(req *sipmsg.Message) (*sipmsg.Message, error) {
+	if req == nil || !req.IsRequest() || req.ReqLine.Method() != "REGISTER" {
+		return nil, ErrorRegistrar.msg("REGISTER request expected")
+	}
+	if req.To == nil || req.From == nil || req.CSeq == nil || req.CallID == "" {
+		return nil, ErrorRegistrar.msg("invalid REGISTER request")
+	}
+	aor := AOR(req.To.Addr())
+	if aor == "" {
+		return req.NewResponseTag(404, "Not Found")
+	}
+
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	now := r.now()
+	current, err := r.bindings(aor, now)
+	if err != nil {
+		return req.NewResponseTag(500, "Server Internal Error")
+	}
+	hasExpires := req.Headers.Find(sipmsg.SIPHdrExpires) != nil
+
+	if req.Contacts.IsStar() {
+		if req.Contacts.Count() > 0 || !hasExpires || req.Expires != 0 {
+			return req.NewResponseTag(400, "Invalid Request")
+		}
+		for _, b := range current {
+			if b.CallID == req.CallID && req.CSeq.Num <= b.CSeq {
+				return req.NewResponseTag(500, "Server Internal Error")
+			}
+		}
+		for _, b := range current {
+			if err := r.store.Remove(aor, b.Contact); err != nil {
+				return req.NewResponseTag(500, "Server Internal Error")
+			}
+		}
+		return r.ok(req, nil, now)
+	}
+
+	byContact := make(map[string]*Binding, len(current))
+	for _, b := range current {
+		byContact[b.Contact] = b
+	}
+
+	// validate all contacts before updating bindings
+	updates := make([]*Binding, 0, req.Contacts.Count())
+	for i := 0; i < req.Contacts.Count(); i++ {
+		var c *sipmsg.Contact
+		if i == 0 {
+			c = req.Contacts.First()
+		} else {
+			c = req.Contacts.Next()
+		}
+		expires := r.defExpires
+		if hasExpires {
+			expires = req.Expires
+		}
+		if val, ok := c.Param("expires"); ok {
+			n, err := strconv.ParseUint(val, 10, 32)
+			if err != nil {
+				return req.NewResponseTag(400, "Invalid Contact expires")
+			}
+			expires = uint(n)
+		}
+		if expires > 0 && expires < r.minExpires {
+			resp, err := req.NewResponseTag(423, "Interval Too Brief")
+			if err != nil {
+				return nil, err
+			}
+			return resp, resp.AddHeader("Min-Expires", strconv.Itoa(int(r.minExpires)))
+		}
+		if expires > r.maxExpires {
+			expires = r.maxExpires
+		}
+
+		b := &Binding{
+			AOR:     aor,
+			Contact: c.Location(),
+			Expires: now.Add(time.Duration(expires) * time.Second),
+			Q:       1,
+			CallID:  req.CallID,
+			CSeq:    req.CSeq.Num,
+		}
+		if val, ok := c.Param("q"); ok {
+			q, err := strconv.ParseFloat(val, 64)
+			if err != nil || q < 0 || q > 1 {
+				return req.NewResponseTag(400, "Invalid Contact q-value")
+			}
+			b.Q = q
+		}
+		if old, ok := byContact[b.Contact]; ok && old.CallID == b.CallID && b.CSeq <= old.CSeq {
+			return req.NewResponseTag(500, "Server Internal Error")
+		}
+		updates = append(updates, b)
+	}
+
+	for _, b := range updates {
+		if !b.Expires.After(now) {
+			err = r.store.Remove(aor, b.Contact)
+		} else {
+			err = r.store.Put(b)
+		}
+		if err != nil {
+			return req.NewResponseTag(500, "Server Internal Error")
+		}
+	}
+
+	current, err = r.bindings(aor, now)
+	if err != nil {
+		return req.NewResponseTag(500, "Server Internal Error")
+	}
+	return r.ok(req, current, now)
+}
+
+// Lookup returns active bindings of address-of-record
+// ordered by q-value
+func (r *Registrar) Lookup(aor string) ([]*Binding, error) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	list, err := r.bindings(AOR(aor), r.now())
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(list, func(i, j int) bool { return list[i].Q > list[j].Q })
+	return list, nil
+}
+
+// bindings returns active bindings and removes expired
+func (r *Registrar) bindings(aor string, now time.Time) ([]*Binding, error) {
+	list, err := r.store.Bindings(aor)
+	if err != nil {
+		return nil, err
+	}
+	active := make([]*Binding, 0, len(list))
+	for _, b := range list {
+		if b.Expires.After(now) {
+			active = append(active, b)
+		} else if err := r.store.Remove(aor, b.Contact); err != nil {
+			return nil, err
+		}
+	}
+	sort.Slice(active, func(i, j int) bool { return active[i].Contact < active[j].Contact })
+	return active, nil
+}
+
+// ok creates 200 response with Contact header for each binding
+func (r *Registrar) ok(req *sipmsg.Message, list []*Binding, now time.Time) (*sipmsg.Message, error) {
+	resp, err := req.NewResponseTag(200, "OK")
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range list {
+		val := "<" + b.Contact + ">;expires=" + strconv.Itoa(int(b.Expires.Sub(now).Seconds()))
+		if b.Q != 1 {
+			val += ";q=" + strconv.FormatFloat(b.Q, 'f', -1, 64)
+		}
+		if err := resp.AddHeader("Contact", val); err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
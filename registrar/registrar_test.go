@@ -0,0 +1,150 @@
+package registrar
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/staskobzar/gosip/sipmsg"
+	"github.com/staskobzar/gosip/transp"
+	"github.com/staskobzar/gosip/txn"
+	"github.com/stretchr/testify/assert"
+)
+
+func register(t *testing.T, cseq string, hdrs ...string) *sipmsg.Message {
+	str := "REGISTER sip:registrar.biloxi.com SIP/2.0\r\n" +
+		"Via: SIP/2.0/UDP bobspc.biloxi.com:5060;branch=z9hG4bKnashds7\r\n" +
+		"Max-Forwards: 70\r\n" +
+		"To: Bob <sip:bob@Biloxi.com>\r\n" +
+		"From: Bob <sip:bob@biloxi.com>;tag=456248\r\n" +
+		"Call-ID: 843817637684230@998sdasdh09\r\n" +
+		"CSeq: " + cseq + " REGISTER\r\n"
+	for _, h := range hdrs {
+		str += h + "\r\n"
+	}
+	msg, err := sipmsg.MsgParse([]byte(str + "Content-Length: 0\r\n\r\n"))
+	if err != nil {
+		t.Fatalf("failed to parse message: %s", err)
+	}
+	return msg
+}
+
+func contacts(resp *sipmsg.Message) []string {
+	var list []string
+	for _, h := range resp.Headers.FindAll(sipmsg.SIPHdrContact) {
+		list = append(list, strings.TrimSpace(h.Value()))
+	}
+	return list
+}
+
+func TestRegistrarRegister(t *testing.T) {
+	r := New(nil)
+	now := time.Now()
+	r.now = func() time.Time { return now }
+
+	resp, err := r.Register(register(t, "1", "Contact: <sip:bob@192.0.2.4>", "Expires: 7200"))
+	assert.Nil(t, err)
+	assert.Equal(t, 200, resp.Code())
+	assert.NotEmpty(t, resp.To.Tag())
+	assert.Equal(t, []string{"<sip:bob@192.0.2.4>;expires=7200"}, contacts(resp))
+
+	// contact expires param and q-value, max expires limit
+	resp, err = r.Register(register(t, "2",
+		"Contact: <sip:bob@10.0.0.1>;expires=600;q=0.5, <sip:bob@10.0.0.2>;expires=999999"))
+	assert.Nil(t, err)
+	assert.Equal(t, 200, resp.Code())
+	assert.Equal(t, []string{
+		"<sip:bob@10.0.0.1>;expires=600;q=0.5",
+		"<sip:bob@10.0.0.2>;expires=86400",
+		"<sip:bob@192.0.2.4>;expires=7200",
+	}, contacts(resp))
+
+	list, err := r.Lookup("sip:bob@biloxi.com")
+	assert.Nil(t, err)
+	assert.Len(t, list, 3)
+	assert.Equal(t, "sip:bob@10.0.0.1", list[2].Contact)
+
+	// query bindings
+	resp, err = r.Register(register(t, "3"))
+	assert.Nil(t, err)
+	assert.Len(t, contacts(resp), 3)
+
+	// remove binding
+	resp, err = r.Register(register(t, "4", "Contact: <sip:bob@10.0.0.2>;expires=0"))
+	assert.Nil(t, err)
+	assert.Len(t, contacts(resp), 2)
+
+	// expired bindings are removed
+	now = now.Add(time.Hour)
+	resp, err = r.Register(register(t, "5"))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"<sip:bob@192.0.2.4>;expires=3600"}, contacts(resp))
+
+	// remove all
+	resp, err = r.Register(register(t, "6", "Contact: *", "Expires: 0"))
+	assert.Nil(t, err)
+	assert.Equal(t, 200, resp.Code())
+	assert.Len(t, contacts(resp), 0)
+	assert.Equal(t, 0, r.store.(*MemoryStore).Len())
+}
+
+func TestRegistrarReject(t *testing.T) {
+	r := New(NewMemoryStore())
+	r.SetExpires(300, 3600, 600)
+
+	resp, err := r.Register(register(t, "1", "Contact: <sip:bob@192.0.2.4>", "Expires: 60"))
+	assert.Nil(t, err)
+	assert.Equal(t, 423, resp.Code())
+	assert.Equal(t, "300", resp.Headers.Find(sipmsg.SIPHdrMinExpires).Value())
+
+	resp, _ = r.Register(register(t, "1", "Contact: <sip:bob@192.0.2.4>"))
+	assert.Equal(t, 200, resp.Code())
+	assert.Equal(t, []string{"<sip:bob@192.0.2.4>;expires=600"}, contacts(resp))
+
+	// out of order request with the same Call-ID
+	resp, _ = r.Register(register(t, "1", "Contact: <sip:bob@192.0.2.4>"))
+	assert.Equal(t, 500, resp.Code())
+	resp, _ = r.Register(register(t, "1", "Contact: *", "Expires: 0"))
+	assert.Equal(t, 500, resp.Code())
+
+	// invalid star
+	resp, _ = r.Register(register(t, "2", "Contact: *"))
+	assert.Equal(t, 400, resp.Code())
+	resp, _ = r.Register(register(t, "2", "Contact: *", "Expires: 10"))
+	assert.Equal(t, 400, resp.Code())
+
+	resp, _ = r.Register(register(t, "2", "Contact: <sip:bob@192.0.2.4>;q=2"))
+	assert.Equal(t, 400, resp.Code())
+
+	_, err = r.Register(nil)
+	assert.NotNil(t, err)
+	invite := register(t, "2")
+	resp, _ = invite.NewResponse(200, "OK")
+	_, err = r.Register(resp)
+	assert.NotNil(t, err)
+}
+
+func TestRegistrarHandle(t *testing.T) {
+	tu := make(chan *txn.Message)
+	tr := make(chan *txn.Message)
+	l := txn.NewLayer(tu, tr, nil)
+	defer l.Close()
+	r := New(nil)
+
+	addr := transp.UDPAddr("192.0.2.4:5060")
+	req := register(t, "1", "Contact: <sip:bob@192.0.2.4>")
+	assert.Nil(t, l.Recv(&txn.Message{Msg: req, Addr: addr}))
+	tm := <-tu
+
+	go func() { assert.Nil(t, r.Handle(l, tm)) }()
+	tm = <-tr
+	assert.Equal(t, 200, tm.Msg.Code())
+	assert.Equal(t, []string{"<sip:bob@192.0.2.4>;expires=3600"}, contacts(tm.Msg))
+}
+
+func TestRegistrarAOR(t *testing.T) {
+	assert.Equal(t, "sip:bob@biloxi.com", AOR("sip:bob@BILOXI.com:5060;transport=tcp"))
+	assert.Equal(t, "sips:biloxi.com", AOR("sips:biloxi.com"))
+	assert.Equal(t, "", AOR("tel:+15551234"))
+	assert.Equal(t, "", AOR("invalid"))
+}
@@ -0,0 +1,274 @@
+package sipmsg
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/binary"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrorAuth digest verification error
+var ErrorAuth = errorNew("Authentication failed")
+
+// ErrorAuthStale is returned when credentials are valid
+// but nonce is expired. Client should retry with new nonce.
+var ErrorAuthStale = errorNew("Stale nonce")
+
+// default nonce life time
+const defaultNonceTTL = 5 * time.Minute
+
+// HA1Store provides HA1 = H(username:realm:password) for the user.
+// HA1 is calculated with hash function of the given algorithm.
+// Returns false if user is not found.
+type HA1Store interface {
+	HA1(username, realm string, algo AlgoType) (string, bool)
+}
+
+// HA1Func is an adapter to use function as HA1Store
+type HA1Func func(username, realm string, algo AlgoType) (string, bool)
+
+// HA1 calls f(username, realm, algo)
+func (f HA1Func) HA1(username, realm string, algo AlgoType) (string, bool) {
+	return f(username, realm, algo)
+}
+
+// DigestHA1 returns H(username:realm:password) for the algorithm.
+// Can be used to prepare HA1Store data.
+func DigestHA1(algo AlgoType, username, realm, password string) string {
+	return digestHA1(algo, username, realm, password)
+}
+
+// Authenticator challenges requests and verifies credentials (RFC3261#22.4).
+// Nonces are signed with HMAC and are valid for limited time. Nonce count
+// is tracked for each nonce to detect replay.
+type Authenticator struct {
+	realm  string
+	secret []byte
+	store  HA1Store
+	algos  []AlgoType
+	proxy  bool
+	ttl    time.Duration
+	now    func() time.Time
+	mux    sync.Mutex
+	nonces map[string]*nonceState
+	swept  time.Time
+}
+
+type nonceState struct {
+	nc      uint
+	created time.Time
+}
+
+// NewAuthenticator creates server side authenticator for realm.
+// Secret is used to sign nonces. Offers MD5 algorithm with qop=auth by default.
+func NewAuthenticator(realm string, secret []byte, store HA1Store) *Authenticator {
+	return &Authenticator{
+		realm:  realm,
+		secret: secret,
+		store:  store,
+		algos:  []AlgoType{AlgoMD5},
+		ttl:    defaultNonceTTL,
+		now:    time.Now,
+		nonces: make(map[string]*nonceState),
+	}
+}
+
+// SetAlgo sets algorithms offered in challenges. Challenge is
+// created for each algorithm in given order (RFC8760#2.4)
+func (a *Authenticator) SetAlgo(algos ...AlgoType) {
+	if len(algos) > 0 {
+		a.algos = algos
+	}
+}
+
+// SetNonceTTL sets nonce life time
+func (a *Authenticator) SetNonceTTL(ttl time.Duration) { a.ttl = ttl }
+
+// SetProxy enables proxy authentication with 407 response and
+// Proxy-Authenticate/Proxy-Authorization headers
+func (a *Authenticator) SetProxy(proxy bool) { a.proxy = proxy }
+
+// Realm returns authenticator realm
+func (a *Authenticator) Realm() string { return a.realm }
+
+// Challenges creates new challenge for each algorithm
+func (a *Authenticator) Challenges(stale bool) []*Challenge {
+	chs := make([]*Challenge, 0, len(a.algos))
+	for _, algo := range a.algos {
+		chs = append(chs, &Challenge{
+			realm:  []byte(a.realm),
+			nonce:  []byte(a.newNonce()),
+			opaque: []byte(a.opaque()),
+			stale:  stale,
+			algo:   algo,
+			qop:    QOPAuth,
+		})
+	}
+	return chs
+}
+
+// Verify verifies credentials for the request method, Request-URI and body.
+// Returns ErrorAuthStale if credentials are valid but nonce is expired.
+func (a *Authenticator) Verify(cr *Credentials, method, uri string, body []byte) error {
+	if cr.Realm() != a.realm {
+		return ErrorAuth.msg("invalid realm %q", cr.realm)
+	}
+	if cr.URI() != uri {
+		return ErrorAuth.msg("uri %q does not match Request-URI", cr.uri)
+	}
+	if cr.Opaque() != a.opaque() {
+		return ErrorAuth.msg("invalid opaque")
+	}
+	created, ok := a.checkNonce(cr.Nonce())
+	if !ok {
+		return ErrorAuth.msg("invalid nonce")
+	}
+	algo := cr.algo
+	if algo == 0 {
+		algo = AlgoMD5
+	}
+	if !a.offers(algo) {
+		return ErrorAuth.msg("algorithm %s is not allowed", algo)
+	}
+	if cr.qop == 0 || cr.qop == QOPAuthAll {
+		return ErrorAuth.msg("invalid qop")
+	}
+	ha1, ok := a.store.HA1(cr.Username(), a.realm, algo)
+	if !ok {
+		return ErrorAuth.msg("user %q not found", cr.username)
+	}
+	if subtle.ConstantTimeCompare([]byte(cr.digest(ha1, method, body)), cr.response) != 1 {
+		return ErrorAuth.msg("invalid response")
+	}
+
+	now := a.now()
+	if now.Sub(created) > a.ttl {
+		return ErrorAuthStale
+	}
+
+	a.mux.Lock()
+	defer a.mux.Unlock()
+	a.sweep(now)
+	st, ok := a.nonces[cr.Nonce()]
+	if !ok {
+		st = &nonceState{created: created}
+		a.nonces[cr.Nonce()] = st
+	}
+	if cr.nc <= st.nc {
+		return ErrorAuth.msg("nonce count %08x replay", cr.nc)
+	}
+	st.nc = cr.nc
+	return nil
+}
+
+// Authenticate verifies request credentials that match authenticator realm.
+// If request is authorized, then credentials are returned. Otherwise
+// 401 (407 for proxy) response with challenges is returned that should
+// be sent to the client.
+func (a *Authenticator) Authenticate(req *Message) (*Credentials, *Message, error) {
+	if req == nil || !req.IsRequest() {
+		return nil, nil, ErrorAuth.msg("sip request expected")
+	}
+	hid := SIPHdrAuthorization
+	if a.proxy {
+		hid = SIPHdrProxyAuthorization
+	}
+
+	stale := false
+	for _, cr := range req.credentials(hid) {
+		if cr.Realm() != a.realm {
+			continue
+		}
+		err := a.Verify(cr, req.ReqLine.Method(), req.ReqLine.RequestURI(), req.Body)
+		if err == nil {
+			return cr, nil, nil
+		}
+		stale = err == ErrorAuthStale
+		break
+	}
+
+	resp, err := a.challengeResponse(req, stale)
+	if err != nil {
+		return nil, nil, err
+	}
+	return nil, resp, nil
+}
+
+// challengeResponse creates 401/407 response with challenges
+func (a *Authenticator) challengeResponse(req *Message, stale bool) (*Message, error) {
+	code, reason, name := 401, "Unauthorized", "WWW-Authenticate"
+	if a.proxy {
+		code, reason, name = 407, "Proxy Authentication Required", "Proxy-Authenticate"
+	}
+	resp, err := req.NewResponseTag(code, reason)
+	if err != nil {
+		return nil, err
+	}
+	for _, ch := range a.Challenges(stale) {
+		if err := resp.AddHeader(name, ch.String()); err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+
+func (a *Authenticator) offers(algo AlgoType) bool {
+	for _, al := range a.algos {
+		if al == algo {
+			return true
+		}
+	}
+	return false
+}
+
+// newNonce creates nonce as hex encoded timestamp, random bytes
+// and HMAC signature of them
+func (a *Authenticator) newNonce() string {
+	b := make([]byte, 16)
+	binary.BigEndian.PutUint64(b, uint64(a.now().UnixNano()))
+	rand.Read(b[8:])
+	return hex.EncodeToString(append(b, a.sign(b)...))
+}
+
+// checkNonce verifies nonce signature and returns nonce creation time
+func (a *Authenticator) checkNonce(nonce string) (time.Time, bool) {
+	b, err := hex.DecodeString(strings.ToLower(nonce))
+	if err != nil || len(b) != 32 {
+		return time.Time{}, false
+	}
+	if !hmac.Equal(b[16:], a.sign(b[:16])) {
+		return time.Time{}, false
+	}
+	return time.Unix(0, int64(binary.BigEndian.Uint64(b))), true
+}
+
+// opaque returns opaque value of the challenges that clients
+// return unchanged in credentials
+func (a *Authenticator) opaque() string {
+	return hex.EncodeToString(a.sign([]byte("opaque")))
+}
+
+func (a *Authenticator) sign(data []byte) []byte {
+	mac := hmac.New(sha256.New, a.secret)
+	mac.Write(data)
+	mac.Write([]byte(a.realm))
+	return mac.Sum(nil)[:16]
+}
+
+// sweep removes expired nonces counters. Must be called with lock.
+func (a *Authenticator) sweep(now time.Time) {
+	if now.Sub(a.swept) < a.ttl {
+		return
+	}
+	a.swept = now
+	for nonce, st := range a.nonces {
+		if now.Sub(st.created) > a.ttl {
+			delete(a.nonces, nonce)
+		}
+	}
+}
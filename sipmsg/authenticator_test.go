@@ -0,0 +1,171 @@
+package sipmsg
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func authRegister(t *testing.T, hdrs ...string) *Message {
+	str := "REGISTER sip:biloxi.com SIP/2.0\r\n" +
+		"Via: SIP/2.0/UDP bobspc.biloxi.com:5060;branch=z9hG4bKnashds7\r\n" +
+		"Max-Forwards: 70\r\n" +
+		"To: Bob <sip:bob@biloxi.com>\r\n" +
+		"From: Bob <sip:bob@biloxi.com>;tag=456248\r\n" +
+		"Call-ID: 843817637684230@998sdasdh09\r\n" +
+		"CSeq: 1826 REGISTER\r\n"
+	for _, h := range hdrs {
+		str += h + "\r\n"
+	}
+	msg, err := MsgParse([]byte(str + "Content-Length: 0\r\n\r\n"))
+	assert.Nil(t, err)
+	return msg
+}
+
+func authStore(passwords map[string]string) HA1Store {
+	return HA1Func(func(user, realm string, algo AlgoType) (string, bool) {
+		pass, ok := passwords[user]
+		if !ok {
+			return "", false
+		}
+		return DigestHA1(algo, user, realm, pass), true
+	})
+}
+
+func authChallenge(t *testing.T, resp *Message, name string) *Challenge {
+	h := resp.Headers.FindByName(name)
+	assert.NotNil(t, h)
+	ch, err := parseChallenge([]byte(h.Value()))
+	assert.Nil(t, err)
+	return ch
+}
+
+func TestAuthenticatorChallenge(t *testing.T) {
+	a := NewAuthenticator("biloxi.com", []byte("secret"), authStore(nil))
+	cr, resp, err := a.Authenticate(authRegister(t))
+	assert.Nil(t, err)
+	assert.Nil(t, cr)
+	assert.Equal(t, 401, resp.Code())
+	assert.NotEmpty(t, resp.To.Tag())
+
+	ch := authChallenge(t, resp, "WWW-Authenticate")
+	assert.Equal(t, "biloxi.com", ch.Realm())
+	assert.Equal(t, AlgoMD5, ch.Algo())
+	assert.Equal(t, QOPAuth, ch.QOP())
+	assert.False(t, ch.Stale())
+	assert.Len(t, ch.Nonce(), 64)
+	assert.NotEmpty(t, ch.Opaque())
+
+	a.SetProxy(true)
+	a.SetAlgo(AlgoSHA256, AlgoMD5)
+	_, resp, err = a.Authenticate(authRegister(t))
+	assert.Nil(t, err)
+	assert.Equal(t, 407, resp.Code())
+	hdrs := resp.Headers.FindAll(SIPHdrProxyAuthenticate)
+	assert.Len(t, hdrs, 2)
+	ch = authChallenge(t, resp, "Proxy-Authenticate")
+	assert.Equal(t, AlgoSHA256, ch.Algo())
+
+	_, _, err = a.Authenticate(nil)
+	assert.NotNil(t, err)
+}
+
+func TestAuthenticatorVerify(t *testing.T) {
+	a := NewAuthenticator("biloxi.com", []byte("secret"), authStore(map[string]string{"bob": "zanzibar"}))
+	a.SetAlgo(AlgoSHA256, AlgoMD5)
+	_, resp, _ := a.Authenticate(authRegister(t))
+	var chs []*Challenge
+	for _, h := range resp.Headers.FindAll(SIPHdrWWWAuthenticate) {
+		ch, err := parseChallenge([]byte(h.Value()))
+		assert.Nil(t, err)
+		chs = append(chs, ch)
+	}
+
+	counter := NewNonceCounter()
+	ch := chs[0]
+	cr := counter.Authorize(ch, "REGISTER", "sip:biloxi.com", "bob", "zanzibar", nil)
+	assert.Nil(t, a.Verify(cr, "REGISTER", "sip:biloxi.com", nil))
+
+	// authorized request
+	cr = counter.Authorize(ch, "REGISTER", "sip:biloxi.com", "bob", "zanzibar", nil)
+	req := authRegister(t, "Authorization: "+cr.String())
+	acr, resp, err := a.Authenticate(req)
+	assert.Nil(t, err)
+	assert.Nil(t, resp)
+	assert.Equal(t, "bob", acr.Username())
+
+	// nonce count replay
+	acr, resp, err = a.Authenticate(req)
+	assert.Nil(t, err)
+	assert.Nil(t, acr)
+	assert.Equal(t, 401, resp.Code())
+	assert.Equal(t, ErrorAuth, a.Verify(cr, "REGISTER", "sip:biloxi.com", nil))
+
+	// invalid password
+	cr = counter.Authorize(ch, "REGISTER", "sip:biloxi.com", "bob", "wrong", nil)
+	assert.NotNil(t, a.Verify(cr, "REGISTER", "sip:biloxi.com", nil))
+	// unknown user
+	cr = counter.Authorize(ch, "REGISTER", "sip:biloxi.com", "alice", "zanzibar", nil)
+	assert.NotNil(t, a.Verify(cr, "REGISTER", "sip:biloxi.com", nil))
+	// invalid method
+	cr = counter.Authorize(ch, "REGISTER", "sip:biloxi.com", "bob", "zanzibar", nil)
+	assert.NotNil(t, a.Verify(cr, "INVITE", "sip:biloxi.com", nil))
+	// uri does not match Request-URI
+	cr = counter.Authorize(ch, "REGISTER", "sip:atlanta.com", "bob", "zanzibar", nil)
+	assert.NotNil(t, a.Verify(cr, "REGISTER", "sip:biloxi.com", nil))
+	req = authRegister(t, "Authorization: "+cr.String())
+	acr, resp, err = a.Authenticate(req)
+	assert.Nil(t, err)
+	assert.Nil(t, acr)
+	assert.Equal(t, 401, resp.Code())
+	// opaque is not returned unchanged
+	cr = counter.Authorize(ch, "REGISTER", "sip:biloxi.com", "bob", "zanzibar", nil)
+	assert.Equal(t, ch.Opaque(), cr.Opaque())
+	cr.opaque = []byte("foo")
+	assert.NotNil(t, a.Verify(cr, "REGISTER", "sip:biloxi.com", nil))
+
+	// not signed nonce
+	ch.nonce = []byte("5db8cc4a0000142280ed54f9ae98253634445c433235da255db8cc4a00001422")
+	cr = ch.Authorize("REGISTER", "sip:biloxi.com", "bob", "zanzibar")
+	assert.NotNil(t, a.Verify(cr, "REGISTER", "sip:biloxi.com", nil))
+
+	// algorithm is not offered
+	a.SetAlgo(AlgoMD5)
+	cr = counter.Authorize(chs[0], "REGISTER", "sip:biloxi.com", "bob", "zanzibar", nil)
+	assert.NotNil(t, a.Verify(cr, "REGISTER", "sip:biloxi.com", nil))
+	cr = counter.Authorize(chs[1], "REGISTER", "sip:biloxi.com", "bob", "zanzibar", nil)
+	assert.Nil(t, a.Verify(cr, "REGISTER", "sip:biloxi.com", nil))
+}
+
+func TestAuthenticatorStale(t *testing.T) {
+	a := NewAuthenticator("biloxi.com", []byte("secret"), authStore(map[string]string{"bob": "zanzibar"}))
+	now := time.Now()
+	a.now = func() time.Time { return now }
+	ch := a.Challenges(false)[0]
+
+	cr := ch.Authorize("REGISTER", "sip:biloxi.com", "bob", "zanzibar")
+	now = now.Add(defaultNonceTTL + time.Second)
+	assert.Equal(t, ErrorAuthStale, a.Verify(cr, "REGISTER", "sip:biloxi.com", nil))
+
+	_, resp, err := a.Authenticate(authRegister(t, "Authorization: "+cr.String()))
+	assert.Nil(t, err)
+	assert.True(t, authChallenge(t, resp, "WWW-Authenticate").Stale())
+
+	// wrong password with expired nonce is not stale
+	cr = ch.Authorize("REGISTER", "sip:biloxi.com", "bob", "wrong")
+	_, resp, err = a.Authenticate(authRegister(t, "Authorization: "+cr.String()))
+	assert.Nil(t, err)
+	assert.False(t, authChallenge(t, resp, "WWW-Authenticate").Stale())
+
+	// expired nonce counters are removed
+	a.SetNonceTTL(time.Hour)
+	cr = ch.Authorize("REGISTER", "sip:biloxi.com", "bob", "zanzibar")
+	assert.Nil(t, a.Verify(cr, "REGISTER", "sip:biloxi.com", nil))
+	assert.Len(t, a.nonces, 1)
+	now = now.Add(2 * time.Hour)
+	a.mux.Lock()
+	a.sweep(now)
+	a.mux.Unlock()
+	assert.Len(t, a.nonces, 0)
+}
@@ -65,6 +65,31 @@ const (
 	SIPHdrWWWAuthenticate
 )
 
+// compactForms maps compact form of header name to the full name
+// (RFC3261#7.3.3 and IANA SIP header fields registry)
+var compactForms = map[string]string{
+	"a": "Accept-Contact",
+	"b": "Referred-By",
+	"c": "Content-Type",
+	"d": "Request-Disposition",
+	"e": "Content-Encoding",
+	"f": "From",
+	"i": "Call-ID",
+	"j": "Reject-Contact",
+	"k": "Supported",
+	"l": "Content-Length",
+	"m": "Contact",
+	"n": "Identity-Info",
+	"o": "Event",
+	"r": "Refer-To",
+	"s": "Subject",
+	"t": "To",
+	"u": "Allow-Events",
+	"v": "Via",
+	"x": "Session-Expires",
+	"y": "Identity",
+}
+
 // HeadersList SIP headers list
 type HeadersList struct {
 	*list.List
@@ -79,11 +104,12 @@ func (l HeadersList) Count() int {
 	return l.Len()
 }
 
-// FindByName find header by name
+// FindByName find header by name. Name is case-insensitive
+// and compact form matches full form of the name.
 func (l HeadersList) FindByName(name string) *Header {
 	for e := l.Front(); e != nil; e = e.Next() {
 		h := e.Value.(*Header)
-		if strings.EqualFold(name, h.Name()) {
+		if sameName(name, h.Name()) {
 			return h
 		}
 	}
@@ -131,18 +157,35 @@ func (l HeadersList) exists(buf []byte) bool {
 	return false
 }
 
+// isLast returns true if buffer is the buffer of the last header.
+// Values of comma separated header line are parsed from the same buffer.
+func (l HeadersList) isLast(buf []byte) bool {
+	e := l.Back()
+	if e == nil {
+		return false
+	}
+	return sameBuf(e.Value.(*Header).buf, buf)
+}
+
+// sameBuf returns true if slices are the same memory
+func sameBuf(a, b []byte) bool {
+	return len(a) > 0 && len(a) == len(b) && &a[0] == &b[0]
+}
+
 func (l HeadersList) push(h *Header) {
 	l.PushBack(h)
 }
 
 func (l HeadersList) remove(name string) bool {
 	found := false
-	for e := l.Front(); e != nil; e = e.Next() {
+	for e := l.Front(); e != nil; {
+		next := e.Next()
 		h := e.Value.(*Header)
-		if strings.EqualFold(h.Name(), name) {
+		if sameName(h.Name(), name) {
 			l.Remove(e)
 			found = true
 		}
+		e = next
 	}
 	return found
 }
@@ -170,6 +213,19 @@ func (h *Header) Value() string {
 	return string(h.buf[h.value.p:h.value.l])
 }
 
+// compact returns header line with compact form of the name
+func (h *Header) compact() []byte {
+	short := compactName(h.Name())
+	if short == h.Name() {
+		return h.buf
+	}
+	colon := bytes.IndexByte(h.buf[h.name.l:], ':')
+	if colon == -1 {
+		return h.buf
+	}
+	return append([]byte(short), h.buf[int(h.name.l)+colon:]...)
+}
+
 // CSeq SIP sequence number
 type CSeq struct {
 	Num    uint
@@ -189,16 +245,36 @@ func searchParam(name string, buf []byte, params []pl) (string, bool) {
 	return "", false
 }
 
+// fullName returns full form of compact header name
+func fullName(name string) string {
+	if full, ok := compactForms[strings.ToLower(name)]; ok {
+		return full
+	}
+	return name
+}
+
+// compactName returns compact form of header name if it exists
+func compactName(name string) string {
+	for short, full := range compactForms {
+		if sameName(full, name) {
+			return short
+		}
+	}
+	return name
+}
+
+// sameName returns true if header names are equal ignoring
+// case and compact form
+func sameName(a, b string) bool {
+	return strings.EqualFold(fullName(a), fullName(b))
+}
+
 // local helper functions and structures
 func randomString() string {
 	rand.Seed(time.Now().UnixNano())
 	return fmt.Sprintf("%x", rand.Uint32())
 }
 
-func randomStringPrefix(prefix string) string {
-	return fmt.Sprintf("%s%s", prefix, randomString())
-}
-
 func hashString() string {
 	b := make([]byte, 18)
 	rand.Read(b)
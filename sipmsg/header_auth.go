@@ -3,7 +3,12 @@ package sipmsg
 import (
 	"bytes"
 	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
 	"fmt"
+	"hash"
+	"sync"
 )
 
 type AlgoType uint
@@ -15,8 +20,77 @@ const (
 	QOPAuthAll  QOPType  = QOPAuth | QOPAuthInt
 	AlgoMD5     AlgoType = 100
 	AlgoMD5sess AlgoType = 200
+	// RFC8760 algorithms
+	AlgoSHA256        AlgoType = 300
+	AlgoSHA256sess    AlgoType = 400
+	AlgoSHA512256     AlgoType = 500
+	AlgoSHA512256sess AlgoType = 600
 )
 
+// String returns qop value. Combined value is returned as
+// comma separated list as used in challenge.
+func (q QOPType) String() string {
+	switch q {
+	case QOPAuth:
+		return "auth"
+	case QOPAuthInt:
+		return "auth-int"
+	case QOPAuthAll:
+		return "auth,auth-int"
+	}
+	return ""
+}
+
+// String returns algorithm name as used in algorithm parameter
+func (a AlgoType) String() string {
+	switch a {
+	case AlgoMD5sess:
+		return "MD5-sess"
+	case AlgoSHA256:
+		return "SHA-256"
+	case AlgoSHA256sess:
+		return "SHA-256-sess"
+	case AlgoSHA512256:
+		return "SHA-512-256"
+	case AlgoSHA512256sess:
+		return "SHA-512-256-sess"
+	}
+	return "MD5"
+}
+
+// IsSess returns true for session algorithm variants (-sess)
+func (a AlgoType) IsSess() bool {
+	return a == AlgoMD5sess || a == AlgoSHA256sess || a == AlgoSHA512256sess
+}
+
+// strength is used to compare algorithms. Session variant has
+// the same strength as its base algorithm.
+func (a AlgoType) strength() int {
+	switch a {
+	case AlgoSHA512256, AlgoSHA512256sess:
+		return 3
+	case AlgoSHA256, AlgoSHA256sess:
+		return 2
+	}
+	return 1
+}
+
+// hash returns hex encoded digest of data using algorithm hash function.
+// Challenge without algorithm parameter defaults to MD5 (RFC2617#3.2.1)
+func (a AlgoType) hash(data []byte) string {
+	var h hash.Hash
+	switch a.strength() {
+	case 3:
+		h = sha512.New512_256()
+	case 2:
+		h = sha256.New()
+	default:
+		h = md5.New()
+	}
+	h.Write(data)
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
+
 // Challenge sturcture represents HTTP auth challenge
 type Challenge struct {
 	realm  []byte
@@ -56,46 +130,227 @@ func (ch *Challenge) Stale() bool { return ch.stale }
 // Algo return challenge algo as string
 func (ch *Challenge) Algo() AlgoType { return ch.algo }
 
+// String returns challenge header structure as string
+func (ch *Challenge) String() string {
+	var buf bytes.Buffer
+	buf.WriteString("Digest ")
+	buf.WriteString("realm=\"")
+	buf.Write(ch.realm)
+	buf.WriteString("\"")
+
+	if len(ch.domain) > 0 {
+		buf.WriteString(", domain=\"")
+		buf.Write(ch.domain)
+		buf.WriteString("\"")
+	}
+
+	buf.WriteString(", nonce=\"")
+	buf.Write(ch.nonce)
+	buf.WriteString("\"")
+
+	if len(ch.opaque) > 0 {
+		buf.WriteString(", opaque=\"")
+		buf.Write(ch.opaque)
+		buf.WriteString("\"")
+	}
+
+	if ch.stale {
+		buf.WriteString(", stale=true")
+	}
+
+	if ch.algo != 0 {
+		buf.WriteString(", algorithm=")
+		buf.WriteString(ch.algo.String())
+	}
+
+	if ch.qop != 0 {
+		buf.WriteString(", qop=\"")
+		buf.WriteString(ch.qop.String())
+		buf.WriteString("\"")
+	}
+
+	return buf.String()
+}
+
 // Authorize creates credentials struct from challenge
 func (ch *Challenge) Authorize(method, uri, user, password string) *Credentials {
+	return ch.authorize(method, uri, user, password, nil, 1, newCNonce())
+}
+
+// AuthorizeBody creates credentials from challenge for the request
+// with message body. Body is used when challenge offers qop=auth-int.
+func (ch *Challenge) AuthorizeBody(method, uri, user, password string, body []byte) *Credentials {
+	if body == nil {
+		body = []byte{}
+	}
+	return ch.authorize(method, uri, user, password, body, 1, newCNonce())
+}
+
+// authorize builds credentials with given nonce count and cnonce.
+// When challenge offers both qop values, auth-int is used only
+// when body is not nil.
+func (ch *Challenge) authorize(method, uri, user, password string,
+	body []byte, nc uint, cnonce []byte) *Credentials {
+	algo := ch.algo
+	if algo == 0 {
+		algo = AlgoMD5
+	}
 	cr := &Credentials{
 		username: []byte(user),
 		uri:      []byte(uri),
 		realm:    ch.realm,
 		nonce:    ch.nonce,
-		algo:     AlgoMD5,
-		qop:      QOPAuth,
-	}
-
-	// HA1 = MD5(username:realm:password)
-	var b1 bytes.Buffer
-	b1.WriteString(user)
-	b1.WriteByte(':')
-	b1.Write(ch.realm)
-	b1.WriteByte(':')
-	b1.WriteString(password)
-	h1 := fmt.Sprintf("%x", md5.Sum(b1.Bytes()))
-
-	// HA2 = MD5(method:digestURI)
-	var b2 bytes.Buffer
-	b2.WriteString(method)
-	b2.WriteByte(':')
-	b2.WriteString(uri)
-	h2 := fmt.Sprintf("%x", md5.Sum(b2.Bytes()))
-
-	// response = MD5(HA1:nonce:HA2)
-	var b3 bytes.Buffer
-	b3.WriteString(h1)
-	b3.WriteByte(':')
-	b3.Write(ch.nonce)
-	b3.WriteByte(':')
-	b3.WriteString(h2)
-	res := fmt.Sprintf("%x", md5.Sum(b3.Bytes()))
-	cr.response = []byte(res)
+		opaque:   ch.opaque,
+		algo:     algo,
+	}
+
+	switch {
+	case ch.IsQOPAuthInt() && (body != nil || !ch.IsQOPAuth()):
+		cr.qop = QOPAuthInt
+	case ch.IsQOPAuth():
+		cr.qop = QOPAuth
+	}
+	if cr.qop != 0 || algo.IsSess() {
+		cr.cnonce = cnonce
+	}
+	if cr.qop != 0 {
+		cr.nc = nc
+	}
 
+	ha1 := digestHA1(algo, user, ch.Realm(), password)
+	cr.response = []byte(cr.digest(ha1, method, body))
 	return cr
 }
 
+// digestHA1 returns H(username:realm:password)
+func digestHA1(algo AlgoType, user, realm, password string) string {
+	return algo.hash([]byte(user + ":" + realm + ":" + password))
+}
+
+// digest calculates response for the credentials with given
+// HA1 = H(username:realm:password) (RFC2617#3.2.2, RFC7616#3.4.1)
+func (cr *Credentials) digest(ha1, method string, body []byte) string {
+	algo := cr.algo
+	if algo == 0 {
+		algo = AlgoMD5
+	}
+
+	var buf bytes.Buffer
+	if algo.IsSess() {
+		// HA1 = H(H(username:realm:password):nonce:cnonce)
+		buf.WriteString(ha1)
+		buf.WriteByte(':')
+		buf.Write(cr.nonce)
+		buf.WriteByte(':')
+		buf.Write(cr.cnonce)
+		ha1 = algo.hash(buf.Bytes())
+		buf.Reset()
+	}
+
+	// HA2 = H(method:digestURI) or H(method:digestURI:H(body)) for auth-int
+	buf.WriteString(method)
+	buf.WriteByte(':')
+	buf.Write(cr.uri)
+	if cr.qop == QOPAuthInt {
+		buf.WriteByte(':')
+		buf.WriteString(algo.hash(body))
+	}
+	ha2 := algo.hash(buf.Bytes())
+	buf.Reset()
+
+	// response = H(HA1:nonce:HA2) or
+	// response = H(HA1:nonce:nc:cnonce:qop:HA2) when qop is set
+	buf.WriteString(ha1)
+	buf.WriteByte(':')
+	buf.Write(cr.nonce)
+	buf.WriteByte(':')
+	if cr.qop != 0 {
+		fmt.Fprintf(&buf, "%08x:", cr.nc)
+		buf.Write(cr.cnonce)
+		buf.WriteByte(':')
+		buf.WriteString(cr.qop.String())
+		buf.WriteByte(':')
+	}
+	buf.WriteString(ha2)
+	return algo.hash(buf.Bytes())
+}
+
+// Authorize creates credentials for the strongest algorithm
+// among offered challenges (RFC8760#2.4). Returns nil if
+// list of challenges is empty.
+func Authorize(challenges []*Challenge, method, uri, user, password string) *Credentials {
+	var best *Challenge
+	for _, ch := range challenges {
+		if best == nil || ch.algo.strength() > best.algo.strength() {
+			best = ch
+		}
+	}
+	if best == nil {
+		return nil
+	}
+	return best.Authorize(method, uri, user, password)
+}
+
+// NonceCounter tracks nonce count per realm. It allows to reuse
+// server nonce for the following requests with incremented nonce count.
+type NonceCounter struct {
+	mux    sync.Mutex
+	realms map[string]*nonceCount
+}
+
+type nonceCount struct {
+	nonce  string
+	cnonce []byte
+	nc     uint
+}
+
+// NewNonceCounter creates nonce count tracker
+func NewNonceCounter() *NonceCounter {
+	return &NonceCounter{realms: make(map[string]*nonceCount)}
+}
+
+// Authorize creates credentials from challenge with the next nonce
+// count for challenge realm. Nonce count is reset when server
+// sends new nonce. Body is used for qop=auth-int and can be nil.
+func (c *NonceCounter) Authorize(ch *Challenge, method, uri, user, password string, body []byte) *Credentials {
+	c.mux.Lock()
+	realm := ch.Realm()
+	cnt, ok := c.realms[realm]
+	if !ok || cnt.nonce != ch.Nonce() {
+		cnt = &nonceCount{nonce: ch.Nonce(), cnonce: newCNonce()}
+		c.realms[realm] = cnt
+	}
+	cnt.nc++
+	nc, cnonce := cnt.nc, cnt.cnonce
+	c.mux.Unlock()
+
+	return ch.authorize(method, uri, user, password, body, nc, cnonce)
+}
+
+// NonceCount returns last nonce count used for realm
+func (c *NonceCounter) NonceCount(realm string) uint {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	if cnt, ok := c.realms[realm]; ok {
+		return cnt.nc
+	}
+	return 0
+}
+
+// Reset removes realm nonce count
+func (c *NonceCounter) Reset(realm string) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	delete(c.realms, realm)
+}
+
+// newCNonce generates random client nonce
+func newCNonce() []byte {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return []byte(fmt.Sprintf("%x", b))
+}
+
 // Credentials sturcture represents HTTP auth credentials
 type Credentials struct {
 	username []byte
@@ -134,7 +389,26 @@ func (cr *Credentials) String() string {
 	buf.Write(cr.response)
 	buf.WriteString("\", ")
 
-	buf.WriteString("algorithm=MD5, qop=auth")
+	if len(cr.cnonce) > 0 {
+		buf.WriteString("cnonce=\"")
+		buf.Write(cr.cnonce)
+		buf.WriteString("\", ")
+	}
+
+	if len(cr.opaque) > 0 {
+		buf.WriteString("opaque=\"")
+		buf.Write(cr.opaque)
+		buf.WriteString("\", ")
+	}
+
+	buf.WriteString("algorithm=")
+	buf.WriteString(cr.algo.String())
+
+	if cr.qop != 0 {
+		buf.WriteString(", qop=")
+		buf.WriteString(cr.qop.String())
+		fmt.Fprintf(&buf, ", nc=%08x", cr.nc)
+	}
 
 	return buf.String()
 }
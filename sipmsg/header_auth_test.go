@@ -82,13 +82,218 @@ func TestAuthAuthorize(t *testing.T) {
 	assert.Equal(t, "sip:example.com", cr.URI())
 	assert.Equal(t, "1a74b013d700b1b3f8c455d2f58be6c4", cr.Response())
 	assert.Equal(t, AlgoMD5, cr.Algo())
-	assert.Equal(t, QOPAuth, cr.QOP())
+	// challenge without qop: RFC2069 compatible response
+	assert.Equal(t, QOPType(0), cr.QOP())
+	assert.Equal(t, "", cr.CNonce())
 
 	str := "Digest username=\"alice\", realm=\"example.com\", " +
 		"nonce=\"5db8cc4a0000142280ed54f9ae98253634445c433235da25\", " +
 		"uri=\"sip:example.com\", response=\"1a74b013d700b1b3f8c455d2f58be6c4\", " +
-		"algorithm=MD5, qop=auth"
+		"algorithm=MD5"
+	assert.Equal(t, str, cr.String())
+}
+
+func TestAuthChallengeAlgorithms(t *testing.T) {
+	tests := []struct {
+		algo string
+		want AlgoType
+	}{
+		{"MD5", AlgoMD5},
+		{"md5-sess", AlgoMD5sess},
+		{"SHA-256", AlgoSHA256},
+		{"sha-256-SESS", AlgoSHA256sess},
+		{"SHA-512-256", AlgoSHA512256},
+		{"SHA-512-256-sess", AlgoSHA512256sess},
+	}
+	for _, tc := range tests {
+		str := "Digest realm=\"atlanta.com\", algorithm=" + tc.algo +
+			", nonce=\"f84f1cec41e6cbe5aea9c8e88d359\""
+		ch, err := parseChallenge([]byte(str))
+		assert.Nil(t, err, tc.algo)
+		assert.Equal(t, tc.want, ch.Algo(), tc.algo)
+		assert.Equal(t, "f84f1cec41e6cbe5aea9c8e88d359", ch.Nonce())
+	}
+
+	_, err := parseChallenge([]byte("Digest realm=\"a.com\", algorithm=SHA-1"))
+	assert.NotNil(t, err)
+	_, err = parseChallenge([]byte("Digest realm=\"a.com\", algorithm=SHA-256-"))
+	assert.NotNil(t, err)
+}
+
+func TestAuthCredentialsSHA(t *testing.T) {
+	str := "Digest username=\"bob\", realm=\"example.com\",\r\n" +
+		"  nonce=\"88df84f1cac4341aea9c8ee6cbe5a359\", uri=\"sip:biloxi.com\",\r\n" +
+		"  response=\"753927fa0e85d155564e2e272a28d1802ca10daf4496794697cf8db5856cb6c1\",\r\n" +
+		"  algorithm=SHA-256, cnonce=\"0a4f113b\""
+	cr, err := parseCredentials([]byte(str))
+	assert.Nil(t, err)
+	assert.Equal(t, AlgoSHA256, cr.Algo())
+	assert.Equal(t, "753927fa0e85d155564e2e272a28d1802ca10daf4496794697cf8db5856cb6c1", cr.Response())
+	assert.Equal(t, "0a4f113b", cr.CNonce())
+
+	str = "Digest username=\"bob\", algorithm=SHA-512-256-sess, realm=\"example.com\""
+	cr, err = parseCredentials([]byte(str))
+	assert.Nil(t, err)
+	assert.Equal(t, AlgoSHA512256sess, cr.Algo())
+	assert.Equal(t, "example.com", cr.Realm())
+
+	// response must be 32 or 64 hex digits
+	str = "Digest username=\"bob\", response=\"753927fa0e85d155564e2e272a28d1802ca10daf\""
+	_, err = parseCredentials([]byte(str))
+	assert.NotNil(t, err)
+}
+
+func TestAuthAuthorizeSHA(t *testing.T) {
+	chlstr := "Digest realm=\"example.com\", algorithm=SHA-256, " +
+		"nonce=\"5db8cc4a0000142280ed54f9ae98253634445c433235da25\""
+	chlg, err := parseChallenge([]byte(chlstr))
+	assert.Nil(t, err)
+
+	cr := chlg.Authorize("REGISTER", "sip:example.com", "alice", "pa55w0rd")
+	assert.Equal(t, AlgoSHA256, cr.Algo())
+	assert.Equal(t, "e53ed26b9987c2ffffc119b256f08a7ddeef9d5f92e4f95d3a82dd5f0006f662", cr.Response())
+
+	str := "Digest username=\"alice\", realm=\"example.com\", " +
+		"nonce=\"5db8cc4a0000142280ed54f9ae98253634445c433235da25\", " +
+		"uri=\"sip:example.com\", " +
+		"response=\"e53ed26b9987c2ffffc119b256f08a7ddeef9d5f92e4f95d3a82dd5f0006f662\", " +
+		"algorithm=SHA-256"
+	assert.Equal(t, str, cr.String())
+
+	// credentials string can be parsed back
+	parsed, err := parseCredentials([]byte(cr.String()))
+	assert.Nil(t, err)
+	assert.Equal(t, cr.Response(), parsed.Response())
+
+	chlstr = "Digest realm=\"example.com\", algorithm=SHA-256-sess, nonce=\"abc\""
+	chlg, err = parseChallenge([]byte(chlstr))
+	assert.Nil(t, err)
+	cr = chlg.Authorize("REGISTER", "sip:example.com", "alice", "pa55w0rd")
+	assert.Equal(t, AlgoSHA256sess, cr.Algo())
+	assert.Len(t, cr.CNonce(), 16)
+	assert.Len(t, cr.Response(), 64)
+	assert.Contains(t, cr.String(), "algorithm=SHA-256-sess")
+}
+
+func TestAuthAuthorizeStrongest(t *testing.T) {
+	var chs []*Challenge
+	for _, algo := range []string{"MD5", "SHA-512-256", "SHA-256"} {
+		ch, err := parseChallenge([]byte("Digest realm=\"example.com\", " +
+			"nonce=\"5db8cc4a0000142280ed54f9ae98253634445c433235da25\", algorithm=" + algo))
+		assert.Nil(t, err)
+		chs = append(chs, ch)
+	}
+
+	cr := Authorize(chs, "REGISTER", "sip:example.com", "alice", "pa55w0rd")
+	assert.Equal(t, AlgoSHA512256, cr.Algo())
+	assert.Equal(t, "a4cae7f72f718d9cd9f913ce1a4b037489634204a1d2fa14a18783b55ed9976d", cr.Response())
+
+	cr = Authorize(chs[:1], "REGISTER", "sip:example.com", "alice", "pa55w0rd")
+	assert.Equal(t, AlgoMD5, cr.Algo())
+
+	assert.Nil(t, Authorize(nil, "REGISTER", "sip:example.com", "alice", "pa55w0rd"))
+}
+
+func TestAuthAuthorizeQOP(t *testing.T) {
+	// RFC2617#3.5 example
+	chlg, err := parseChallenge([]byte("Digest realm=\"testrealm@host.com\", qop=\"auth,auth-int\", " +
+		"nonce=\"dcd98b7102dd2f0e8b11d0f600bfb0c093\", opaque=\"5ccc069c403ebaf9f0171e9517f40e41\""))
+	assert.Nil(t, err)
+	cr := chlg.authorize("GET", "/dir/index.html", "Mufasa", "Circle Of Life", nil, 1, []byte("0a4f113b"))
+	assert.Equal(t, QOPAuth, cr.QOP())
+	assert.Equal(t, 1, cr.NonceCount())
+	assert.Equal(t, "0a4f113b", cr.CNonce())
+	assert.Equal(t, "5ccc069c403ebaf9f0171e9517f40e41", cr.Opaque())
+	assert.Equal(t, "6629fae49393a05397450978507c4ef1", cr.Response())
+	str := "Digest username=\"Mufasa\", realm=\"testrealm@host.com\", " +
+		"nonce=\"dcd98b7102dd2f0e8b11d0f600bfb0c093\", uri=\"/dir/index.html\", " +
+		"response=\"6629fae49393a05397450978507c4ef1\", cnonce=\"0a4f113b\", " +
+		"opaque=\"5ccc069c403ebaf9f0171e9517f40e41\", algorithm=MD5, qop=auth, nc=00000001"
 	assert.Equal(t, str, cr.String())
+
+	// RFC7616#3.9.1 example
+	chlg, err = parseChallenge([]byte("Digest realm=\"http-auth@example.org\", qop=\"auth,auth-int\", " +
+		"algorithm=SHA-256, nonce=\"7ypf/xlj9XXwfDPEoM4URrv/xwf94BcCAzFZH4GiTo0v\", " +
+		"opaque=\"FQhe/qaU925kfnzjCev0ciny7QMkPqMAFRtzCUYo5tdS\""))
+	assert.Nil(t, err)
+	cr = chlg.authorize("GET", "/dir/index.html", "Mufasa", "Circle of Life", nil, 1,
+		[]byte("f2/wE4q74E6zIJEtWaHKaf5wv/H5QzzpXusqGemxURZJ"))
+	assert.Equal(t, "753927fa0e85d155564e2e272a28d1802ca10daf4496794697cf8db5856cb6c1", cr.Response())
+
+	// credentials string is parsed back
+	cr = chlg.Authorize("REGISTER", "sip:example.org", "Mufasa", "Circle of Life")
+	parsed, err := parseCredentials([]byte(cr.String()))
+	assert.Nil(t, err)
+	assert.Equal(t, cr.Response(), parsed.Response())
+	assert.Equal(t, cr.CNonce(), parsed.CNonce())
+	assert.Equal(t, QOPAuth, parsed.QOP())
+	assert.Equal(t, 1, parsed.NonceCount())
+}
+
+func TestAuthAuthorizeAuthInt(t *testing.T) {
+	chlg, err := parseChallenge([]byte("Digest realm=\"example.com\", " +
+		"qop=\"auth,auth-int\", nonce=\"abc123\""))
+	assert.Nil(t, err)
+	body := []byte("v=0\r\n")
+	cr := chlg.authorize("INVITE", "sip:bob@example.com", "alice", "pa55w0rd", body, 1, []byte("0a4f113b"))
+	assert.Equal(t, QOPAuthInt, cr.QOP())
+	assert.Equal(t, "2e51ada1da069fb336b06a64b4439866", cr.Response())
+	assert.Contains(t, cr.String(), "qop=auth-int, nc=00000001")
+
+	// qop=auth is preferred for request without body
+	cr = chlg.Authorize("INVITE", "sip:bob@example.com", "alice", "pa55w0rd")
+	assert.Equal(t, QOPAuth, cr.QOP())
+
+	// auth-int only challenge hashes empty body
+	chlg, err = parseChallenge([]byte("Digest realm=\"example.com\", qop=\"auth-int\", nonce=\"abc123\""))
+	assert.Nil(t, err)
+	cr = chlg.Authorize("INVITE", "sip:bob@example.com", "alice", "pa55w0rd")
+	assert.Equal(t, QOPAuthInt, cr.QOP())
+	assert.Equal(t, cr.Response(), chlg.authorize("INVITE", "sip:bob@example.com",
+		"alice", "pa55w0rd", []byte{}, 1, []byte(cr.CNonce())).Response())
+}
+
+func TestAuthAuthorizeMD5sess(t *testing.T) {
+	chlg, err := parseChallenge([]byte("Digest realm=\"example.com\", qop=\"auth\", " +
+		"nonce=\"abc123\", algorithm=MD5-sess"))
+	assert.Nil(t, err)
+	cr := chlg.authorize("INVITE", "sip:bob@example.com", "alice", "pa55w0rd", nil, 2, []byte("0a4f113b"))
+	assert.Equal(t, AlgoMD5sess, cr.Algo())
+	assert.Equal(t, "d2909de7406678db896fb64faed9dd24", cr.Response())
+	assert.Contains(t, cr.String(), "algorithm=MD5-sess, qop=auth, nc=00000002")
+}
+
+func TestAuthNonceCounter(t *testing.T) {
+	chlg, err := parseChallenge([]byte("Digest realm=\"example.com\", qop=\"auth\", nonce=\"abc123\""))
+	assert.Nil(t, err)
+	nc := NewNonceCounter()
+	assert.Equal(t, uint(0), nc.NonceCount("example.com"))
+
+	cr1 := nc.Authorize(chlg, "REGISTER", "sip:example.com", "alice", "pa55w0rd", nil)
+	assert.Equal(t, 1, cr1.NonceCount())
+	cr2 := nc.Authorize(chlg, "INVITE", "sip:bob@example.com", "alice", "pa55w0rd", nil)
+	assert.Equal(t, 2, cr2.NonceCount())
+	assert.Equal(t, cr1.CNonce(), cr2.CNonce())
+	assert.Equal(t, uint(2), nc.NonceCount("example.com"))
+
+	// new nonce resets count
+	chlg, err = parseChallenge([]byte("Digest realm=\"example.com\", qop=\"auth\", nonce=\"def456\""))
+	assert.Nil(t, err)
+	cr3 := nc.Authorize(chlg, "REGISTER", "sip:example.com", "alice", "pa55w0rd", nil)
+	assert.Equal(t, 1, cr3.NonceCount())
+	assert.NotEqual(t, cr1.CNonce(), cr3.CNonce())
+
+	nc.Reset("example.com")
+	assert.Equal(t, uint(0), nc.NonceCount("example.com"))
+}
+
+func TestAuthChallengeString(t *testing.T) {
+	str := "Digest realm=\"atlanta.com\", domain=\"sip:ss1.carrier.com\", " +
+		"nonce=\"f84f1cec41e6cbe5aea9c8e88d359\", opaque=\"5ccc069c403ebaf9f0171e9517f40e41\", " +
+		"stale=true, algorithm=SHA-256, qop=\"auth,auth-int\""
+	ch, err := parseChallenge([]byte(str))
+	assert.Nil(t, err)
+	assert.Equal(t, str, ch.String())
 }
 
 func BenchmarkParseCredentials(b *testing.B) {
@@ -20,7 +20,7 @@ func TestHdrCreateVia(t *testing.T) {
 	assert.Equal(t, "sip.info", h.Host())
 	assert.Equal(t, "8060", h.Port())
 	assert.Equal(t, "Via: SIP/2.0/TCP sip.info:8060;branch="+h.Branch()+"\r\n",
-		h.buf.String())
+		h.String())
 
 	h, err = NewHdrVia("TCP", "sip.info", 69537, nil)
 	assert.NotNil(t, err)
@@ -1,6 +1,9 @@
 package sipmsg
 
-import "bytes"
+import (
+	"bytes"
+	"strings"
+)
 
 // ContentType structure represents SIP body type description
 type ContentType struct {
@@ -25,11 +28,20 @@ func (ct *ContentType) IsSDP() bool {
 		bytes.EqualFold([]byte("sdp"), ct.msubtype)
 }
 
-// Param content type header parameter
+// IsMultipart returns true if media type is multipart
+func (ct *ContentType) IsMultipart() bool {
+	return bytes.EqualFold([]byte("multipart"), ct.mtype)
+}
+
+// Param content type header parameter. Name is case-insensitive.
 func (ct *ContentType) Param(name string) string {
-	val, ok := ct.params[name]
-	if !ok {
-		return ""
+	if val, ok := ct.params[name]; ok {
+		return string(val)
+	}
+	for key, val := range ct.params {
+		if strings.EqualFold(key, name) {
+			return string(val)
+		}
 	}
-	return string(val)
+	return ""
 }
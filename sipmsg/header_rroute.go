@@ -1,5 +1,7 @@
 package sipmsg
 
+import "strings"
+
 // Recorde and Record-Route headers structure
 
 // RouteList list of Route/Record-Route header
@@ -13,6 +15,7 @@ func (r RouteList) Count() int {
 // Route headers Route/Record-Route structure
 type Route struct {
 	buf    buffer
+	line   []byte // buffer of the header line the value is parsed from
 	fname  pl
 	dname  pl
 	addr   pl
@@ -51,3 +54,26 @@ func (r *Route) AddrURI() *URI {
 func (r *Route) Param(name string) (string, bool) {
 	return searchParam(name, r.buf.Bytes(), r.params)
 }
+
+// Reverse returns new list with routes in reverse order. UAC route
+// set is the list of Record-Route headers in reverse order (RFC3261#12.1.2)
+func (r RouteList) Reverse() RouteList {
+	rev := make(RouteList, 0, len(r))
+	for i := len(r) - 1; i >= 0; i-- {
+		rev = append(rev, r[i])
+	}
+	return rev
+}
+
+// value returns route-param as name-addr with parameters
+func (r *Route) value() string {
+	var b strings.Builder
+	if dname := strings.TrimSpace(r.buf.str(r.dname)); len(dname) > 0 {
+		b.WriteString(dname + " ")
+	}
+	b.WriteString("<" + r.Addr() + ">")
+	for _, p := range r.params {
+		b.WriteString(";" + r.buf.str(p))
+	}
+	return b.String()
+}
@@ -692,3 +692,28 @@ func TestHdrWWWAuth(t *testing.T) {
 	assert.Equal(t, SIPHdrWWWAuthenticate, hid)
 	assert.Equal(t, str, msg.Headers.Find(SIPHdrWWWAuthenticate).Value())
 }
+
+func TestHdrViaParam(t *testing.T) {
+	msg := initMessage()
+	str := "Via: SIP / 2.0 / UDP first.example.com: 4000;ttl=16\r\n" +
+		" ;maddr=224.2.0.1 ;rport ; branch=z9hG4bKa7c6a8dlze.1, SIP/2.0/TCP foo.com;x=\"a,b\"\r\n"
+	_, err := parseHeader(msg, []byte(str))
+	assert.Nil(t, err)
+	via := msg.Vias[0]
+
+	val, ok := via.Param("rport")
+	assert.True(t, ok)
+	assert.Equal(t, "", val)
+	val, ok = via.Param("TTL")
+	assert.True(t, ok)
+	assert.Equal(t, "16", val)
+	val, ok = via.Param("branch")
+	assert.True(t, ok)
+	assert.Equal(t, "z9hG4bKa7c6a8dlze.1", val)
+	_, ok = via.Param("received")
+	assert.False(t, ok)
+
+	val, ok = msg.Vias[1].Param("x")
+	assert.True(t, ok)
+	assert.Equal(t, "\"a,b\"", val)
+}
@@ -1,11 +1,15 @@
 package sipmsg
 
 import (
+	"bytes"
+	"crypto/rand"
+	"fmt"
 	"strconv"
 	"strings"
 )
 
-const cookie = "z9hG4bK"
+// BranchCookie magic cookie of RFC3261 compliant Via branch
+const BranchCookie = "z9hG4bK"
 
 // ViaList list of via headers
 type ViaList []*Via
@@ -18,6 +22,7 @@ func (v ViaList) Count() int {
 // Via SIP header structure
 type Via struct {
 	buf    buffer
+	line   []byte // buffer of the header line the value is parsed from
 	name   pl
 	trans  pl // transport
 	host   pl
@@ -34,6 +39,18 @@ type Via struct {
 // Transport must be uppercase. If port is 0 then no port set (default 5060).
 // Parameters is a map. If parameters contain "branch" it will be ignored.
 func NewHdrVia(trans, host string, port uint, params map[string]string) (*Via, error) {
+	return newHdrVia(trans, host, port, params, NewBranch())
+}
+
+// NewBranch generates unique RFC3261 compliant Via branch
+// parameter value that starts with magic cookie "z9hG4bK"
+func NewBranch() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return fmt.Sprintf("%s%x", BranchCookie, b)
+}
+
+func newHdrVia(trans, host string, port uint, params map[string]string, branch string) (*Via, error) {
 	var buf buffer
 	v := &Via{}
 	buf.name("Via", &v.name)
@@ -53,6 +70,7 @@ func NewHdrVia(trans, host string, port uint, params map[string]string) (*Via, e
 	v.params.p = buf.plen()
 	for name, val := range params {
 		switch strings.ToLower(name) {
+		case "branch":
 		case "ttl":
 			buf.paramVal(name, val, &v.ttl)
 		case "maddr":
@@ -63,7 +81,7 @@ func NewHdrVia(trans, host string, port uint, params map[string]string) (*Via, e
 			buf.paramVal(name, val, nil)
 		}
 	}
-	buf.paramVal("branch", randomStringPrefix(cookie), &v.branch)
+	buf.paramVal("branch", branch, &v.branch)
 	v.params.l = buf.plen()
 
 	buf.crlf()
@@ -71,6 +89,11 @@ func NewHdrVia(trans, host string, port uint, params map[string]string) (*Via, e
 	return v, nil
 }
 
+// String returns Via header as string
+func (v *Via) String() string {
+	return v.buf.String()
+}
+
 // Transport Via header transport
 func (v *Via) Transport() string {
 	return v.buf.str(v.trans)
@@ -83,6 +106,9 @@ func (v *Via) Host() string {
 
 // Port Via header port of send-by value
 func (v *Via) Port() string {
+	if !v.hasPort() {
+		return ""
+	}
 	return v.buf.str(v.port)
 }
 
@@ -105,3 +131,79 @@ func (v *Via) MAddr() string {
 func (v *Via) Received() string {
 	return v.buf.str(v.recevd)
 }
+
+// Param returns true if Via parameter exists and parameter value
+func (v *Via) Param(name string) (string, bool) {
+	for _, prm := range v.paramList() {
+		kv := strings.SplitN(prm, "=", 2)
+		if strings.EqualFold(strings.TrimSpace(kv[0]), name) {
+			if len(kv) < 2 {
+				return "", true
+			}
+			return strings.TrimSpace(kv[1]), true
+		}
+	}
+	return "", false
+}
+
+// hasPort returns true if send-by has port. Port position must follow
+// host as parser keeps port position of previous comma separated via-parm.
+func (v *Via) hasPort() bool {
+	return v.port.l > v.port.p && v.port.p >= v.host.l
+}
+
+// sentBy returns Via header send-by value as host or host:port
+func (v *Via) sentBy() string {
+	if v.hasPort() {
+		return v.Host() + ":" + v.Port()
+	}
+	return v.Host()
+}
+
+// extent returns start and end positions of the via-parm in the
+// buffer. Buffer can contain several comma separated via-parms.
+func (v *Via) extent() pl {
+	buf := v.buf.Bytes()
+	start := bytes.LastIndex(buf[:v.trans.p], []byte("SIP"))
+	if start < 0 {
+		start = int(v.trans.p)
+	}
+	end := int(v.host.l)
+	if v.hasPort() {
+		end = int(v.port.l)
+	}
+	quoted := false
+	for ; end < len(buf); end++ {
+		if buf[end] == '"' {
+			quoted = !quoted
+		}
+		if !quoted && (buf[end] == ',' || bytes.HasPrefix(buf[end:], []byte("\r\n"))) {
+			// folded line continues header value
+			if buf[end] == '\r' && end+2 < len(buf) && (buf[end+2] == ' ' || buf[end+2] == '\t') {
+				continue
+			}
+			break
+		}
+	}
+	return pl{ptr(start), ptr(end)}
+}
+
+// paramList returns list of via parameters as "name=value" strings
+func (v *Via) paramList() []string {
+	start := v.host.l
+	if v.hasPort() {
+		start = v.port.l
+	}
+	ext := v.extent()
+	if ext.l <= start {
+		return nil
+	}
+	params := make([]string, 0)
+	for _, prm := range strings.Split(v.buf.str(pl{start, ext.l}), ";") {
+		prm = strings.Join(strings.Fields(prm), "")
+		if len(prm) > 0 {
+			params = append(params, prm)
+		}
+	}
+	return params
+}
@@ -2,10 +2,17 @@ package sipmsg
 
 import (
 	"bytes"
+	"container/list"
+	"net"
 	"strconv"
+	"strings"
+
+	"github.com/staskobzar/gosip/sdp"
 )
 
-type ptr uint16
+// ptr is offset in header buffer. It has the size of int so
+// that headers and messages are not limited to 64KB.
+type ptr int
 
 // Structure to replresent position in []byte buffer
 // "p" points to start position and "l" points to the last.
@@ -38,6 +45,7 @@ type Message struct {
 	MaxFwd     uint
 	Headers    HeadersList
 	Body       []byte
+	compact    bool
 }
 
 func initMessage() *Message {
@@ -64,20 +72,34 @@ func MsgParse(data []byte) (*Message, error) {
 		return nil, ErrorSIPMsgParse.msg("Missing Request/Status line")
 	}
 
-	start := idx
-	for i := idx; i < len(data); {
+	pos, err := parseHeaders(msg, data[idx:])
+	if err != nil {
+		return nil, err
+	}
+	if start := idx + pos; start < len(data) {
+		msg.Body = data[start:]
+	}
+	return msg, nil
+}
+
+// parseHeaders parses header lines until empty line
+// and returns position of the body
+func parseHeaders(msg *Message, data []byte) (int, error) {
+	var hid HdrType
+	start := 0
+	for i := 0; i < len(data); {
 		if bytes.HasPrefix(data[i:], []byte("\r\n")) {
 			i += 2
 			if i < len(data) && (data[i] == ' ' || data[i] == '\t') {
 				continue
 			}
+			var err error
 			hid, err = parseHeader(msg, data[start:i])
 			if err != nil {
-				return nil, err
+				return 0, err
 			}
 			if hid == MsgEOF {
-				start += 2
-				break
+				return i, nil
 			}
 			start = i
 			continue
@@ -85,14 +107,7 @@ func MsgParse(data []byte) (*Message, error) {
 		i++
 	}
 	// must be CRLF in the end of the SIP Message
-	if hid != MsgEOF {
-		return nil, ErrorSIPMsgParse.msg("Message must be finished with CRLF (%d)", hid)
-	}
-
-	if start < len(data) {
-		msg.Body = data[start:]
-	}
-	return msg, nil
+	return 0, ErrorSIPMsgParse.msg("Message must be finished with CRLF (%d)", hid)
 }
 
 // NewRequest initiate SIP Request
@@ -162,8 +177,36 @@ func (m *Message) NewResponse(code int, reason string) (*Message, error) {
 	return resp, nil
 }
 
+// NewResponseTag creates response to the request and adds To tag
+// if request does not have it (RFC3261#8.2.6.2)
+func (m *Message) NewResponseTag(code int, reason string) (*Message, error) {
+	resp, err := m.NewResponse(code, reason)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.To.Tag()) == 0 {
+		if err := resp.AddToTag(); err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+
+// NewResponseSDP creates response to request with SDP answer as body
+func (m *Message) NewResponseSDP(code int, reason string, answer *sdp.Message) (*Message, error) {
+	resp, err := m.NewResponse(code, reason)
+	if err != nil {
+		return nil, err
+	}
+	if err := resp.SetSDP(answer); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // NewACK creates ACK for Request from Response.
-// Used by transactions
+// Used by transactions for non-2xx responses. ACK for 2xx
+// response is created by dialog (RFC3261#13.2.2.4).
 func (m *Message) NewACK(resp *Message) (*Message, error) {
 	if !m.IsRequest() {
 		return nil, ErrorSIPMsgCreate.msg("ACK can be generated only to SIP request.")
@@ -196,6 +239,179 @@ func (m *Message) NewACK(resp *Message) (*Message, error) {
 	return ack, nil
 }
 
+// NewCANCEL creates CANCEL for the request (RFC3261#9.1).
+// Request-URI, Call-ID, To, From, Route headers and top Via are
+// copied from the request. CSeq has the same number and method CANCEL.
+func (m *Message) NewCANCEL() (*Message, error) {
+	if !m.IsRequest() {
+		return nil, ErrorSIPMsgCreate.msg("CANCEL can be generated only to SIP request.")
+	}
+	if met := m.ReqLine.Method(); met == "ACK" || met == "CANCEL" {
+		return nil, ErrorSIPMsgCreate.msg("%s request can not be cancelled.", met)
+	}
+	if m.Vias.Count() == 0 || m.CSeq == nil || m.From == nil || m.To == nil {
+		return nil, ErrorSIPMsgCreate.msg("Request has no Via, CSeq, From or To header.")
+	}
+	cancel := initMessage()
+	cancel.ReqLine = NewReqLine("CANCEL", m.ReqLine.RequestURI())
+	// single Via header equal to the top Via of the request
+	via := m.Vias[0]
+	cancel.Vias = append(cancel.Vias, via)
+	cancel.pushHeader(SIPHdrVia, via.buf.Bytes(), via.name, pl{via.name.l + 2, via.buf.plen()})
+
+	cancel.MaxFwd = 70
+	buf, plName, plVal := headerValue("Max-Forwards", "70")
+	cancel.pushHeader(SIPHdrMaxForwards, buf, plName, plVal)
+
+	cancel.copyHeader(m, SIPHdrRoute)
+	cancel.copyHeader(m, SIPHdrFrom)
+	cancel.copyHeader(m, SIPHdrTo)
+	cancel.copyHeader(m, SIPHdrCallID)
+	cancel.CSeq = &CSeq{m.CSeq.Num, "CANCEL"}
+	buf, plName, plVal = headerValue("CSeq", strconv.Itoa(int(cancel.CSeq.Num)), cancel.CSeq.Method)
+	cancel.pushHeader(SIPHdrCSeq, buf, plName, plVal)
+
+	return cancel, nil
+}
+
+// Clone returns copy of the message. Copy can be changed with
+// message methods without changing the original message.
+func (m *Message) Clone() *Message {
+	cp := initMessage()
+	cp.ReqLine, cp.StatusLine = m.ReqLine, m.StatusLine
+	cp.CSeq, cp.CallID = m.CSeq, m.CallID
+	cp.ContentLen, cp.Expires, cp.MaxFwd = m.ContentLen, m.Expires, m.MaxFwd
+	cp.Body, cp.compact = m.Body, m.compact
+	if m.From != nil {
+		cp.From = initHeaderFromTo(m.From.buf.Bytes(), m.From.params, m.From.name, m.From.dname, m.From.addr, m.From.tag)
+	}
+	if m.To != nil {
+		cp.To = initHeaderFromTo(m.To.buf.Bytes(), m.To.params, m.To.name, m.To.dname, m.To.addr, m.To.tag)
+	}
+	cp.Contacts = ContactsList{cnt: append([]*Contact{}, m.Contacts.cnt...), star: m.Contacts.star}
+	cp.Vias = append(ViaList{}, m.Vias...)
+	cp.Routes = append(RouteList{}, m.Routes...)
+	cp.RecRoutes = append(RouteList{}, m.RecRoutes...)
+	m.Headers.ForEach(func(h *Header) {
+		cp.pushHeader(h.id, h.buf, h.name, h.value)
+	})
+	return cp
+}
+
+// CredentialsFunc returns username and password for the realm.
+// Returns false if there are no credentials for the realm.
+type CredentialsFunc func(realm string) (user, password string, ok bool)
+
+// NewAuthorized creates new request from the request challenged by
+// 401 or 407 response (RFC3261#22.2). Authorization (Proxy-Authorization
+// for 407) header is added for each challenged realm using the strongest
+// offered algorithm. CSeq is incremented and new Via branch is generated.
+// Counter is used to track nonce count and can be nil.
+func (m *Message) NewAuthorized(resp *Message, creds CredentialsFunc, counter *NonceCounter) (*Message, error) {
+	if !m.IsRequest() || m.CSeq == nil {
+		return nil, ErrorSIPMsgCreate.msg("Authorized request can be generated only from SIP request.")
+	}
+	chid, crid, name := SIPHdrWWWAuthenticate, SIPHdrAuthorization, "Authorization"
+	switch resp.Code() {
+	case 401:
+	case 407:
+		chid, crid, name = SIPHdrProxyAuthenticate, SIPHdrProxyAuthorization, "Proxy-Authorization"
+	default:
+		return nil, ErrorSIPMsgCreate.msg("Response %d is not a challenge.", resp.Code())
+	}
+
+	// strongest challenge for each realm in order of appearance
+	var realms []string
+	byRealm := make(map[string]*Challenge)
+	for _, ch := range resp.challenges(chid) {
+		best, ok := byRealm[ch.Realm()]
+		if !ok {
+			realms = append(realms, ch.Realm())
+		}
+		if !ok || ch.algo.strength() > best.algo.strength() {
+			byRealm[ch.Realm()] = ch
+		}
+	}
+	if len(realms) == 0 {
+		return nil, ErrorSIPMsgCreate.msg("Response has no valid challenge.")
+	}
+
+	req, err := MsgParse(m.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	method, uri := m.ReqLine.Method(), m.ReqLine.RequestURI()
+	authorized := 0
+	for _, realm := range realms {
+		ch := byRealm[realm]
+		// credentials were rejected if challenge is not stale
+		if req.removeCredentials(crid, realm) && !ch.Stale() {
+			return nil, ErrorSIPMsgCreate.msg("Credentials for realm %q were rejected.", realm)
+		}
+		user, password, ok := creds(realm)
+		if !ok {
+			continue
+		}
+		var cr *Credentials
+		if counter != nil {
+			cr = counter.Authorize(ch, method, uri, user, password, m.Body)
+		} else {
+			cr = ch.AuthorizeBody(method, uri, user, password, m.Body)
+		}
+		if err := req.AddHeader(name, cr.String()); err != nil {
+			return nil, err
+		}
+		authorized++
+	}
+	if authorized == 0 {
+		return nil, ErrorSIPMsgCreate.msg("No credentials for challenged realms.")
+	}
+
+	if err := req.updateCSeq(m.CSeq.Num + 1); err != nil {
+		return nil, err
+	}
+	if err := req.SetViaParam("branch", NewBranch()); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// WWWAuthenticate returns challenges of WWW-Authenticate headers.
+// Headers that can not be parsed are skipped.
+func (m *Message) WWWAuthenticate() []*Challenge {
+	return m.challenges(SIPHdrWWWAuthenticate)
+}
+
+// ProxyAuthenticate returns challenges of Proxy-Authenticate headers.
+// Headers that can not be parsed are skipped.
+func (m *Message) ProxyAuthenticate() []*Challenge {
+	return m.challenges(SIPHdrProxyAuthenticate)
+}
+
+// Authorization returns credentials of Authorization headers.
+// Headers that can not be parsed are skipped.
+func (m *Message) Authorization() []*Credentials {
+	return m.credentials(SIPHdrAuthorization)
+}
+
+// ProxyAuthorization returns credentials of Proxy-Authorization headers.
+// Headers that can not be parsed are skipped.
+func (m *Message) ProxyAuthorization() []*Credentials {
+	return m.credentials(SIPHdrProxyAuthorization)
+}
+
+// SetAuthorization adds Authorization header with credentials.
+// Existing credentials for the same realm are replaced.
+func (m *Message) SetAuthorization(cr *Credentials) error {
+	return m.setCredentials(SIPHdrAuthorization, "Authorization", cr)
+}
+
+// SetProxyAuthorization adds Proxy-Authorization header with credentials.
+// Existing credentials for the same realm are replaced.
+func (m *Message) SetProxyAuthorization(cr *Credentials) error {
+	return m.setCredentials(SIPHdrProxyAuthorization, "Proxy-Authorization", cr)
+}
+
 // IsRequest returns true is SIP Message is request
 func (m *Message) IsRequest() bool { return m.ReqLine != nil }
 
@@ -228,19 +444,92 @@ func (m *Message) Bytes() []byte {
 	return b.Bytes()
 }
 
-// HasSDP returns true if content type is application/sdp and body present
+// HasSDP returns true if content type is application/sdp or
+// multipart body has application/sdp part
 func (m *Message) HasSDP() bool {
-	hdr := m.Headers.Find(SIPHdrContentType)
-	if hdr == nil {
+	ct := contentType(m.Headers)
+	if ct == nil {
 		return false
 	}
+	return ct.IsSDP() || (ct.IsMultipart() && m.SDPBody() != nil)
+}
 
-	ct, err := parseContentType([]byte(hdr.Value()))
+// SDPBody returns message body if content type is application/sdp
+// or body of SDP part of multipart body. Returns nil if no SDP found.
+func (m *Message) SDPBody() []byte {
+	ct := contentType(m.Headers)
+	if ct == nil {
+		return nil
+	}
+	if ct.IsSDP() {
+		return m.Body
+	}
+	mp, err := m.Multipart()
 	if err != nil {
-		return false
+		return nil
+	}
+	if part := mp.SDP(); part != nil {
+		return part.Body
+	}
+	return nil
+}
+
+// SDP parses SDP body of the message. SDP part is used
+// if message has multipart body.
+func (m *Message) SDP() (*sdp.Message, error) {
+	body := m.SDPBody()
+	if len(body) == 0 {
+		return nil, ErrorSIPMsgParse.msg("Message has no SDP body.")
+	}
+	return sdp.Parse(body)
+}
+
+// SetSDP sets SDP offer or answer as message body and updates
+// Content-Type and Content-Length headers
+func (m *Message) SetSDP(s *sdp.Message) error {
+	if s == nil {
+		return ErrorSIPMsgCreate.msg("Invalid SDP message.")
+	}
+	return m.SetBody("application/sdp", []byte(s.String()))
+}
+
+// Multipart parses multipart body of the message
+func (m *Message) Multipart() (*Multipart, error) {
+	ct := contentType(m.Headers)
+	if ct == nil {
+		return nil, ErrorSIPMsgParse.msg("Missing or invalid Content-Type.")
+	}
+	return parseMultipart(ct, m.Body)
+}
+
+// SetMultipart sets multipart body of the message
+func (m *Message) SetMultipart(mp *Multipart) error {
+	if mp == nil || len(mp.Parts) == 0 || mp.Boundary == "" {
+		return ErrorSIPMsgCreate.msg("Multipart body must have boundary and parts.")
 	}
+	return m.SetBody(mp.ContentType(), mp.Bytes())
+}
 
-	return ct.IsSDP()
+// SetBody sets message body and replaces Content-Type and
+// Content-Length headers. Content-Type is removed if empty.
+func (m *Message) SetBody(ctype string, body []byte) error {
+	if ctype != "" {
+		if _, err := parseContentType([]byte(ctype)); err != nil {
+			return err
+		}
+	}
+	m.RemoveHeader("Content-Type")
+	m.RemoveHeader("Content-Length")
+	if ctype != "" {
+		if err := m.AddHeader("Content-Type", ctype); err != nil {
+			return err
+		}
+	}
+	if err := m.AddHeader("Content-Length", strconv.Itoa(len(body))); err != nil {
+		return err
+	}
+	m.Body = body
+	return nil
 }
 
 // AddToTag appends tag parameter to To header if not exists.
@@ -257,8 +546,239 @@ func (m *Message) AddToTag() error {
 	return nil
 }
 
-// AddHeader appends new header to the end of SIP message.
-// If header is invalid returns error.
+// SetRequestURI replaces Request-URI of the request line.
+// Proxy forwards request to the target with new Request-URI (RFC3261#16.6).
+func (m *Message) SetRequestURI(uri string) error {
+	if !m.IsRequest() {
+		return ErrorSIPMsgCreate.msg("Request-URI can be set only to SIP request.")
+	}
+	if URIParse([]byte(uri)) == nil {
+		return ErrorSIPMsgCreate.msg("Invalid Request-URI %s", uri)
+	}
+	m.ReqLine = NewReqLine(m.ReqLine.Method(), uri)
+	return nil
+}
+
+// SetMaxForwards replaces Max-Forwards header value or adds
+// the header if message does not have it
+func (m *Message) SetMaxForwards(max uint) {
+	buf, name, value := headerValue("Max-Forwards", strconv.Itoa(int(max)))
+	m.MaxFwd = max
+	if hdr := m.Headers.Find(SIPHdrMaxForwards); hdr != nil {
+		hdr.buf, hdr.name, hdr.value = buf, name, value
+		return
+	}
+	m.pushHeader(SIPHdrMaxForwards, buf, name, value)
+}
+
+// SetViaParam adds parameter to the top Via header or updates its value
+// if parameter exists. If value is empty then parameter without value
+// is set (for example ";rport"). Top Via header is re-parsed to keep
+// Vias list and headers list consistent.
+func (m *Message) SetViaParam(name, value string) error {
+	if m.Vias.Count() == 0 {
+		return ErrorSIPHeader.msg("Message has no Via header.")
+	}
+	hdr := m.Headers.Find(SIPHdrVia)
+	if hdr == nil {
+		return ErrorSIPHeader.msg("Message has no Via header.")
+	}
+	via := m.Vias[0]
+
+	var b buffer
+	b.WriteString("SIP/2.0/")
+	b.WriteString(via.Transport())
+	b.WriteByte(' ')
+	b.WriteString(via.sentBy())
+	found := false
+	for _, prm := range via.paramList() {
+		kv := strings.SplitN(prm, "=", 2)
+		if strings.EqualFold(kv[0], name) {
+			prm, found = name, true
+			if len(value) > 0 {
+				prm += "=" + value
+			}
+		}
+		b.WriteByte(';')
+		b.WriteString(prm)
+	}
+	if !found {
+		b.WriteByte(';')
+		b.WriteString(name)
+		if len(value) > 0 {
+			b.WriteByte('=')
+			b.WriteString(value)
+		}
+	}
+
+	n := m.topViaCount(hdr)
+	ext := via.extent()
+	line := make([]byte, 0, len(hdr.buf)+b.Len())
+	line = append(line, hdr.buf[:ext.p]...)
+	line = append(line, b.Bytes()...)
+	line = append(line, hdr.buf[ext.l:]...)
+
+	tmp := initMessage()
+	if _, err := parseHeader(tmp, line); err != nil {
+		return err
+	}
+	h := tmp.Headers.Find(SIPHdrVia)
+	if h == nil {
+		return ErrorSIPHeader.msg("Invalid Via parameter %s=%s", name, value)
+	}
+	hdr.buf, hdr.name, hdr.value = h.buf, h.name, h.value
+
+	vias := make(ViaList, 0, m.Vias.Count())
+	vias = append(vias, tmp.Vias...)
+	m.Vias = append(vias, m.Vias[n:]...)
+	return nil
+}
+
+// SetViaReceived sets received and rport parameters of the top Via
+// with the source address of the request (RFC3261#18.2.1, RFC3581#4).
+// Parameter received is added if Via sent-by host differs from the
+// source address or if rport is requested.
+func (m *Message) SetViaReceived(ip string, port int) error {
+	if m.Vias.Count() == 0 {
+		return ErrorSIPHeader.msg("Message has no Via header.")
+	}
+	if _, ok := m.Vias[0].Param("rport"); ok {
+		if err := m.SetViaParam("rport", strconv.Itoa(port)); err != nil {
+			return err
+		}
+		return m.SetViaParam("received", ip)
+	}
+	if host := net.ParseIP(m.Vias[0].Host()); host != nil && host.Equal(net.ParseIP(ip)) {
+		return nil
+	}
+	return m.SetViaParam("received", ip)
+}
+
+// PushVia adds Via header on top of the Via headers. If branch is
+// empty then new unique branch is generated (RFC3261#8.1.1.7).
+func (m *Message) PushVia(trans, host string, port uint, branch string) (*Via, error) {
+	if len(branch) == 0 {
+		branch = NewBranch()
+	}
+	via, err := newHdrVia(trans, host, port, nil, branch)
+	if err != nil {
+		return nil, err
+	}
+	via.line = via.buf.Bytes()
+	h := &Header{
+		buf:   via.line,
+		id:    SIPHdrVia,
+		name:  via.name,
+		value: pl{via.name.l + 2, via.buf.plen()},
+	}
+	if e := m.topViaElement(); e != nil {
+		m.Headers.InsertBefore(h, e)
+	} else {
+		m.Headers.PushFront(h)
+	}
+	m.Vias = append(ViaList{via}, m.Vias...)
+	return via, nil
+}
+
+// PopVia removes top Via header value and returns it. Proxy removes
+// its own Via from responses before forwarding (RFC3261#16.7).
+func (m *Message) PopVia() (*Via, error) {
+	e := m.topViaElement()
+	if e == nil || m.Vias.Count() == 0 {
+		return nil, ErrorSIPHeader.msg("Message has no Via header.")
+	}
+	hdr := e.Value.(*Header)
+	via := m.Vias[0]
+	n := m.topViaCount(hdr)
+	if n == 1 {
+		m.Headers.Remove(e)
+		m.Vias = m.Vias[1:]
+		return via, nil
+	}
+
+	// remove first via-parm of comma separated values
+	ext := via.extent()
+	line := make([]byte, 0, len(hdr.buf))
+	line = append(line, hdr.buf[:ext.p]...)
+	line = append(line, bytes.TrimLeft(hdr.buf[ext.l:], ", \t\r\n")...)
+
+	tmp := initMessage()
+	if _, err := parseHeader(tmp, line); err != nil {
+		return nil, err
+	}
+	h := tmp.Headers.Find(SIPHdrVia)
+	if h == nil {
+		return nil, ErrorSIPHeader.msg("Invalid Via header.")
+	}
+	hdr.buf, hdr.name, hdr.value = h.buf, h.name, h.value
+
+	vias := make(ViaList, 0, m.Vias.Count()-1)
+	vias = append(vias, tmp.Vias...)
+	m.Vias = append(vias, m.Vias[n:]...)
+	return via, nil
+}
+
+// PushRoute adds Route header with address on top of the route set
+func (m *Message) PushRoute(addr string) error {
+	return m.insertRoute(SIPHdrRoute, addr, true)
+}
+
+// AppendRoute adds Route header with address to the end of the route set
+func (m *Message) AppendRoute(addr string) error {
+	return m.insertRoute(SIPHdrRoute, addr, false)
+}
+
+// PopRoute removes top Route value and returns it. Proxy removes
+// Route with its own address (RFC3261#16.4).
+func (m *Message) PopRoute() (*Route, error) {
+	return m.removeRoute(SIPHdrRoute, true)
+}
+
+// PopLastRoute removes last Route value and returns it. Proxy uses last
+// Route as Request-URI when request is received from strict router
+// (RFC3261#16.4).
+func (m *Message) PopLastRoute() (*Route, error) {
+	return m.removeRoute(SIPHdrRoute, false)
+}
+
+// PushRecordRoute adds Record-Route header with address on top
+// of the Record-Route headers (RFC3261#16.6 step 4)
+func (m *Message) PushRecordRoute(addr string) error {
+	return m.insertRoute(SIPHdrRecordRoute, addr, true)
+}
+
+// PopRecordRoute removes top Record-Route value and returns it
+func (m *Message) PopRecordRoute() (*Route, error) {
+	return m.removeRoute(SIPHdrRecordRoute, true)
+}
+
+// SetRouteSet replaces Route headers with Route header for each
+// entry of route set. UAC route set is Record-Route list of the
+// response in reverse order (RFC3261#12.1.2), for example
+// req.SetRouteSet(resp.RecRoutes.Reverse()).
+func (m *Message) SetRouteSet(set RouteList) error {
+	values := make([]string, 0, len(set))
+	for _, r := range set {
+		values = append(values, r.value())
+	}
+	for e := m.Headers.Front(); e != nil; {
+		next := e.Next()
+		if e.Value.(*Header).ID() == SIPHdrRoute {
+			m.Headers.Remove(e)
+		}
+		e = next
+	}
+	m.Routes = nil
+	for _, val := range values {
+		if err := m.AppendRoute(val); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddHeader appends new header to the end of SIP message. Name is
+// case-insensitive and can be compact form. If header is invalid returns error.
 func (m *Message) AddHeader(name, value string) error {
 	buf, _, _ := headerValue(name, value)
 	_, err := parseHeader(m, buf)
@@ -268,8 +788,14 @@ func (m *Message) AddHeader(name, value string) error {
 	return nil
 }
 
-// RemoveHeader removes header(s) from headers list.
-// Returns true id found and removed.
+// SetCompact enables serialization of header names in compact
+// form (RFC3261#7.3.3) to reduce size of the message sent over UDP.
+func (m *Message) SetCompact(on bool) {
+	m.compact = on
+}
+
+// RemoveHeader removes header(s) from headers list. Name is
+// case-insensitive and can be compact form. Returns true id found and removed.
 func (m *Message) RemoveHeader(name string) bool {
 	return m.Headers.remove(name)
 }
@@ -280,6 +806,213 @@ func (m *Message) String() string {
 	return b.String()
 }
 
+func (m *Message) challenges(id HdrType) []*Challenge {
+	list := make([]*Challenge, 0)
+	for _, h := range m.Headers.FindAll(id) {
+		if ch, err := parseChallenge([]byte(h.Value())); err == nil {
+			list = append(list, ch)
+		}
+	}
+	return list
+}
+
+func (m *Message) credentials(id HdrType) []*Credentials {
+	list := make([]*Credentials, 0)
+	for _, h := range m.Headers.FindAll(id) {
+		if cr, err := parseCredentials([]byte(h.Value())); err == nil {
+			list = append(list, cr)
+		}
+	}
+	return list
+}
+
+// setCredentials replaces credentials of the realm with new header
+func (m *Message) setCredentials(id HdrType, name string, cr *Credentials) error {
+	if cr == nil {
+		return ErrorSIPHeader.msg("Invalid %s credentials.", name)
+	}
+	// validate before existing credentials are removed
+	if _, err := parseCredentials([]byte(cr.String())); err != nil {
+		return err
+	}
+	m.removeCredentials(id, cr.Realm())
+	return m.AddHeader(name, cr.String())
+}
+
+// removeCredentials removes authorization headers for the realm.
+// Returns true if found and removed.
+func (m *Message) removeCredentials(id HdrType, realm string) bool {
+	found := false
+	for e := m.Headers.Front(); e != nil; {
+		next := e.Next()
+		h := e.Value.(*Header)
+		if h.ID() == id {
+			if cr, err := parseCredentials([]byte(h.Value())); err == nil && cr.Realm() == realm {
+				m.Headers.Remove(e)
+				found = true
+			}
+		}
+		e = next
+	}
+	return found
+}
+
+// insertRoute adds Route or Record-Route header on top or
+// to the end of the headers with the same id
+func (m *Message) insertRoute(hid HdrType, addr string, top bool) error {
+	name := "Route"
+	if hid == SIPHdrRecordRoute {
+		name = "Record-Route"
+	}
+	if !strings.Contains(addr, "<") {
+		addr = "<" + addr + ">"
+	}
+	buf, _, _ := headerValue(name, addr)
+	tmp := initMessage()
+	if _, err := parseHeader(tmp, buf); err != nil {
+		return err
+	}
+	h := tmp.Headers.Find(hid)
+	if h == nil {
+		return ErrorSIPHeader.msg("Invalid %s header %s", name, addr)
+	}
+	routes, added := &m.Routes, tmp.Routes
+	if hid == SIPHdrRecordRoute {
+		routes, added = &m.RecRoutes, tmp.RecRoutes
+	}
+
+	pos := m.routeElement(hid, top)
+	switch {
+	case pos == nil:
+		m.Headers.PushBack(h)
+	case top:
+		m.Headers.InsertBefore(h, pos)
+	default:
+		m.Headers.InsertAfter(h, pos)
+	}
+	if top {
+		*routes = append(added, *routes...)
+	} else {
+		*routes = append(*routes, added...)
+	}
+	return nil
+}
+
+// removeRoute removes first or last Route or Record-Route value.
+// Header is removed if it has no more values.
+func (m *Message) removeRoute(hid HdrType, first bool) (*Route, error) {
+	routes := &m.Routes
+	if hid == SIPHdrRecordRoute {
+		routes = &m.RecRoutes
+	}
+	elem := m.routeElement(hid, first)
+	if elem == nil || len(*routes) == 0 {
+		return nil, ErrorSIPHeader.msg("Message has no route header.")
+	}
+	hdr := elem.Value.(*Header)
+	all := *routes
+
+	// routes of the header comma separated values are all[start:end]
+	start, end := 0, len(all)
+	if first {
+		end = 1
+		for end < len(all) && sameBuf(all[end].line, hdr.buf) {
+			end++
+		}
+	} else {
+		start = len(all) - 1
+		for start > 0 && sameBuf(all[start-1].line, hdr.buf) {
+			start--
+		}
+	}
+	line := append(RouteList{}, all[start:end]...)
+	var r *Route
+	if first {
+		r, line = line[0], line[1:]
+	} else {
+		r, line = line[len(line)-1], line[:len(line)-1]
+	}
+
+	rest := make(RouteList, 0, len(all)-1)
+	rest = append(rest, all[:start]...)
+	if len(line) == 0 {
+		m.Headers.Remove(elem)
+	} else {
+		values := make([]string, 0, len(line))
+		for _, rt := range line {
+			values = append(values, rt.value())
+		}
+		buf, _, _ := headerValue(hdr.Name(), strings.Join(values, ", "))
+		tmp := initMessage()
+		if _, err := parseHeader(tmp, buf); err != nil {
+			return nil, err
+		}
+		h := tmp.Headers.Find(hid)
+		hdr.buf, hdr.name, hdr.value = h.buf, h.name, h.value
+		if hid == SIPHdrRecordRoute {
+			rest = append(rest, tmp.RecRoutes...)
+		} else {
+			rest = append(rest, tmp.Routes...)
+		}
+	}
+	*routes = append(rest, all[end:]...)
+	return r, nil
+}
+
+// routeElement returns headers list element of the first
+// or the last header with id
+func (m *Message) routeElement(hid HdrType, first bool) *list.Element {
+	var elem *list.Element
+	for e := m.Headers.Front(); e != nil; e = e.Next() {
+		if e.Value.(*Header).ID() == hid {
+			elem = e
+			if first {
+				break
+			}
+		}
+	}
+	return elem
+}
+
+// topViaElement returns headers list element of the top Via header
+func (m *Message) topViaElement() *list.Element {
+	for e := m.Headers.Front(); e != nil; e = e.Next() {
+		if e.Value.(*Header).ID() == SIPHdrVia {
+			return e
+		}
+	}
+	return nil
+}
+
+// topViaCount returns number of via-parms in the top Via header
+func (m *Message) topViaCount(hdr *Header) int {
+	n := 0
+	for n < m.Vias.Count() && sameBuf(m.Vias[n].line, hdr.buf) {
+		n++
+	}
+	if n == 0 {
+		n = 1
+	}
+	return n
+}
+
+// updateCSeq replaces CSeq header sequence number
+func (m *Message) updateCSeq(num uint) error {
+	hdr := m.Headers.Find(SIPHdrCSeq)
+	if hdr == nil || m.CSeq == nil {
+		return ErrorSIPHeader.msg("Message has no CSeq header.")
+	}
+	tmp := initMessage()
+	buf, _, _ := headerValue("CSeq", strconv.Itoa(int(num)), m.CSeq.Method)
+	if _, err := parseHeader(tmp, buf); err != nil {
+		return err
+	}
+	h := tmp.Headers.Find(SIPHdrCSeq)
+	hdr.buf, hdr.name, hdr.value = h.buf, h.name, h.value
+	m.CSeq = tmp.CSeq
+	return nil
+}
+
 func (m *Message) buffer() buffer {
 	var buf buffer
 	if m.IsRequest() {
@@ -288,8 +1021,15 @@ func (m *Message) buffer() buffer {
 		buf.Write(m.StatusLine.Bytes())
 	}
 
-	m.Headers.ForEach(func(h *Header) { buf.Write(h.buf) })
+	m.Headers.ForEach(func(h *Header) {
+		if m.compact {
+			buf.Write(h.compact())
+			return
+		}
+		buf.Write(h.buf)
+	})
 	buf.crlf()
+	buf.Write(m.Body)
 	return buf
 }
 
@@ -382,10 +1122,11 @@ func (m *Message) setVia(data []byte, name, trans, addr, port, branch, ttl, madd
 	if m.Vias.Count() == 0 || m.Vias.Count() == i {
 		var buf buffer
 		buf.init(data)
-		m.Vias = append(m.Vias, &Via{buf: buf, name: name})
+		m.Vias = append(m.Vias, &Via{buf: buf, line: data, name: name})
 
-		if !m.Headers.exists(data) {
-			m.pushHeader(SIPHdrVia, buf.Bytes(), name, pl{name.l + 1, buf.plen()})
+		// comma separated values share the same header line
+		if !m.Headers.isLast(data) {
+			m.pushHeader(SIPHdrVia, data, name, pl{name.l + 1, buf.plen()})
 		}
 	}
 	m.Vias[i].trans = trans
@@ -402,6 +1143,7 @@ func (m *Message) setRoute(hid HdrType, buf []byte, fname, dname, addr pl, param
 	b.init(buf)
 	r := &Route{
 		buf:    b,
+		line:   buf,
 		fname:  fname,
 		dname:  dname,
 		addr:   addr,
@@ -409,11 +1151,13 @@ func (m *Message) setRoute(hid HdrType, buf []byte, fname, dname, addr pl, param
 	}
 	if hid == SIPHdrRecordRoute {
 		m.RecRoutes = append(m.RecRoutes, r)
-		m.pushHeader(SIPHdrRecordRoute, buf, fname, pl{fname.l + 1, r.buf.plen()})
-		return
+	} else {
+		m.Routes = append(m.Routes, r)
+	}
+	// comma separated values share the same header line
+	if !m.Headers.isLast(buf) {
+		m.pushHeader(hid, buf, fname, pl{fname.l + 1, r.buf.plen()})
 	}
-	m.Routes = append(m.Routes, r)
-	m.pushHeader(SIPHdrRoute, buf, fname, pl{fname.l + 1, r.buf.plen()})
 }
 
 func (m *Message) setExpires(num []byte) HdrType {
@@ -466,6 +1210,9 @@ func (m *Message) copyHeader(src *Message, id HdrType) {
 		m.CallID = src.CallID
 	case SIPHdrCSeq:
 		m.CSeq = &CSeq{src.CSeq.Num, src.CSeq.Method}
+	case SIPHdrRoute:
+		m.Routes = make(RouteList, src.Routes.Count())
+		copy(m.Routes, src.Routes)
 	}
 
 	src.Headers.ForEach(func(h *Header) {
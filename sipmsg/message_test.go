@@ -1,8 +1,12 @@
 package sipmsg
 
 import (
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/staskobzar/gosip/sdp"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -172,6 +176,57 @@ func TestMessageParseWithBody(t *testing.T) {
 	assert.True(t, msg.IsInvite())
 }
 
+func TestMessageParseLarge(t *testing.T) {
+	body := strings.Repeat("0123456789abcdef", 5000)
+	str := "MESSAGE sip:bob@biloxi.example.com SIP/2.0\r\n" +
+		"Via: SIP/2.0/TCP client.atlanta.example.com:5060;branch=z9hG4bKbf9f44\r\n" +
+		"Max-Forwards: 70\r\n" +
+		"To: Bob <sip:bob@biloxi.example.com>\r\n" +
+		"From: Alice <sip:alice@atlanta.example.com>;tag=49583\r\n" +
+		"Call-ID: asd88asd77a@1.2.3.4\r\n" +
+		"CSeq: 1 MESSAGE\r\n" +
+		"Content-Type: text/plain\r\n" +
+		"Content-Length: 80000\r\n\r\n" + body
+	msg, err := MsgParse([]byte(str))
+	assert.Nil(t, err)
+	assert.Equal(t, uint(80000), msg.ContentLen)
+	assert.Equal(t, body, string(msg.Body))
+	assert.Equal(t, []byte(str), msg.Bytes())
+
+	// headers are located beyond 64KB of the message
+	long := "X-Data: " + strings.Repeat("x", 70000) + "\r\n"
+	str = strings.Replace(str, "Max-Forwards: 70\r\n", long+"Max-Forwards: 70\r\n", 1)
+	msg, err = MsgParse([]byte(str))
+	assert.Nil(t, err)
+	assert.EqualValues(t, 70, msg.MaxFwd)
+	assert.Equal(t, "Bob", msg.To.DisplayName())
+	assert.Equal(t, strings.Repeat("x", 70000), strings.TrimSpace(msg.Headers.FindByName("X-Data").Value()))
+	assert.Equal(t, []byte(str), msg.Bytes())
+
+	// header value longer than 64KB
+	routes := make([]string, 0)
+	for i := 0; i < 3000; i++ {
+		routes = append(routes, fmt.Sprintf("<sip:proxy%d.example.com;lr>", i))
+	}
+	str = strings.Replace(str, long, "Route: "+strings.Join(routes, ", ")+"\r\n", 1)
+	msg, err = MsgParse([]byte(str))
+	assert.Nil(t, err)
+	assert.Equal(t, 3000, msg.Routes.Count())
+	assert.Equal(t, "sip:proxy2999.example.com;lr", msg.Routes[2999].Addr())
+	assert.Equal(t, []byte(str), msg.Bytes())
+	route, err := msg.PopLastRoute()
+	assert.Nil(t, err)
+	assert.Equal(t, "sip:proxy2999.example.com;lr", route.Addr())
+	assert.Equal(t, 2999, msg.Routes.Count())
+
+	via := "SIP/2.0/UDP " + strings.Repeat("a", 70000) + ".com;branch=z9hG4bK776asdhds"
+	str = strings.Replace(str, "SIP/2.0/TCP client.atlanta.example.com:5060;branch=z9hG4bKbf9f44", via, 1)
+	msg, err = MsgParse([]byte(str))
+	assert.Nil(t, err)
+	assert.Equal(t, "z9hG4bK776asdhds", msg.Vias[0].Branch())
+	assert.Equal(t, 70004, len(msg.Vias[0].Host()))
+}
+
 func TestMessageRequestToBytes(t *testing.T) {
 	str := "REGISTER sips:ss2.biloxi.example.com SIP/2.0\r\n" +
 		"Via: SIP/2.0/TLS client.biloxi.example.com:5061;branch=z9hG4bKnashds7\r\n" +
@@ -309,6 +364,14 @@ func TestMessageCreateResponse(t *testing.T) {
 	// can not generate response on nil
 	resp, err = resp.NewResponse(200, "Ok")
 	assert.NotNil(t, err)
+
+	// response with To tag
+	resp, err = msg.NewResponseTag(486, "Busy Here")
+	assert.Nil(t, err)
+	assert.NotEmpty(t, resp.To.Tag())
+	tagged, err := resp.NewResponseTag(200, "OK")
+	assert.NotNil(t, err)
+	assert.Nil(t, tagged)
 }
 
 func TestMessageCreateAddHeader(t *testing.T) {
@@ -383,6 +446,61 @@ func TestMessageContentType(t *testing.T) {
 	assert.True(t, msg.HasSDP())
 }
 
+func TestMessageSDP(t *testing.T) {
+	body := strings.Replace(multipartSDP, "s=\r\n", "s=-\r\n", 1)
+	msg := multipartInvite(t, "application/sdp", body)
+	s, err := msg.SDP()
+	assert.Nil(t, err)
+	assert.Equal(t, "alice", s.Origin.Username())
+	assert.Equal(t, 1, len(s.Medias))
+
+	// SDP part of multipart body
+	part, _ := NewPart("application/sdp", []byte(body))
+	pidf, _ := NewPart("application/pidf+xml", []byte("<presence/>"))
+	assert.Nil(t, msg.SetMultipart(NewMultipart(pidf, part)))
+	s, err = msg.SDP()
+	assert.Nil(t, err)
+	assert.Equal(t, "client.atlanta.example.com", s.Origin.UnicastAddr())
+
+	// offer
+	offer := sdp.NewMessage("pc33.atlanta.com")
+	offer.AddMedia(sdp.NewMedia("audio", 49172, "RTP/AVP", "0"))
+	assert.Nil(t, msg.SetSDP(offer))
+	assert.Equal(t, offer.String(), string(msg.Body))
+	assert.EqualValues(t, len(msg.Body), msg.ContentLen)
+	assert.Equal(t, "application/sdp", strings.TrimSpace(msg.Headers.Find(SIPHdrContentType).Value()))
+	assert.Len(t, msg.Headers.FindAll(SIPHdrContentLength), 1)
+	assert.NotNil(t, msg.SetSDP(nil))
+
+	msg, err = MsgParse(msg.Bytes())
+	assert.Nil(t, err)
+	assert.True(t, msg.HasSDP())
+	s, err = msg.SDP()
+	assert.Nil(t, err)
+	assert.Equal(t, "pc33.atlanta.com", s.Origin.UnicastAddr())
+
+	// answer
+	answer := sdp.NewMessage("biloxi.example.com")
+	answer.AddMedia(sdp.NewMedia("audio", 3456, "RTP/AVP", "0"))
+	resp, err := msg.NewResponseSDP(200, "OK", answer)
+	assert.Nil(t, err)
+	assert.True(t, resp.HasSDP())
+	assert.EqualValues(t, len(answer.String()), resp.ContentLen)
+	resp, err = MsgParse(resp.Bytes())
+	assert.Nil(t, err)
+	s, err = resp.SDP()
+	assert.Nil(t, err)
+	assert.Equal(t, "biloxi.example.com", s.Origin.UnicastAddr())
+	_, err = msg.NewResponseSDP(200, "OK", nil)
+	assert.NotNil(t, err)
+	_, err = resp.NewResponseSDP(200, "OK", answer)
+	assert.NotNil(t, err)
+
+	// no SDP
+	_, err = authRegister(t).SDP()
+	assert.NotNil(t, err)
+}
+
 func TestMessageTxnACK(t *testing.T) {
 	reqstr := "INVITE sip:bob@biloxi.example.com SIP/2.0\r\n" +
 		"Via: SIP/2.0/UDP client.atlanta.example.com:5060;branch=z9hG4bKbf9f44\r\n" +
@@ -424,6 +542,45 @@ func TestMessageTxnACK(t *testing.T) {
 	assert.Equal(t, ackstr, ack.String())
 }
 
+func TestMessageNewCANCEL(t *testing.T) {
+	reqstr := "INVITE sip:bob@biloxi.example.com SIP/2.0\r\n" +
+		"Via: SIP/2.0/UDP client.atlanta.example.com:5060;branch=z9hG4bKbf9f44\r\n" +
+		"Max-Forwards: 70\r\n" +
+		"Route: <sip:ss1.atlanta.example.com;lr>\r\n" +
+		"From: Alice <sip:alice@atlanta.example.com>;tag=9fxced76sl\r\n" +
+		"To: Bob <sip:bob@biloxi.example.com>\r\n" +
+		"Call-ID: 2xTb9vxSit55XU7p8@atlanta.example.com\r\n" +
+		"CSeq: 1 INVITE\r\n" +
+		"Contact: <sip:alice@client.atlanta.example.com>\r\n" +
+		"Content-Length: 0\r\n\r\n"
+
+	cancelstr := "CANCEL sip:bob@biloxi.example.com SIP/2.0\r\n" +
+		"Via: SIP/2.0/UDP client.atlanta.example.com:5060;branch=z9hG4bKbf9f44\r\n" +
+		"Max-Forwards: 70\r\n" +
+		"Route: <sip:ss1.atlanta.example.com;lr>\r\n" +
+		"From: Alice <sip:alice@atlanta.example.com>;tag=9fxced76sl\r\n" +
+		"To: Bob <sip:bob@biloxi.example.com>\r\n" +
+		"Call-ID: 2xTb9vxSit55XU7p8@atlanta.example.com\r\n" +
+		"CSeq: 1 CANCEL\r\n\r\n"
+
+	req, err := MsgParse([]byte(reqstr))
+	assert.Nil(t, err)
+
+	cancel, err := req.NewCANCEL()
+	assert.Nil(t, err)
+	assert.Equal(t, cancelstr, cancel.String())
+	assert.Equal(t, "z9hG4bKbf9f44", cancel.Vias[0].Branch())
+	assert.Equal(t, 1, cancel.Routes.Count())
+	assert.Equal(t, "CANCEL", cancel.CSeq.Method)
+
+	_, err = cancel.NewCANCEL()
+	assert.NotNil(t, err)
+
+	resp, _ := req.NewResponse(180, "Ringing")
+	_, err = resp.NewCANCEL()
+	assert.NotNil(t, err)
+}
+
 func BenchmarkMessageParse(b *testing.B) {
 	str := "REGISTER sip:registrar.biloxi.com SIP/2.0\r\n" +
 		"Via: SIP/2.0/UDP bobspc.biloxi.com:5060;branch=z9hG4bKnashds7\r\n" +
@@ -439,3 +596,498 @@ func BenchmarkMessageParse(b *testing.B) {
 		MsgParse([]byte(str))
 	}
 }
+
+func TestMessageSetViaParam(t *testing.T) {
+	str := "OPTIONS sip:bob@biloxi.com SIP/2.0\r\n" +
+		"Via: SIP/2.0/UDP bobspc.biloxi.com:5060;rport;branch=z9hG4bKnashds7,\r\n" +
+		" SIP/2.0/UDP 10.0.0.1;branch=z9hG4bK83754\r\n" +
+		"Via: SIP/2.0/TLS ss1.example.com:5061;branch=z9hG4bK83755\r\n" +
+		"To: Bob <sip:bob@biloxi.com>\r\n" +
+		"From: Bob <sip:bob@biloxi.com>;tag=456248\r\n" +
+		"Call-ID: 843817637684230@998sdasdh09\r\n" +
+		"CSeq: 1826 OPTIONS\r\n" +
+		"Content-Length: 0\r\n\r\n"
+	msg, err := MsgParse([]byte(str))
+	assert.Nil(t, err)
+	assert.Equal(t, 3, msg.Vias.Count())
+
+	assert.Nil(t, msg.SetViaParam("received", "192.0.2.1"))
+	assert.Nil(t, msg.SetViaParam("rport", "5066"))
+	assert.Equal(t, 3, msg.Vias.Count())
+	assert.Equal(t, "192.0.2.1", msg.Vias[0].Received())
+	val, ok := msg.Vias[0].Param("rport")
+	assert.True(t, ok)
+	assert.Equal(t, "5066", val)
+	assert.Equal(t, "z9hG4bKnashds7", msg.Vias[0].Branch())
+	assert.Equal(t, "z9hG4bK83754", msg.Vias[1].Branch())
+	assert.Equal(t, "z9hG4bK83755", msg.Vias[2].Branch())
+
+	expect := "OPTIONS sip:bob@biloxi.com SIP/2.0\r\n" +
+		"Via: SIP/2.0/UDP bobspc.biloxi.com:5060;rport=5066;branch=z9hG4bKnashds7;" +
+		"received=192.0.2.1,\r\n" +
+		" SIP/2.0/UDP 10.0.0.1;branch=z9hG4bK83754\r\n" +
+		"Via: SIP/2.0/TLS ss1.example.com:5061;branch=z9hG4bK83755\r\n"
+	assert.Contains(t, msg.String(), expect)
+
+	_, err = MsgParse(msg.Bytes())
+	assert.Nil(t, err)
+
+	// Via created with NewHdrVia
+	via, _ := NewHdrVia("UDP", "atlanta.com", 0, nil)
+	from := NewHdrFrom("", "sip:bob@voip.com", nil)
+	to := NewHdrTo("", "sip:alice@voip.com", nil)
+	msg, _ = NewRequest("INVITE", "sip:alice@atlanta.com", via, to, from, 1, 70)
+	assert.Nil(t, msg.SetViaParam("received", "10.0.0.1"))
+	assert.Equal(t, "10.0.0.1", msg.Vias[0].Received())
+	assert.Contains(t, msg.String(), "Via: SIP/2.0/UDP atlanta.com;branch="+
+		via.Branch()+";received=10.0.0.1\r\n")
+
+	assert.NotNil(t, msg.SetViaParam("received", "not an ip"))
+
+	msg, _ = NewRequest("INVITE", "sip:alice@atlanta.com", nil, to, from, 1, 70)
+	assert.NotNil(t, msg.SetViaParam("received", "10.0.0.1"))
+}
+
+func TestMessageViaPushPop(t *testing.T) {
+	str := "OPTIONS sip:bob@biloxi.com SIP/2.0\r\n" +
+		"Max-Forwards: 70\r\n" +
+		"Via: SIP/2.0/UDP bobspc.biloxi.com:5060;branch=z9hG4bKnashds7,\r\n" +
+		" SIP/2.0/UDP 10.0.0.1;branch=z9hG4bK83754\r\n" +
+		"Via: SIP/2.0/TLS ss1.example.com:5061;branch=z9hG4bK83755\r\n" +
+		"To: Bob <sip:bob@biloxi.com>\r\n" +
+		"From: Bob <sip:bob@biloxi.com>;tag=456248\r\n" +
+		"Call-ID: 843817637684230@998sdasdh09\r\n" +
+		"CSeq: 1826 OPTIONS\r\n" +
+		"Content-Length: 0\r\n\r\n"
+	msg, err := MsgParse([]byte(str))
+	assert.Nil(t, err)
+
+	via, err := msg.PushVia("TCP", "proxy.example.com", 5080, "")
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(via.Branch(), "z9hG4bK"))
+	assert.Equal(t, 4, msg.Vias.Count())
+	assert.Equal(t, via, msg.Vias[0])
+	assert.Contains(t, msg.String(), "Max-Forwards: 70\r\n"+
+		"Via: SIP/2.0/TCP proxy.example.com:5080;branch="+via.Branch()+"\r\n"+
+		"Via: SIP/2.0/UDP bobspc.biloxi.com:5060")
+
+	first := via.Branch()
+	via, err = msg.PushVia("UDP", "proxy.example.com", 0, "z9hG4bKproxy")
+	assert.Nil(t, err)
+	assert.Equal(t, "z9hG4bKproxy", msg.Vias[0].Branch())
+	_, err = msg.PushVia("UDP", "proxy.example.com", 70000, "")
+	assert.NotNil(t, err)
+
+	for _, branch := range []string{"z9hG4bKproxy", first, "z9hG4bKnashds7", "z9hG4bK83754"} {
+		via, err = msg.PopVia()
+		assert.Nil(t, err)
+		assert.Equal(t, branch, via.Branch())
+	}
+	assert.Equal(t, 1, msg.Vias.Count())
+	assert.Equal(t, "z9hG4bK83755", msg.Vias[0].Branch())
+	assert.Len(t, msg.Headers.FindAll(SIPHdrVia), 1)
+
+	msg, err = MsgParse([]byte(str))
+	assert.Nil(t, err)
+	via, err = msg.PopVia()
+	assert.Nil(t, err)
+	assert.Equal(t, "z9hG4bKnashds7", via.Branch())
+	assert.Equal(t, 2, msg.Vias.Count())
+	assert.Equal(t, "10.0.0.1", msg.Vias[0].Host())
+	assert.Contains(t, msg.String(), "Max-Forwards: 70\r\n"+
+		"Via: SIP/2.0/UDP 10.0.0.1;branch=z9hG4bK83754\r\n"+
+		"Via: SIP/2.0/TLS ss1.example.com:5061;branch=z9hG4bK83755\r\n")
+	_, err = MsgParse(msg.Bytes())
+	assert.Nil(t, err)
+
+	msg.PopVia()
+	msg.PopVia()
+	_, err = msg.PopVia()
+	assert.NotNil(t, err)
+
+	// Via added to message without Via headers
+	via, err = msg.PushVia("UDP", "10.0.0.2", 5060, "")
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(msg.String(), "OPTIONS sip:bob@biloxi.com SIP/2.0\r\n"+
+		"Via: SIP/2.0/UDP 10.0.0.2:5060;branch="+via.Branch()+"\r\n"))
+
+	assert.NotEqual(t, NewBranch(), NewBranch())
+}
+
+func TestMessageSetViaReceived(t *testing.T) {
+	msg := authRegister(t)
+	assert.Nil(t, msg.SetViaReceived("192.0.2.4", 5060))
+	assert.Equal(t, "192.0.2.4", msg.Vias[0].Received())
+
+	// sent-by is the source address
+	msg, _ = MsgParse([]byte(strings.Replace(msg.String(),
+		"bobspc.biloxi.com:5060;branch=z9hG4bKnashds7;received=192.0.2.4", "192.0.2.4", 1)))
+	assert.Nil(t, msg.SetViaReceived("192.0.2.4", 5060))
+	assert.Equal(t, "", msg.Vias[0].Received())
+
+	// rport requested
+	msg, _ = MsgParse([]byte(strings.Replace(msg.String(), "Via: SIP/2.0/UDP 192.0.2.4",
+		"Via: SIP/2.0/UDP 192.0.2.4;rport", 1)))
+	assert.Nil(t, msg.SetViaReceived("192.0.2.4", 6070))
+	assert.Equal(t, "192.0.2.4", msg.Vias[0].Received())
+	rport, _ := msg.Vias[0].Param("rport")
+	assert.Equal(t, "6070", rport)
+
+	msg.PopVia()
+	assert.NotNil(t, msg.SetViaReceived("192.0.2.4", 5060))
+}
+
+func TestMessageRoutePushPop(t *testing.T) {
+	msg := authRegister(t, "Route: <sip:p1.com;lr>, <sip:p2.com;lr>;foo=bar",
+		"Route: <sip:p3.com;lr>")
+	assert.Nil(t, msg.PushRoute("sip:p0.com;lr"))
+	assert.Nil(t, msg.AppendRoute("<sip:p4.com>"))
+	assert.Equal(t, 5, msg.Routes.Count())
+	assert.Equal(t, "sip:p0.com;lr", msg.Routes[0].Addr())
+	assert.Equal(t, "sip:p4.com", msg.Routes[4].Addr())
+	assert.Contains(t, msg.String(), "Route: <sip:p0.com;lr>\r\n"+
+		"Route: <sip:p1.com;lr>, <sip:p2.com;lr>;foo=bar\r\n"+
+		"Route: <sip:p3.com;lr>\r\n"+
+		"Route: <sip:p4.com>\r\n")
+
+	r, err := msg.PopRoute()
+	assert.Nil(t, err)
+	assert.Equal(t, "sip:p0.com;lr", r.Addr())
+	r, err = msg.PopRoute()
+	assert.Nil(t, err)
+	assert.Equal(t, "sip:p1.com;lr", r.Addr())
+	assert.Equal(t, 3, msg.Routes.Count())
+	val, ok := msg.Routes[0].Param("foo")
+	assert.True(t, ok)
+	assert.Equal(t, "bar", val)
+	assert.Contains(t, msg.String(), "Route: <sip:p2.com;lr>;foo=bar\r\n"+
+		"Route: <sip:p3.com;lr>\r\n")
+
+	r, err = msg.PopLastRoute()
+	assert.Nil(t, err)
+	assert.Equal(t, "sip:p4.com", r.Addr())
+	assert.Equal(t, 2, msg.Routes.Count())
+	assert.Len(t, msg.Headers.FindAll(SIPHdrRoute), 2)
+
+	_, err = MsgParse(msg.Bytes())
+	assert.Nil(t, err)
+	msg.PopRoute()
+	msg.PopRoute()
+	_, err = msg.PopRoute()
+	assert.NotNil(t, err)
+	assert.Equal(t, 0, msg.Routes.Count())
+	assert.NotNil(t, msg.PushRoute("not a uri"))
+
+	// multi value line last route
+	msg = authRegister(t, "Route: <sip:p1.com;lr>, <sip:p2.com;lr>")
+	r, err = msg.PopLastRoute()
+	assert.Nil(t, err)
+	assert.Equal(t, "sip:p2.com;lr", r.Addr())
+	assert.Contains(t, msg.String(), "Route: <sip:p1.com;lr>\r\n")
+	assert.Equal(t, 1, msg.Routes.Count())
+}
+
+func TestMessageClone(t *testing.T) {
+	msg := authRegister(t, "Route: <sip:p1.com;lr>, <sip:p2.com;lr>", "Route: <sip:p3.com;lr>")
+	orig := msg.String()
+
+	cp := msg.Clone()
+	assert.Equal(t, orig, cp.String())
+	_, err := cp.PopRoute()
+	assert.Nil(t, err)
+	_, err = cp.PopLastRoute()
+	assert.Nil(t, err)
+	assert.Nil(t, cp.AppendRoute("sip:p4.com;lr"))
+	_, err = cp.PushVia("UDP", "p1.com", 0, "z9hG4bK1")
+	assert.Nil(t, err)
+	assert.Nil(t, cp.AddToTag())
+	assert.Nil(t, cp.SetRequestURI("sip:bob@192.0.2.4"))
+	cp.SetMaxForwards(69)
+
+	assert.Equal(t, orig, msg.String())
+	assert.Equal(t, 3, msg.Routes.Count())
+	assert.Equal(t, 1, msg.Vias.Count())
+	assert.Empty(t, msg.To.Tag())
+
+	assert.Equal(t, "sip:bob@192.0.2.4", cp.ReqLine.RequestURI())
+	assert.EqualValues(t, 69, cp.MaxFwd)
+	assert.Equal(t, 2, cp.Vias.Count())
+	assert.Equal(t, "p1.com", cp.Vias[0].Host())
+	assert.Equal(t, 2, cp.Routes.Count())
+	assert.Equal(t, "sip:p2.com;lr", cp.Routes[0].Addr())
+	assert.Equal(t, "sip:p4.com;lr", cp.Routes[1].Addr())
+	assert.NotEmpty(t, cp.To.Tag())
+
+	parsed, err := MsgParse(cp.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, cp.String(), parsed.String())
+	assert.EqualValues(t, 69, parsed.MaxFwd)
+
+	assert.NotNil(t, cp.SetRequestURI("not a uri"))
+	resp, _ := msg.NewResponse(200, "OK")
+	assert.NotNil(t, resp.SetRequestURI("sip:bob@192.0.2.4"))
+
+	// header is added if message does not have it
+	resp.SetMaxForwards(10)
+	assert.Contains(t, resp.String(), "Max-Forwards: 10\r\n")
+}
+
+func TestMessageRecordRoute(t *testing.T) {
+	resp := authRegister(t, "Record-Route: <sip:p3.com;lr>, <sip:p2.com;lr>",
+		"Record-Route: <sip:p1.com;lr>")
+	assert.Nil(t, resp.PushRecordRoute("sip:p4.com;lr"))
+	assert.Equal(t, 4, resp.RecRoutes.Count())
+	assert.Contains(t, resp.String(), "Record-Route: <sip:p4.com;lr>\r\n"+
+		"Record-Route: <sip:p3.com;lr>, <sip:p2.com;lr>\r\n")
+	r, err := resp.PopRecordRoute()
+	assert.Nil(t, err)
+	assert.Equal(t, "sip:p4.com;lr", r.Addr())
+
+	// UAC route set is reversed Record-Route list
+	req := authRegister(t, "Route: <sip:outbound.com;lr>")
+	assert.Nil(t, req.SetRouteSet(resp.RecRoutes.Reverse()))
+	assert.Equal(t, 3, req.Routes.Count())
+	assert.Equal(t, "sip:p1.com;lr", req.Routes[0].Addr())
+	assert.Equal(t, "sip:p3.com;lr", req.Routes[2].Addr())
+	assert.Len(t, req.Headers.FindAll(SIPHdrRoute), 3)
+	assert.NotContains(t, req.String(), "outbound.com")
+	assert.Contains(t, req.String(), "Route: <sip:p1.com;lr>\r\n"+
+		"Route: <sip:p2.com;lr>\r\n"+
+		"Route: <sip:p3.com;lr>\r\n")
+	assert.Equal(t, 3, resp.RecRoutes.Count())
+	assert.Equal(t, "sip:p3.com;lr", resp.RecRoutes[0].Addr())
+
+	assert.Nil(t, req.SetRouteSet(nil))
+	assert.Equal(t, 0, req.Routes.Count())
+	assert.Nil(t, req.Headers.Find(SIPHdrRoute))
+}
+
+func TestMessageBytesWithBody(t *testing.T) {
+	str := "MESSAGE sip:bob@biloxi.example.com SIP/2.0\r\n" +
+		"Via: SIP/2.0/TCP client.atlanta.example.com:5060;branch=z9hG4bKbf9f44\r\n" +
+		"CSeq: 1 MESSAGE\r\n" +
+		"Content-Length: 5\r\n\r\n" +
+		"Hello"
+	msg, err := MsgParse([]byte(str))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte(str), msg.Bytes())
+}
+
+func TestMessageNewAuthorized(t *testing.T) {
+	req := authRegister(t)
+	srv := NewAuthenticator("biloxi.com", []byte("secret"), authStore(map[string]string{"bob": "zanzibar"}))
+	creds := func(realm string) (string, string, bool) {
+		return "bob", "zanzibar", realm == "biloxi.com"
+	}
+	_, resp, err := srv.Authenticate(req)
+	assert.Nil(t, err)
+
+	areq, err := req.NewAuthorized(resp, creds, nil)
+	assert.Nil(t, err)
+	assert.EqualValues(t, 1827, areq.CSeq.Num)
+	assert.Equal(t, "REGISTER", areq.CSeq.Method)
+	assert.Equal(t, "1827 REGISTER", areq.Headers.Find(SIPHdrCSeq).Value())
+	assert.NotEqual(t, req.Vias[0].Branch(), areq.Vias[0].Branch())
+	assert.EqualValues(t, 1826, req.CSeq.Num)
+	cr, _, err := srv.Authenticate(areq)
+	assert.Nil(t, err)
+	assert.Equal(t, "bob", cr.Username())
+
+	// parsed back
+	areq, err = MsgParse(areq.Bytes())
+	assert.Nil(t, err)
+	assert.EqualValues(t, 1827, areq.CSeq.Num)
+
+	// credentials rejected
+	_, resp, _ = srv.Authenticate(areq)
+	_, err = areq.NewAuthorized(resp, creds, nil)
+	assert.NotNil(t, err)
+
+	// stale nonce: credentials are replaced
+	areq, _ = req.NewAuthorized(resp, creds, nil)
+	srv.now = func() time.Time { return time.Now().Add(time.Hour) }
+	_, resp, _ = srv.Authenticate(areq)
+	nreq, err := areq.NewAuthorized(resp, creds, NewNonceCounter())
+	assert.Nil(t, err)
+	assert.Len(t, nreq.Headers.FindAll(SIPHdrAuthorization), 1)
+	assert.EqualValues(t, 1828, nreq.CSeq.Num)
+	cr, _, _ = srv.Authenticate(nreq)
+	assert.NotNil(t, cr)
+
+	// no credentials for realm
+	_, err = req.NewAuthorized(resp, func(string) (string, string, bool) { return "", "", false }, nil)
+	assert.NotNil(t, err)
+
+	// proxy challenge
+	srv.SetProxy(true)
+	_, resp, _ = srv.Authenticate(req)
+	areq, err = req.NewAuthorized(resp, creds, nil)
+	assert.Nil(t, err)
+	assert.Len(t, areq.Headers.FindAll(SIPHdrProxyAuthorization), 1)
+	assert.Len(t, areq.Headers.FindAll(SIPHdrAuthorization), 0)
+
+	// not a challenge
+	resp, _ = req.NewResponse(200, "OK")
+	_, err = req.NewAuthorized(resp, creds, nil)
+	assert.NotNil(t, err)
+	_, err = resp.NewAuthorized(resp, creds, nil)
+	assert.NotNil(t, err)
+}
+
+func TestMessageAuthHeaders(t *testing.T) {
+	msg := authRegister(t,
+		`WWW-Authenticate: Digest realm="atlanta.com", nonce="84a4cc6f3082121f32b42a2187831a9e"`,
+		`WWW-Authenticate: Digest realm="biloxi.com", nonce="1a9e84a4cc6f3082121f32b42a218783", algorithm=SHA-256`,
+		`Proxy-Authenticate: Digest realm="proxy.com", nonce="c60f3082ee1212b402a21831ae", stale=true`,
+		`Authorization: Digest username="bob", realm="biloxi.com", nonce="dcd98b7102dd2f0e", uri="sip:biloxi.com", response="6629fae49393a05397450978507c4ef1"`,
+		"Proxy-Authorization: Invalid")
+
+	chs := msg.WWWAuthenticate()
+	assert.Len(t, chs, 2)
+	assert.Equal(t, "atlanta.com", chs[0].Realm())
+	assert.Equal(t, "biloxi.com", chs[1].Realm())
+	assert.Equal(t, AlgoSHA256, chs[1].Algo())
+	chs = msg.ProxyAuthenticate()
+	assert.Len(t, chs, 1)
+	assert.True(t, chs[0].Stale())
+	crs := msg.Authorization()
+	assert.Len(t, crs, 1)
+	assert.Equal(t, "bob", crs[0].Username())
+	assert.Len(t, msg.ProxyAuthorization(), 0)
+
+	// credentials of the same realm are replaced
+	cr := chs[0].Authorize("REGISTER", "sip:proxy.com", "alice", "secret")
+	assert.Nil(t, msg.SetProxyAuthorization(cr))
+	assert.Nil(t, msg.SetProxyAuthorization(cr))
+	crs = msg.ProxyAuthorization()
+	assert.Len(t, crs, 1)
+	assert.Equal(t, "alice", crs[0].Username())
+	assert.Equal(t, cr.Response(), crs[0].Response())
+	assert.Len(t, msg.Headers.FindAll(SIPHdrProxyAuthorization), 2)
+
+	cr = msg.WWWAuthenticate()[0].Authorize("REGISTER", "sip:atlanta.com", "alice", "secret")
+	assert.Nil(t, msg.SetAuthorization(cr))
+	assert.Len(t, msg.Authorization(), 2)
+	assert.NotNil(t, msg.SetAuthorization(nil))
+
+	// parsed back
+	msg, err := MsgParse(msg.Bytes())
+	assert.Nil(t, err)
+	assert.Len(t, msg.Authorization(), 2)
+	assert.Equal(t, "alice", msg.ProxyAuthorization()[0].Username())
+}
+
+func TestMessageRemoveHeaders(t *testing.T) {
+	msg := authRegister(t, "X-Foo: 1", "X-Foo: 2", "X-Bar: 3")
+	assert.True(t, msg.RemoveHeader("x-foo"))
+	assert.Nil(t, msg.Headers.FindByName("X-Foo"))
+	assert.NotNil(t, msg.Headers.FindByName("X-Bar"))
+	assert.False(t, msg.RemoveHeader("X-Foo"))
+}
+
+func TestMessageCompactHeaders(t *testing.T) {
+	str := "INVITE sip:bob@biloxi.com SIP/2.0\r\n" +
+		"v: SIP/2.0/UDP pc33.atlanta.com;branch=z9hG4bK776asdhds, SIP/2.0/UDP pc22.atlanta.com;branch=z9hG4bK4b43c2ff8.1\r\n" +
+		"MAX-FORWARDS: 70\r\n" +
+		"t: Bob <sip:bob@biloxi.com>\r\n" +
+		"f: Alice <sip:alice@atlanta.com>;tag=1928301774\r\n" +
+		"i: a84b4c76e66710@pc33.atlanta.com\r\n" +
+		"CSeq: 314159 INVITE\r\n" +
+		"m: <sip:alice@pc33.atlanta.com>\r\n" +
+		"o: presence\r\n" +
+		"U: presence, dialog\r\n" +
+		"x: 1800\r\n" +
+		"l: 0\r\n\r\n"
+	msg, err := MsgParse([]byte(str))
+	assert.Nil(t, err)
+	assert.Equal(t, 2, msg.Vias.Count())
+	assert.Equal(t, "a84b4c76e66710@pc33.atlanta.com", msg.CallID)
+
+	for name, id := range map[string]HdrType{
+		"Via": SIPHdrVia, "to": SIPHdrTo, "FROM": SIPHdrFrom, "call-id": SIPHdrCallID,
+		"Contact": SIPHdrContact, "Content-Length": SIPHdrContentLength, "max-forwards": SIPHdrMaxForwards,
+	} {
+		h := msg.Headers.FindByName(name)
+		assert.NotNil(t, h, name)
+		assert.Equal(t, id, h.ID(), name)
+	}
+	assert.Equal(t, "presence", msg.Headers.FindByName("event").Value())
+	assert.Equal(t, "1800", msg.Headers.FindByName("Session-Expires").Value())
+	assert.Equal(t, "1800", msg.Headers.FindByName("X").Value())
+	assert.Nil(t, msg.Headers.FindByName("Refer-To"))
+
+	assert.True(t, msg.RemoveHeader("allow-events"))
+	assert.Nil(t, msg.Headers.FindByName("u"))
+	assert.True(t, msg.RemoveHeader("O"))
+	assert.Nil(t, msg.Headers.FindByName("Event"))
+
+	assert.Nil(t, msg.AddHeader("K", "timer"))
+	assert.Equal(t, SIPHdrSupported, msg.Headers.FindByName("Supported").ID())
+	assert.Nil(t, msg.AddHeader("Content-Type", "application/sdp"))
+
+	// serialized in compact form
+	msg.SetCompact(true)
+	out := msg.String()
+	assert.Contains(t, out, "\r\nv: SIP/2.0/UDP pc33.atlanta.com;branch=z9hG4bK776asdhds, SIP/2.0/UDP")
+	assert.Contains(t, out, "\r\nMAX-FORWARDS: 70\r\n")
+	assert.Contains(t, out, "\r\nCSeq: 314159 INVITE\r\n")
+	assert.Contains(t, out, "\r\nk: timer\r\n")
+	assert.Contains(t, out, "\r\nc: application/sdp\r\n")
+	assert.Less(t, len(out), len(str))
+
+	req := authRegister(t)
+	full := req.String()
+	req.SetCompact(true)
+	assert.Contains(t, req.String(), "\r\nv: SIP/2.0/UDP bobspc.biloxi.com:5060;branch=z9hG4bKnashds7\r\n")
+	assert.Contains(t, req.String(), "\r\nt: Bob <sip:bob@biloxi.com>\r\n")
+	assert.Contains(t, req.String(), "\r\ni: 843817637684230@998sdasdh09\r\n")
+	assert.Contains(t, req.String(), "\r\nl: 0\r\n")
+	parsed, err := MsgParse(req.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, req.Vias[0].Branch(), parsed.Vias[0].Branch())
+	assert.Equal(t, req.From.Tag(), parsed.From.Tag())
+	req.SetCompact(false)
+	assert.Equal(t, full, req.String())
+}
+
+func TestMessageMultiValueRoute(t *testing.T) {
+	msg := authRegister(t, "Route: <sip:p1.com;lr>, <sip:p2.com;lr>",
+		"Record-Route: <sip:p3.com;lr>, <sip:p4.com;lr>")
+	assert.Equal(t, 2, msg.Routes.Count())
+	assert.Equal(t, 2, msg.RecRoutes.Count())
+	assert.Len(t, msg.Headers.FindAll(SIPHdrRoute), 1)
+	assert.Len(t, msg.Headers.FindAll(SIPHdrRecordRoute), 1)
+	assert.Equal(t, 1, strings.Count(msg.String(), "<sip:p1.com;lr>"))
+	assert.Equal(t, 1, strings.Count(msg.String(), "<sip:p4.com;lr>"))
+
+	// identical header lines are kept
+	msg = authRegister(t, "Route: <sip:p1.com;lr>", "Route: <sip:p1.com;lr>",
+		"Record-Route: <sip:p3.com;lr>", "Record-Route: <sip:p3.com;lr>")
+	assert.Equal(t, 2, msg.Routes.Count())
+	assert.Equal(t, 2, msg.RecRoutes.Count())
+	assert.Len(t, msg.Headers.FindAll(SIPHdrRoute), 2)
+	assert.Len(t, msg.Headers.FindAll(SIPHdrRecordRoute), 2)
+	assert.Equal(t, 2, strings.Count(msg.String(), "Route: <sip:p1.com;lr>\r\n"))
+	assert.Equal(t, 2, strings.Count(msg.String(), "Record-Route: <sip:p3.com;lr>\r\n"))
+
+	// values of identical header lines are removed one by one
+	_, err := msg.PopRoute()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, msg.Routes.Count())
+	assert.Equal(t, 1, strings.Count(msg.String(), "Route: <sip:p1.com;lr>\r\n"))
+	_, err = msg.PopLastRoute()
+	assert.Nil(t, err)
+	assert.Equal(t, 0, msg.Routes.Count())
+	assert.Equal(t, 0, strings.Count(msg.String(), "\r\nRoute:"))
+
+	msg = authRegister(t, "Via: SIP/2.0/UDP p1.com;branch=z9hG4bK1", "Via: SIP/2.0/UDP p1.com;branch=z9hG4bK1")
+	assert.Equal(t, 3, msg.Vias.Count())
+	assert.Len(t, msg.Headers.FindAll(SIPHdrVia), 3)
+	_, err = msg.PopVia()
+	assert.Nil(t, err)
+	_, err = msg.PopVia()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, msg.Vias.Count())
+	assert.Equal(t, 1, strings.Count(msg.String(), "Via:"))
+	assert.Equal(t, 1, strings.Count(msg.String(), "Via: SIP/2.0/UDP p1.com;branch=z9hG4bK1\r\n"))
+}
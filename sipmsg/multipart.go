@@ -0,0 +1,192 @@
+package sipmsg
+
+import (
+	"bytes"
+	"container/list"
+	"fmt"
+	"strings"
+)
+
+// Multipart multipart MIME body (RFC2046#5.1, RFC5621)
+type Multipart struct {
+	Subtype  string
+	Boundary string
+	Parts    []*Part
+}
+
+// Part body part of multipart body with own headers
+type Part struct {
+	Headers HeadersList
+	Body    []byte
+}
+
+// NewMultipart creates multipart/mixed body with random boundary
+func NewMultipart(parts ...*Part) *Multipart {
+	return &Multipart{
+		Subtype:  "mixed",
+		Boundary: hashString(),
+		Parts:    parts,
+	}
+}
+
+// NewPart creates body part with content type
+func NewPart(ctype string, body []byte) (*Part, error) {
+	p := &Part{Headers: HeadersList{list.New()}, Body: body}
+	if err := p.AddHeader("Content-Type", ctype); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// ContentType value of Content-Type header of the multipart body
+func (mp *Multipart) ContentType() string {
+	return fmt.Sprintf("multipart/%s;boundary=%s", mp.Subtype, mp.Boundary)
+}
+
+// Bytes multipart body as bytes
+func (mp *Multipart) Bytes() []byte {
+	var b buffer
+	for _, p := range mp.Parts {
+		b.WriteString("--" + mp.Boundary)
+		b.crlf()
+		b.Write(p.Bytes())
+		b.crlf()
+	}
+	b.WriteString("--" + mp.Boundary + "--")
+	b.crlf()
+	return b.Bytes()
+}
+
+// SDP returns first part with application/sdp content type.
+// Nested multipart bodies are searched as well.
+func (mp *Multipart) SDP() *Part {
+	for _, p := range mp.Parts {
+		ct := p.ContentType()
+		if ct == nil {
+			continue
+		}
+		if ct.IsSDP() {
+			return p
+		}
+		if ct.IsMultipart() {
+			nested, err := parseMultipart(ct, p.Body)
+			if err != nil {
+				continue
+			}
+			if sdp := nested.SDP(); sdp != nil {
+				return sdp
+			}
+		}
+	}
+	return nil
+}
+
+// AddHeader appends header to the part headers. Returns error
+// if header or Content-Type value is invalid.
+func (p *Part) AddHeader(name, value string) error {
+	if sameName(name, "Content-Type") {
+		if _, err := parseContentType([]byte(strings.TrimSpace(value))); err != nil {
+			return err
+		}
+	}
+	msg := &Message{Headers: p.Headers}
+	buf, _, _ := headerValue(name, value)
+	_, err := parseHeader(msg, buf)
+	return err
+}
+
+// ContentType returns content type of the part or nil if
+// Content-Type header is missing or invalid
+func (p *Part) ContentType() *ContentType {
+	return contentType(p.Headers)
+}
+
+// Disposition returns value of Content-Disposition header
+func (p *Part) Disposition() string {
+	h := p.Headers.Find(SIPHdrContentDisposition)
+	if h == nil {
+		return ""
+	}
+	return strings.TrimSpace(h.Value())
+}
+
+// Bytes part headers and body as bytes
+func (p *Part) Bytes() []byte {
+	var b buffer
+	p.Headers.ForEach(func(h *Header) { b.Write(h.buf) })
+	b.crlf()
+	b.Write(p.Body)
+	return b.Bytes()
+}
+
+// parseMultipart splits body into parts with boundary of content type
+func parseMultipart(ct *ContentType, body []byte) (*Multipart, error) {
+	boundary := ct.Param("boundary")
+	if !ct.IsMultipart() || boundary == "" {
+		return nil, ErrorSIPMsgParse.msg("Body is not multipart.")
+	}
+	mp := &Multipart{
+		Subtype:  ct.MediaSubtype(),
+		Boundary: boundary,
+		Parts:    make([]*Part, 0),
+	}
+	// delimiter is always preceded by CRLF, even the first one
+	data := append([]byte("\r\n"), body...)
+	// preamble is skipped
+	_, end, closing := delimiter(data, boundary, 0)
+	if end == -1 || closing {
+		return nil, ErrorSIPMsgParse.msg("Multipart body has no parts.")
+	}
+	for !closing {
+		start, next, last := delimiter(data, boundary, end)
+		if next == -1 {
+			return nil, ErrorSIPMsgParse.msg("Missing multipart close delimiter.")
+		}
+		part := &Part{Headers: HeadersList{list.New()}}
+		pos, err := parseHeaders(&Message{Headers: part.Headers}, data[end:start])
+		if err != nil {
+			return nil, err
+		}
+		part.Body = data[end+pos : start]
+		mp.Parts = append(mp.Parts, part)
+		end, closing = next, last
+	}
+	return mp, nil
+}
+
+// delimiter finds next delimiter line starting from position. Returns
+// positions of delimiter and of data after it, and true if it is close
+// delimiter. Boundary must be followed by "--" or transport padding
+// and CRLF (RFC2046#5.1.1). Returns -1 if delimiter is not found.
+func delimiter(data []byte, boundary string, from int) (int, int, bool) {
+	delim := []byte("\r\n--" + boundary)
+	for {
+		i := bytes.Index(data[from:], delim)
+		if i == -1 {
+			return -1, -1, false
+		}
+		start := from + i
+		from = start + len(delim)
+		rest := data[from:]
+		if bytes.HasPrefix(rest, []byte("--")) {
+			return start, from + 2, true
+		}
+		pad := len(rest) - len(bytes.TrimLeft(rest, " \t"))
+		if bytes.HasPrefix(rest[pad:], []byte("\r\n")) {
+			return start, from + pad + 2, false
+		}
+	}
+}
+
+// contentType returns parsed Content-Type header of the list
+func contentType(headers HeadersList) *ContentType {
+	h := headers.Find(SIPHdrContentType)
+	if h == nil {
+		return nil
+	}
+	ct, err := parseContentType([]byte(strings.TrimSpace(h.Value())))
+	if err != nil {
+		return nil
+	}
+	return ct
+}
@@ -0,0 +1,166 @@
+package sipmsg
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const multipartSDP = "v=0\r\n" +
+	"o=alice 2890844526 2890844526 IN IP4 client.atlanta.example.com\r\n" +
+	"s=\r\n" +
+	"c=IN IP4 client.atlanta.example.com\r\n" +
+	"t=0 0\r\n" +
+	"m=audio 49170 RTP/AVP 0\r\n" +
+	"a=rtpmap:0 PCMU/8000\r\n"
+
+func multipartInvite(t *testing.T, ctype, body string) *Message {
+	str := "INVITE sip:bob@biloxi.example.com SIP/2.0\r\n" +
+		"Via: SIP/2.0/TCP client.atlanta.example.com:5060;branch=z9hG4bK74bf9\r\n" +
+		"Max-Forwards: 70\r\n" +
+		"From: Alice <sip:alice@atlanta.example.com>;tag=9fxced76sl\r\n" +
+		"To: Bob <sip:bob@biloxi.example.com>\r\n" +
+		"Call-ID: 3848276298220188511@atlanta.example.com\r\n" +
+		"CSeq: 1 INVITE\r\n" +
+		"Content-Type: " + ctype + "\r\n" +
+		"Content-Length: " + strconv.Itoa(len(body)) + "\r\n\r\n" + body
+	msg, err := MsgParse([]byte(str))
+	assert.Nil(t, err)
+	return msg
+}
+
+func TestMultipartParse(t *testing.T) {
+	body := "preamble is ignored\r\n" +
+		"--boundary1\r\n" +
+		"Content-Type: application/sdp\r\n" +
+		"\r\n" + multipartSDP +
+		"\r\n--boundary1 \r\n" +
+		"Content-Type: application/pidf+xml\r\n" +
+		"Content-ID: <target123@atlanta.example.com>\r\n" +
+		"Content-Disposition: by-reference;handling=optional\r\n" +
+		"\r\n" +
+		"<?xml version=\"1.0\" encoding=\"UTF-8\"?>\r\n" +
+		"<presence entity=\"pres:alice@atlanta.example.com\"/>\r\n" +
+		"--boundary1\r\n" +
+		"\r\n" +
+		"plain text part\r\n" +
+		"--boundary1--\r\n" +
+		"epilogue\r\n"
+	msg := multipartInvite(t, `multipart/mixed; BOUNDARY="boundary1"`, body)
+
+	mp, err := msg.Multipart()
+	assert.Nil(t, err)
+	assert.Equal(t, "mixed", mp.Subtype)
+	assert.Equal(t, "boundary1", mp.Boundary)
+	assert.Len(t, mp.Parts, 3)
+	assert.True(t, mp.Parts[0].ContentType().IsSDP())
+	assert.Equal(t, multipartSDP, string(mp.Parts[0].Body))
+	assert.Equal(t, "", mp.Parts[0].Disposition())
+
+	pidf := mp.Parts[1]
+	assert.Equal(t, "pidf+xml", pidf.ContentType().MediaSubtype())
+	assert.Equal(t, "by-reference;handling=optional", pidf.Disposition())
+	assert.Equal(t, "<target123@atlanta.example.com>", strings.TrimSpace(pidf.Headers.FindByName("content-id").Value()))
+	assert.True(t, strings.HasSuffix(string(pidf.Body), "\"/>"))
+
+	assert.Nil(t, mp.Parts[2].ContentType())
+	assert.Equal(t, "plain text part", string(mp.Parts[2].Body))
+
+	assert.True(t, msg.HasSDP())
+	assert.Equal(t, multipartSDP, string(msg.SDPBody()))
+	assert.Equal(t, mp.Parts[0], mp.SDP())
+}
+
+func TestMultipartParseInvalid(t *testing.T) {
+	msg := multipartInvite(t, "multipart/mixed;boundary=b1", "--b1\r\n\r\nfoo\r\n--b1\r\n")
+	_, err := msg.Multipart()
+	assert.NotNil(t, err)
+	assert.False(t, msg.HasSDP())
+	assert.Nil(t, msg.SDPBody())
+
+	msg = multipartInvite(t, "multipart/mixed;boundary=b1", "--b1 foo\r\n\r\nfoo\r\n--b1--\r\n")
+	_, err = msg.Multipart()
+	assert.NotNil(t, err)
+
+	// boundary followed by other characters is not delimiter
+	msg = multipartInvite(t, "multipart/mixed;boundary=b1",
+		"--b1\r\n\r\nfoo\r\n--b1X\r\nbar\r\n--b1 \t\r\n\r\nbaz\r\n--b1--")
+	mp, err := msg.Multipart()
+	assert.Nil(t, err)
+	assert.Len(t, mp.Parts, 2)
+	assert.Equal(t, "foo\r\n--b1X\r\nbar", string(mp.Parts[0].Body))
+	assert.Equal(t, "baz", string(mp.Parts[1].Body))
+
+	msg = multipartInvite(t, "multipart/mixed;boundary=b1", "--b1X\r\n\r\nfoo\r\n--b1X--\r\n")
+	_, err = msg.Multipart()
+	assert.NotNil(t, err)
+
+	msg = multipartInvite(t, "multipart/mixed", "--b1\r\n\r\nfoo\r\n--b1--\r\n")
+	_, err = msg.Multipart()
+	assert.NotNil(t, err)
+
+	msg = multipartInvite(t, "application/sdp", multipartSDP)
+	_, err = msg.Multipart()
+	assert.NotNil(t, err)
+	assert.True(t, msg.HasSDP())
+	assert.Equal(t, multipartSDP, string(msg.SDPBody()))
+}
+
+func TestMultipartBuild(t *testing.T) {
+	sdp, err := NewPart("application/sdp", []byte(multipartSDP))
+	assert.Nil(t, err)
+	isup, err := NewPart("application/ISUP;version=itu-t92+", []byte{0x01, 0x00, 0x49, 0x00})
+	assert.Nil(t, err)
+	assert.Nil(t, isup.AddHeader("Content-Disposition", "signal;handling=optional"))
+	_, err = NewPart("invalid", nil)
+	assert.NotNil(t, err)
+	assert.NotNil(t, isup.AddHeader("c", "invalid"))
+	assert.NotNil(t, isup.AddHeader("Bad Name", "foo"))
+	assert.Len(t, isup.Headers.FindAll(SIPHdrContentType), 1)
+
+	msg := multipartInvite(t, "text/plain", "hello")
+	assert.NotNil(t, msg.SetMultipart(NewMultipart()))
+	mp := NewMultipart(sdp, isup)
+	assert.Nil(t, msg.SetMultipart(mp))
+	assert.EqualValues(t, len(msg.Body), msg.ContentLen)
+	assert.Len(t, msg.Headers.FindAll(SIPHdrContentType), 1)
+	assert.Len(t, msg.Headers.FindAll(SIPHdrContentLength), 1)
+	assert.True(t, msg.HasSDP())
+
+	// parsed back
+	msg, err = MsgParse(msg.Bytes())
+	assert.Nil(t, err)
+	assert.EqualValues(t, len(msg.Body), msg.ContentLen)
+	parsed, err := msg.Multipart()
+	assert.Nil(t, err)
+	assert.Equal(t, mp.Boundary, parsed.Boundary)
+	assert.Len(t, parsed.Parts, 2)
+	assert.Equal(t, multipartSDP, string(parsed.Parts[0].Body))
+	assert.Equal(t, "itu-t92+", parsed.Parts[1].ContentType().Param("version"))
+	assert.Equal(t, "signal;handling=optional", parsed.Parts[1].Disposition())
+	assert.Equal(t, []byte{0x01, 0x00, 0x49, 0x00}, parsed.Parts[1].Body)
+
+	// body without content type
+	assert.Nil(t, msg.SetBody("", nil))
+	assert.Nil(t, msg.Headers.Find(SIPHdrContentType))
+	assert.EqualValues(t, 0, msg.ContentLen)
+	assert.False(t, msg.HasSDP())
+	assert.NotNil(t, msg.SetBody("foo", nil))
+}
+
+func TestMultipartNested(t *testing.T) {
+	sdp, _ := NewPart("application/sdp", []byte(multipartSDP))
+	text, _ := NewPart("text/plain", []byte("alternative"))
+	alt := NewMultipart(text, sdp)
+	alt.Subtype = "alternative"
+	nested, err := NewPart(alt.ContentType(), alt.Bytes())
+	assert.Nil(t, err)
+	pidf, _ := NewPart("application/pidf+xml", []byte("<presence/>"))
+
+	msg := multipartInvite(t, "text/plain", "")
+	assert.Nil(t, msg.SetMultipart(NewMultipart(pidf, nested)))
+	assert.True(t, msg.HasSDP())
+	assert.Equal(t, multipartSDP, string(msg.SDPBody()))
+}
@@ -30,7 +30,9 @@ func parseChallenge (data []byte) (*Challenge, error) {
     opaque  = "opaque"i EQUAL quoted_string >sm %opaque;
     stale   = "stale"i EQUAL ( "true"i %{ch.stale = true} | "false"i %{ch.stale = false} );
     algo    = "algorithm"i EQUAL
-              ( "MD5"i %{ ch.algo = AlgoMD5 } | "MD5-sess"i %{ ch.algo = AlgoMD5sess });
+              ( "MD5"i %{ ch.algo = AlgoMD5 } | "MD5-sess"i %{ ch.algo = AlgoMD5sess } |
+                "SHA-256"i %{ ch.algo = AlgoSHA256 } | "SHA-256-sess"i %{ ch.algo = AlgoSHA256sess } |
+                "SHA-512-256"i %{ ch.algo = AlgoSHA512256 } | "SHA-512-256-sess"i %{ ch.algo = AlgoSHA512256sess } );
     qop     = "qop"i EQUAL LDQUOT qopval ("," qopval)* RDQUOT;
 
     digest  = realm | domain | nonce | opaque | stale | algo | qop;
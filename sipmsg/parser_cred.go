@@ -13,84 +13,92 @@ var _credentials_actions []byte = []byte{
 	0, 1, 0, 1, 1, 1, 2, 1, 3,
 	1, 4, 1, 5, 1, 6, 1, 7,
 	1, 8, 1, 9, 1, 10, 1, 11,
-	1, 12,
+	1, 12, 1, 13, 1, 15, 1, 14,
+	1, 16,
 }
 
 var _credentials_key_offsets []int16 = []int16{
 	0, 0, 1, 2, 3, 4, 5, 6,
 	9, 26, 27, 29, 45, 47, 49, 51,
 	53, 55, 57, 59, 61, 65, 66, 68,
-	71, 76, 77, 79, 83, 85, 86, 90,
-	91, 93, 96, 98, 100, 102, 104, 106,
-	108, 110, 112, 114, 118, 119, 121, 124,
-	128, 129, 131, 135, 136, 138, 141, 157,
-	158, 160, 166, 168, 170, 172, 174, 176,
-	180, 184, 185, 187, 190, 197, 198, 200,
-	206, 210, 214, 218, 222, 226, 230, 234,
-	236, 238, 240, 244, 245, 247, 250, 254,
-	255, 257, 261, 262, 264, 267, 283, 284,
-	286, 292, 294, 296, 298, 300, 302, 304,
-	306, 308, 310, 312, 316, 317, 319, 322,
-	326, 327, 329, 333, 334, 336, 339, 355,
-	356, 358, 364, 366, 368, 370, 372, 374,
-	376, 378, 382, 383, 385, 388, 393, 394,
-	396, 400, 402, 404, 406, 408, 410, 412,
-	414, 418, 420, 422, 426, 427, 429, 432,
-	436, 437, 439, 443, 444, 446, 449, 465,
-	466, 468, 474, 476, 478, 480, 482, 484,
-	486, 488, 490, 492, 494, 498, 499, 501,
-	504, 508, 509, 511, 515, 516, 518, 521,
-	525, 529, 533, 537, 541, 545, 549, 553,
-	557, 561, 565, 569, 573, 577, 581, 585,
-	589, 593, 597, 601, 605, 609, 613, 617,
-	621, 625, 629, 633, 637, 641, 645, 649,
-	650, 651, 653, 657, 659, 663, 664, 666,
-	669, 673, 674, 676, 680, 681, 683, 686,
-	692, 702, 715, 727, 733, 739, 752, 767,
-	781, 787, 793, 810, 816, 822, 835, 842,
-	850, 858, 866, 868, 875, 884, 886, 889,
-	891, 894, 896, 899, 902, 903, 907, 909,
-	914, 919, 924, 929, 932, 935, 936, 939,
-	940, 949, 958, 966, 974, 982, 990, 992,
-	998, 1007, 1016, 1025, 1027, 1030, 1033, 1034,
-	1035, 1047, 1059, 1082, 1095, 1101, 1107, 1121,
-	1127, 1133, 1140, 1148, 1155, 1163, 1169, 1181,
-	1188, 1198, 1200, 1205, 1210, 1215, 1220, 1223,
-	1242, 1259, 1265, 1271, 1284, 1300, 1306, 1312,
-	1327, 1343, 1349, 1355, 1371, 1377, 1383, 1402,
-	1421, 1440, 1459, 1478, 1495, 1514, 1536, 1559,
-	1576, 1599, 1618, 1637, 1656, 1675, 1694, 1713,
-	1732, 1751, 1770, 1789, 1808, 1814, 1822, 1828,
-	1836, 1842, 1854, 1866, 1878, 1886, 1894, 1902,
-	1910, 1918, 1926, 1933, 1941, 1949, 1957, 1959,
-	1966, 1975, 1977, 1980, 1982, 1985, 1987, 1990,
-	1993, 1994, 1998, 2001, 2002, 2005, 2006, 2015,
-	2024, 2032, 2040, 2048, 2056, 2058, 2064, 2073,
-	2082, 2091, 2093, 2096, 2099, 2100, 2101, 2120,
-	2137, 2154, 2160, 2166, 2185, 2205, 2224, 2244,
-	2262, 2282, 2301, 2319, 2335, 2352, 2369, 2386,
-	2403, 2417, 2441, 2459, 2465, 2471, 2489, 2507,
-	2513, 2519, 2537, 2555, 2561, 2567, 2585, 2591,
-	2597, 2617, 2637, 2657, 2677, 2697, 2715, 2738,
-	2761, 2784, 2807, 2827, 2847, 2867, 2887, 2907,
-	2927, 2947, 2967, 2987, 3007, 3027, 3045, 3065,
-	3083, 3103, 3121, 3141, 3161, 3181, 3201, 3221,
-	3241, 3261, 3281, 3301, 3321, 3341, 3361, 3382,
-	3403, 3423, 3445, 3464, 3483, 3502, 3521, 3540,
-	3557, 3581, 3600, 3606, 3612, 3632, 3638, 3644,
-	3661, 3681, 3687, 3693, 3711, 3730, 3736, 3742,
-	3760, 3778, 3784, 3790, 3809, 3815, 3821, 3841,
-	3847, 3853, 3872, 3891, 3897, 3903, 3924, 3945,
-	3966, 3987, 4008, 4027, 4049, 4072, 4095, 4118,
-	4139, 4160, 4181, 4202, 4223, 4244, 4265, 4286,
-	4307, 4328, 4349, 4370, 4390, 4411, 4431, 4452,
-	4473, 4494, 4515, 4535, 4555, 4575, 4595, 4615,
-	4635, 4657, 4678, 4700, 4721, 4742, 4744, 4746,
-	4748, 4750, 4752, 4754, 4758, 4759, 4761, 4764,
-	4768, 4769, 4771, 4775, 4776, 4778, 4781, 4797,
-	4798, 4800, 4806, 4808, 4810, 4812, 4814, 4816,
-	4821, 4825, 4829, 4833, 4837, 4841, 4846, 4850,
-	4854, 4858, 4862,
+	71, 78, 79, 81, 87, 89, 90, 94,
+	95, 97, 100, 102, 104, 106, 108, 110,
+	112, 114, 116, 118, 122, 123, 125, 128,
+	132, 133, 135, 139, 140, 142, 145, 161,
+	162, 164, 170, 172, 174, 176, 178, 180,
+	184, 188, 189, 191, 194, 201, 202, 204,
+	210, 214, 218, 222, 226, 230, 234, 238,
+	240, 242, 244, 248, 249, 251, 254, 258,
+	259, 261, 265, 266, 268, 271, 287, 288,
+	290, 296, 298, 300, 302, 304, 306, 308,
+	310, 312, 314, 316, 320, 321, 323, 326,
+	330, 331, 333, 337, 338, 340, 343, 359,
+	360, 362, 368, 370, 372, 374, 376, 378,
+	380, 382, 386, 387, 389, 392, 397, 398,
+	400, 404, 406, 408, 410, 412, 414, 416,
+	418, 422, 424, 426, 430, 431, 433, 436,
+	440, 441, 443, 447, 448, 450, 453, 469,
+	470, 472, 478, 480, 482, 484, 486, 488,
+	490, 492, 494, 496, 498, 502, 503, 505,
+	508, 512, 513, 515, 519, 520, 522, 525,
+	529, 533, 537, 541, 545, 549, 553, 557,
+	561, 565, 569, 573, 577, 581, 585, 589,
+	593, 597, 601, 605, 609, 613, 617, 621,
+	625, 629, 633, 637, 641, 645, 649, 653,
+	658, 659, 661, 665, 667, 671, 672, 674,
+	677, 681, 682, 684, 688, 689, 691, 694,
+	700, 710, 723, 735, 741, 747, 760, 775,
+	789, 795, 801, 818, 824, 830, 843, 850,
+	858, 866, 874, 876, 883, 892, 894, 897,
+	899, 902, 904, 907, 910, 911, 915, 917,
+	922, 927, 932, 937, 940, 943, 944, 947,
+	948, 957, 966, 974, 982, 990, 998, 1000,
+	1006, 1015, 1024, 1033, 1035, 1038, 1041, 1042,
+	1043, 1055, 1067, 1090, 1103, 1109, 1115, 1129,
+	1135, 1141, 1148, 1156, 1163, 1171, 1177, 1189,
+	1196, 1206, 1208, 1213, 1218, 1223, 1228, 1231,
+	1250, 1267, 1273, 1279, 1292, 1308, 1314, 1320,
+	1335, 1351, 1357, 1363, 1379, 1385, 1391, 1410,
+	1429, 1448, 1467, 1486, 1503, 1522, 1544, 1567,
+	1584, 1607, 1626, 1645, 1664, 1683, 1702, 1721,
+	1740, 1759, 1778, 1797, 1816, 1822, 1830, 1836,
+	1844, 1850, 1862, 1874, 1886, 1894, 1902, 1910,
+	1918, 1926, 1934, 1941, 1949, 1957, 1965, 1967,
+	1974, 1983, 1985, 1988, 1990, 1993, 1995, 1998,
+	2001, 2002, 2006, 2009, 2010, 2013, 2014, 2023,
+	2032, 2040, 2048, 2056, 2064, 2066, 2072, 2081,
+	2090, 2099, 2101, 2104, 2107, 2108, 2109, 2128,
+	2145, 2162, 2168, 2174, 2193, 2213, 2232, 2252,
+	2270, 2290, 2309, 2327, 2343, 2360, 2377, 2394,
+	2411, 2425, 2449, 2467, 2473, 2479, 2497, 2515,
+	2521, 2527, 2545, 2563, 2569, 2575, 2593, 2599,
+	2605, 2625, 2645, 2665, 2685, 2705, 2723, 2746,
+	2769, 2792, 2815, 2835, 2855, 2875, 2895, 2915,
+	2935, 2955, 2975, 2995, 3015, 3035, 3053, 3073,
+	3091, 3111, 3129, 3149, 3169, 3189, 3209, 3229,
+	3249, 3269, 3289, 3309, 3329, 3349, 3369, 3390,
+	3411, 3431, 3453, 3472, 3491, 3510, 3529, 3548,
+	3565, 3589, 3608, 3614, 3620, 3640, 3646, 3652,
+	3669, 3689, 3695, 3701, 3719, 3738, 3744, 3750,
+	3768, 3786, 3792, 3798, 3817, 3823, 3829, 3849,
+	3855, 3861, 3880, 3899, 3905, 3911, 3932, 3953,
+	3974, 3995, 4016, 4035, 4057, 4080, 4103, 4126,
+	4147, 4168, 4189, 4210, 4231, 4252, 4273, 4294,
+	4315, 4336, 4357, 4378, 4398, 4419, 4439, 4460,
+	4481, 4502, 4523, 4543, 4563, 4583, 4603, 4623,
+	4643, 4665, 4686, 4708, 4729, 4750, 4752, 4754,
+	4756, 4758, 4760, 4762, 4766, 4767, 4769, 4772,
+	4776, 4777, 4779, 4783, 4784, 4786, 4789, 4805,
+	4806, 4808, 4814, 4816, 4818, 4820, 4822, 4824,
+	4826, 4828, 4829, 4831, 4832, 4833, 4834, 4835,
+	4836, 4837, 4838, 4839, 4841, 4843, 4845, 4847,
+	4849, 4851, 4853, 4855, 4859, 4863, 4867, 4871,
+	4875, 4879, 4883, 4887, 4891, 4895, 4899, 4903,
+	4907, 4911, 4915, 4919, 4923, 4927, 4931, 4935,
+	4939, 4943, 4947, 4951, 4955, 4959, 4963, 4967,
+	4971, 4975, 4979, 4980, 4985, 4989, 4993, 4997,
+	5001, 5005, 5010, 5014, 5018, 5022, 5026, 5030,
+	5035, 5040, 5044,
 }
 
 var _credentials_trans_keys []byte = []byte{
@@ -103,50 +111,12 @@ var _credentials_trans_keys []byte = []byte{
 	103, 79, 111, 82, 114, 73, 105, 84,
 	116, 72, 104, 77, 109, 9, 13, 32,
 	61, 10, 9, 32, 9, 32, 61, 9,
-	13, 32, 77, 109, 10, 9, 32, 9,
-	32, 77, 109, 68, 100, 53, 9, 13,
-	32, 44, 10, 9, 32, 9, 32, 44,
-	83, 115, 69, 101, 83, 115, 83, 115,
-	78, 110, 79, 111, 78, 110, 67, 99,
-	69, 101, 9, 13, 32, 61, 10, 9,
-	32, 9, 32, 61, 9, 13, 32, 34,
-	10, 9, 32, 9, 13, 32, 34, 10,
-	9, 32, 9, 32, 34, 9, 13, 34,
-	92, 32, 126, 192, 223, 224, 239, 240,
-	247, 248, 251, 252, 253, 10, 9, 32,
-	0, 9, 11, 12, 14, 127, 128, 191,
-	128, 191, 128, 191, 128, 191, 128, 191,
-	67, 79, 99, 111, 9, 13, 32, 61,
-	10, 9, 32, 9, 32, 61, 9, 13,
-	32, 48, 57, 97, 102, 10, 9, 32,
-	9, 32, 48, 57, 97, 102, 48, 57,
-	97, 102, 48, 57, 97, 102, 48, 57,
-	97, 102, 48, 57, 97, 102, 48, 57,
-	97, 102, 48, 57, 97, 102, 48, 57,
-	97, 102, 78, 110, 67, 99, 69, 101,
-	9, 13, 32, 61, 10, 9, 32, 9,
-	32, 61, 9, 13, 32, 34, 10, 9,
-	32, 9, 13, 32, 34, 10, 9, 32,
-	9, 32, 34, 9, 13, 34, 92, 32,
-	126, 192, 223, 224, 239, 240, 247, 248,
-	251, 252, 253, 10, 9, 32, 0, 9,
-	11, 12, 14, 127, 128, 191, 128, 191,
-	128, 191, 128, 191, 128, 191, 80, 112,
-	65, 97, 81, 113, 85, 117, 69, 101,
-	9, 13, 32, 61, 10, 9, 32, 9,
-	32, 61, 9, 13, 32, 34, 10, 9,
-	32, 9, 13, 32, 34, 10, 9, 32,
-	9, 32, 34, 9, 13, 34, 92, 32,
-	126, 192, 223, 224, 239, 240, 247, 248,
-	251, 252, 253, 10, 9, 32, 0, 9,
-	11, 12, 14, 127, 128, 191, 128, 191,
-	128, 191, 128, 191, 128, 191, 79, 111,
-	80, 112, 9, 13, 32, 61, 10, 9,
-	32, 9, 32, 61, 9, 13, 32, 65,
-	97, 10, 9, 32, 9, 32, 65, 97,
-	85, 117, 84, 116, 72, 104, 73, 105,
-	78, 110, 84, 116, 69, 101, 65, 83,
-	97, 115, 76, 108, 77, 109, 9, 13,
+	13, 32, 77, 83, 109, 115, 10, 9,
+	32, 9, 32, 77, 83, 109, 115, 68,
+	100, 53, 9, 13, 32, 44, 10, 9,
+	32, 9, 32, 44, 83, 115, 69, 101,
+	83, 115, 83, 115, 78, 110, 79, 111,
+	78, 110, 67, 99, 69, 101, 9, 13,
 	32, 61, 10, 9, 32, 9, 32, 61,
 	9, 13, 32, 34, 10, 9, 32, 9,
 	13, 32, 34, 10, 9, 32, 9, 32,
@@ -154,12 +124,50 @@ var _credentials_trans_keys []byte = []byte{
 	223, 224, 239, 240, 247, 248, 251, 252,
 	253, 10, 9, 32, 0, 9, 11, 12,
 	14, 127, 128, 191, 128, 191, 128, 191,
-	128, 191, 128, 191, 80, 112, 79, 111,
-	78, 110, 83, 115, 69, 101, 9, 13,
+	128, 191, 128, 191, 67, 79, 99, 111,
+	9, 13, 32, 61, 10, 9, 32, 9,
+	32, 61, 9, 13, 32, 48, 57, 97,
+	102, 10, 9, 32, 9, 32, 48, 57,
+	97, 102, 48, 57, 97, 102, 48, 57,
+	97, 102, 48, 57, 97, 102, 48, 57,
+	97, 102, 48, 57, 97, 102, 48, 57,
+	97, 102, 48, 57, 97, 102, 78, 110,
+	67, 99, 69, 101, 9, 13, 32, 61,
+	10, 9, 32, 9, 32, 61, 9, 13,
+	32, 34, 10, 9, 32, 9, 13, 32,
+	34, 10, 9, 32, 9, 32, 34, 9,
+	13, 34, 92, 32, 126, 192, 223, 224,
+	239, 240, 247, 248, 251, 252, 253, 10,
+	9, 32, 0, 9, 11, 12, 14, 127,
+	128, 191, 128, 191, 128, 191, 128, 191,
+	128, 191, 80, 112, 65, 97, 81, 113,
+	85, 117, 69, 101, 9, 13, 32, 61,
+	10, 9, 32, 9, 32, 61, 9, 13,
+	32, 34, 10, 9, 32, 9, 13, 32,
+	34, 10, 9, 32, 9, 32, 34, 9,
+	13, 34, 92, 32, 126, 192, 223, 224,
+	239, 240, 247, 248, 251, 252, 253, 10,
+	9, 32, 0, 9, 11, 12, 14, 127,
+	128, 191, 128, 191, 128, 191, 128, 191,
+	128, 191, 79, 111, 80, 112, 9, 13,
 	32, 61, 10, 9, 32, 9, 32, 61,
-	9, 13, 32, 34, 10, 9, 32, 9,
-	13, 32, 34, 10, 9, 32, 9, 32,
-	34, 48, 57, 97, 102, 48, 57, 97,
+	9, 13, 32, 65, 97, 10, 9, 32,
+	9, 32, 65, 97, 85, 117, 84, 116,
+	72, 104, 73, 105, 78, 110, 84, 116,
+	69, 101, 65, 83, 97, 115, 76, 108,
+	77, 109, 9, 13, 32, 61, 10, 9,
+	32, 9, 32, 61, 9, 13, 32, 34,
+	10, 9, 32, 9, 13, 32, 34, 10,
+	9, 32, 9, 32, 34, 9, 13, 34,
+	92, 32, 126, 192, 223, 224, 239, 240,
+	247, 248, 251, 252, 253, 10, 9, 32,
+	0, 9, 11, 12, 14, 127, 128, 191,
+	128, 191, 128, 191, 128, 191, 128, 191,
+	80, 112, 79, 111, 78, 110, 83, 115,
+	69, 101, 9, 13, 32, 61, 10, 9,
+	32, 9, 32, 61, 9, 13, 32, 34,
+	10, 9, 32, 9, 13, 32, 34, 10,
+	9, 32, 9, 32, 34, 48, 57, 97,
 	102, 48, 57, 97, 102, 48, 57, 97,
 	102, 48, 57, 97, 102, 48, 57, 97,
 	102, 48, 57, 97, 102, 48, 57, 97,
@@ -175,7 +183,8 @@ var _credentials_trans_keys []byte = []byte{
 	102, 48, 57, 97, 102, 48, 57, 97,
 	102, 48, 57, 97, 102, 48, 57, 97,
 	102, 48, 57, 97, 102, 48, 57, 97,
-	102, 34, 10, 9, 32, 82, 83, 114,
+	102, 48, 57, 97, 102, 34, 48, 57,
+	97, 102, 10, 9, 32, 82, 83, 114,
 	115, 73, 105, 9, 13, 32, 61, 10,
 	9, 32, 9, 32, 61, 9, 13, 32,
 	34, 10, 9, 32, 9, 13, 32, 34,
@@ -696,20 +705,41 @@ var _credentials_trans_keys []byte = []byte{
 	247, 248, 251, 252, 253, 10, 9, 32,
 	0, 9, 11, 12, 14, 127, 128, 191,
 	128, 191, 128, 191, 128, 191, 128, 191,
-	9, 13, 32, 44, 45, 9, 13, 32,
+	72, 104, 65, 97, 45, 50, 53, 53,
+	54, 49, 50, 45, 50, 53, 54, 83,
+	115, 69, 101, 83, 115, 83, 115, 83,
+	115, 69, 101, 83, 115, 83, 115, 48,
+	57, 97, 102, 48, 57, 97, 102, 48,
+	57, 97, 102, 48, 57, 97, 102, 48,
+	57, 97, 102, 48, 57, 97, 102, 48,
+	57, 97, 102, 48, 57, 97, 102, 48,
+	57, 97, 102, 48, 57, 97, 102, 48,
+	57, 97, 102, 48, 57, 97, 102, 48,
+	57, 97, 102, 48, 57, 97, 102, 48,
+	57, 97, 102, 48, 57, 97, 102, 48,
+	57, 97, 102, 48, 57, 97, 102, 48,
+	57, 97, 102, 48, 57, 97, 102, 48,
+	57, 97, 102, 48, 57, 97, 102, 48,
+	57, 97, 102, 48, 57, 97, 102, 48,
+	57, 97, 102, 48, 57, 97, 102, 48,
+	57, 97, 102, 48, 57, 97, 102, 48,
+	57, 97, 102, 48, 57, 97, 102, 48,
+	57, 97, 102, 34, 9, 13, 32, 44,
+	45, 9, 13, 32, 44, 9, 13, 32,
 	44, 9, 13, 32, 44, 9, 13, 32,
 	44, 9, 13, 32, 44, 9, 13, 32,
-	44, 9, 13, 32, 44, 45, 9, 13,
+	44, 45, 9, 13, 32, 44, 9, 13,
 	32, 44, 9, 13, 32, 44, 9, 13,
 	32, 44, 9, 13, 32, 44, 9, 13,
-	32, 44,
+	32, 44, 45, 9, 13, 32, 44, 45,
+	9, 13, 32, 44, 9, 13, 32, 44,
 }
 
 var _credentials_single_lengths []byte = []byte{
 	0, 1, 1, 1, 1, 1, 1, 3,
 	17, 1, 2, 16, 2, 2, 2, 2,
 	2, 2, 2, 2, 4, 1, 2, 3,
-	5, 1, 2, 4, 2, 1, 4, 1,
+	7, 1, 2, 6, 2, 1, 4, 1,
 	2, 3, 2, 2, 2, 2, 2, 2,
 	2, 2, 2, 4, 1, 2, 3, 4,
 	1, 2, 4, 1, 2, 3, 4, 1,
@@ -777,9 +807,16 @@ var _credentials_single_lengths []byte = []byte{
 	14, 13, 12, 13, 13, 2, 2, 2,
 	2, 2, 2, 4, 1, 2, 3, 4,
 	1, 2, 4, 1, 2, 3, 4, 1,
-	2, 0, 0, 0, 0, 0, 0, 5,
-	4, 4, 4, 4, 4, 5, 4, 4,
-	4, 4, 4,
+	2, 0, 0, 0, 0, 0, 0, 2,
+	2, 1, 2, 1, 1, 1, 1, 1,
+	1, 1, 1, 2, 2, 2, 2, 2,
+	2, 2, 2, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 1, 5, 4, 4, 4, 4,
+	4, 5, 4, 4, 4, 4, 4, 5,
+	5, 4, 4,
 }
 
 var _credentials_range_lengths []byte = []byte{
@@ -809,7 +846,7 @@ var _credentials_range_lengths []byte = []byte{
 	2, 2, 2, 2, 2, 2, 2, 2,
 	2, 2, 2, 2, 2, 2, 2, 2,
 	2, 2, 2, 2, 2, 2, 2, 2,
-	2, 2, 2, 2, 2, 2, 2, 0,
+	2, 2, 2, 2, 2, 2, 2, 2,
 	0, 0, 0, 0, 0, 0, 0, 0,
 	0, 0, 0, 0, 0, 0, 0, 2,
 	4, 3, 3, 3, 3, 3, 3, 3,
@@ -856,6 +893,13 @@ var _credentials_range_lengths []byte = []byte{
 	0, 0, 0, 0, 0, 0, 6, 0,
 	0, 3, 1, 1, 1, 1, 1, 0,
 	0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 2, 2, 2, 2, 2,
+	2, 2, 2, 2, 2, 2, 2, 2,
+	2, 2, 2, 2, 2, 2, 2, 2,
+	2, 2, 2, 2, 2, 2, 2, 2,
+	2, 2, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0,
 	0, 0, 0,
 }
 
@@ -863,77 +907,84 @@ var _credentials_index_offsets []int16 = []int16{
 	0, 0, 2, 4, 6, 8, 10, 12,
 	16, 34, 36, 39, 56, 59, 62, 65,
 	68, 71, 74, 77, 80, 85, 87, 90,
-	94, 100, 102, 105, 110, 113, 115, 120,
-	122, 125, 129, 132, 135, 138, 141, 144,
-	147, 150, 153, 156, 161, 163, 166, 170,
-	175, 177, 180, 185, 187, 190, 194, 205,
-	207, 210, 214, 216, 218, 220, 222, 224,
-	229, 234, 236, 239, 243, 249, 251, 254,
-	259, 262, 265, 268, 271, 274, 277, 280,
-	283, 286, 289, 294, 296, 299, 303, 308,
-	310, 313, 318, 320, 323, 327, 338, 340,
-	343, 347, 349, 351, 353, 355, 357, 360,
-	363, 366, 369, 372, 377, 379, 382, 386,
-	391, 393, 396, 401, 403, 406, 410, 421,
-	423, 426, 430, 432, 434, 436, 438, 440,
-	443, 446, 451, 453, 456, 460, 466, 468,
-	471, 476, 479, 482, 485, 488, 491, 494,
-	497, 502, 505, 508, 513, 515, 518, 522,
-	527, 529, 532, 537, 539, 542, 546, 557,
-	559, 562, 566, 568, 570, 572, 574, 576,
-	579, 582, 585, 588, 591, 596, 598, 601,
-	605, 610, 612, 615, 620, 622, 625, 629,
-	632, 635, 638, 641, 644, 647, 650, 653,
-	656, 659, 662, 665, 668, 671, 674, 677,
-	680, 683, 686, 689, 692, 695, 698, 701,
-	704, 707, 710, 713, 716, 719, 722, 725,
-	727, 729, 732, 737, 740, 745, 747, 750,
-	754, 759, 761, 764, 769, 771, 774, 778,
-	783, 790, 801, 811, 815, 819, 830, 843,
-	855, 859, 863, 877, 881, 885, 896, 901,
-	907, 913, 919, 922, 927, 934, 936, 939,
-	941, 944, 946, 949, 952, 954, 959, 961,
-	966, 971, 976, 981, 985, 988, 990, 993,
-	995, 1002, 1009, 1015, 1021, 1027, 1033, 1036,
-	1040, 1047, 1054, 1061, 1063, 1066, 1069, 1071,
-	1073, 1082, 1091, 1110, 1121, 1125, 1129, 1140,
-	1144, 1148, 1153, 1159, 1164, 1170, 1174, 1184,
-	1189, 1197, 1199, 1204, 1209, 1214, 1219, 1223,
-	1239, 1253, 1257, 1261, 1271, 1284, 1288, 1292,
-	1304, 1317, 1321, 1325, 1338, 1342, 1346, 1362,
-	1378, 1394, 1410, 1426, 1440, 1455, 1473, 1489,
-	1502, 1518, 1534, 1550, 1566, 1582, 1598, 1614,
-	1630, 1646, 1662, 1678, 1694, 1698, 1704, 1708,
-	1714, 1718, 1728, 1738, 1748, 1754, 1760, 1766,
-	1772, 1778, 1784, 1789, 1795, 1801, 1807, 1810,
-	1815, 1822, 1824, 1827, 1829, 1832, 1834, 1837,
-	1840, 1842, 1847, 1850, 1852, 1855, 1857, 1864,
-	1871, 1877, 1883, 1889, 1895, 1898, 1902, 1909,
-	1916, 1923, 1925, 1928, 1931, 1933, 1935, 1951,
-	1965, 1979, 1983, 1987, 2001, 2016, 2030, 2045,
-	2058, 2075, 2089, 2104, 2116, 2130, 2144, 2158,
-	2172, 2184, 2205, 2221, 2225, 2229, 2244, 2260,
-	2264, 2268, 2284, 2300, 2304, 2308, 2324, 2328,
-	2332, 2350, 2368, 2386, 2404, 2422, 2438, 2457,
-	2477, 2493, 2509, 2527, 2545, 2563, 2581, 2599,
-	2617, 2635, 2653, 2671, 2689, 2707, 2720, 2735,
-	2748, 2763, 2776, 2793, 2810, 2827, 2842, 2857,
-	2872, 2887, 2902, 2917, 2934, 2951, 2968, 2985,
-	3003, 3020, 3038, 3053, 3069, 3085, 3101, 3117,
-	3132, 3154, 3171, 3175, 3179, 3197, 3201, 3205,
-	3220, 3238, 3242, 3246, 3262, 3279, 3283, 3287,
-	3303, 3319, 3323, 3327, 3344, 3348, 3352, 3370,
-	3374, 3378, 3395, 3412, 3416, 3420, 3439, 3458,
-	3477, 3496, 3515, 3532, 3551, 3571, 3587, 3603,
-	3622, 3641, 3660, 3679, 3698, 3717, 3736, 3755,
-	3774, 3793, 3812, 3829, 3846, 3863, 3880, 3897,
-	3915, 3933, 3951, 3968, 3985, 4002, 4019, 4036,
-	4053, 4072, 4090, 4108, 4126, 4144, 4147, 4150,
-	4153, 4156, 4159, 4162, 4167, 4169, 4172, 4176,
-	4181, 4183, 4186, 4191, 4193, 4196, 4200, 4211,
-	4213, 4216, 4220, 4222, 4224, 4226, 4228, 4230,
-	4236, 4241, 4246, 4251, 4256, 4261, 4267, 4272,
-	4277, 4282, 4287,
+	94, 102, 104, 107, 114, 117, 119, 124,
+	126, 129, 133, 136, 139, 142, 145, 148,
+	151, 154, 157, 160, 165, 167, 170, 174,
+	179, 181, 184, 189, 191, 194, 198, 209,
+	211, 214, 218, 220, 222, 224, 226, 228,
+	233, 238, 240, 243, 247, 253, 255, 258,
+	263, 266, 269, 272, 275, 278, 281, 284,
+	287, 290, 293, 298, 300, 303, 307, 312,
+	314, 317, 322, 324, 327, 331, 342, 344,
+	347, 351, 353, 355, 357, 359, 361, 364,
+	367, 370, 373, 376, 381, 383, 386, 390,
+	395, 397, 400, 405, 407, 410, 414, 425,
+	427, 430, 434, 436, 438, 440, 442, 444,
+	447, 450, 455, 457, 460, 464, 470, 472,
+	475, 480, 483, 486, 489, 492, 495, 498,
+	501, 506, 509, 512, 517, 519, 522, 526,
+	531, 533, 536, 541, 543, 546, 550, 561,
+	563, 566, 570, 572, 574, 576, 578, 580,
+	583, 586, 589, 592, 595, 600, 602, 605,
+	609, 614, 616, 619, 624, 626, 629, 633,
+	636, 639, 642, 645, 648, 651, 654, 657,
+	660, 663, 666, 669, 672, 675, 678, 681,
+	684, 687, 690, 693, 696, 699, 702, 705,
+	708, 711, 714, 717, 720, 723, 726, 729,
+	733, 735, 738, 743, 746, 751, 753, 756,
+	760, 765, 767, 770, 775, 777, 780, 784,
+	789, 796, 807, 817, 821, 825, 836, 849,
+	861, 865, 869, 883, 887, 891, 902, 907,
+	913, 919, 925, 928, 933, 940, 942, 945,
+	947, 950, 952, 955, 958, 960, 965, 967,
+	972, 977, 982, 987, 991, 994, 996, 999,
+	1001, 1008, 1015, 1021, 1027, 1033, 1039, 1042,
+	1046, 1053, 1060, 1067, 1069, 1072, 1075, 1077,
+	1079, 1088, 1097, 1116, 1127, 1131, 1135, 1146,
+	1150, 1154, 1159, 1165, 1170, 1176, 1180, 1190,
+	1195, 1203, 1205, 1210, 1215, 1220, 1225, 1229,
+	1245, 1259, 1263, 1267, 1277, 1290, 1294, 1298,
+	1310, 1323, 1327, 1331, 1344, 1348, 1352, 1368,
+	1384, 1400, 1416, 1432, 1446, 1461, 1479, 1495,
+	1508, 1524, 1540, 1556, 1572, 1588, 1604, 1620,
+	1636, 1652, 1668, 1684, 1700, 1704, 1710, 1714,
+	1720, 1724, 1734, 1744, 1754, 1760, 1766, 1772,
+	1778, 1784, 1790, 1795, 1801, 1807, 1813, 1816,
+	1821, 1828, 1830, 1833, 1835, 1838, 1840, 1843,
+	1846, 1848, 1853, 1856, 1858, 1861, 1863, 1870,
+	1877, 1883, 1889, 1895, 1901, 1904, 1908, 1915,
+	1922, 1929, 1931, 1934, 1937, 1939, 1941, 1957,
+	1971, 1985, 1989, 1993, 2007, 2022, 2036, 2051,
+	2064, 2081, 2095, 2110, 2122, 2136, 2150, 2164,
+	2178, 2190, 2211, 2227, 2231, 2235, 2250, 2266,
+	2270, 2274, 2290, 2306, 2310, 2314, 2330, 2334,
+	2338, 2356, 2374, 2392, 2410, 2428, 2444, 2463,
+	2483, 2499, 2515, 2533, 2551, 2569, 2587, 2605,
+	2623, 2641, 2659, 2677, 2695, 2713, 2726, 2741,
+	2754, 2769, 2782, 2799, 2816, 2833, 2848, 2863,
+	2878, 2893, 2908, 2923, 2940, 2957, 2974, 2991,
+	3009, 3026, 3044, 3059, 3075, 3091, 3107, 3123,
+	3138, 3160, 3177, 3181, 3185, 3203, 3207, 3211,
+	3226, 3244, 3248, 3252, 3268, 3285, 3289, 3293,
+	3309, 3325, 3329, 3333, 3350, 3354, 3358, 3376,
+	3380, 3384, 3401, 3418, 3422, 3426, 3445, 3464,
+	3483, 3502, 3521, 3538, 3557, 3577, 3593, 3609,
+	3628, 3647, 3666, 3685, 3704, 3723, 3742, 3761,
+	3780, 3799, 3818, 3835, 3852, 3869, 3886, 3903,
+	3921, 3939, 3957, 3974, 3991, 4008, 4025, 4042,
+	4059, 4078, 4096, 4114, 4132, 4150, 4153, 4156,
+	4159, 4162, 4165, 4168, 4173, 4175, 4178, 4182,
+	4187, 4189, 4192, 4197, 4199, 4202, 4206, 4217,
+	4219, 4222, 4226, 4228, 4230, 4232, 4234, 4236,
+	4238, 4240, 4241, 4243, 4244, 4245, 4246, 4247,
+	4248, 4249, 4250, 4251, 4253, 4255, 4257, 4259,
+	4261, 4263, 4265, 4267, 4269, 4271, 4273, 4275,
+	4277, 4279, 4281, 4283, 4285, 4287, 4289, 4291,
+	4293, 4295, 4297, 4299, 4301, 4303, 4305, 4307,
+	4309, 4311, 4313, 4315, 4317, 4319, 4321, 4323,
+	4325, 4327, 4329, 4330, 4336, 4341, 4346, 4351,
+	4356, 4361, 4367, 4372, 4377, 4382, 4387, 4392,
+	4398, 4404, 4409,
 }
 
 var _credentials_indicies []int16 = []int16{
@@ -949,531 +1000,546 @@ var _credentials_indicies []int16 = []int16{
 	23, 1, 24, 24, 1, 25, 25, 1,
 	25, 26, 25, 27, 1, 28, 1, 29,
 	29, 1, 29, 29, 27, 1, 27, 30,
-	27, 31, 31, 1, 32, 1, 33, 33,
-	1, 33, 33, 31, 31, 1, 34, 34,
-	1, 35, 1, 36, 37, 36, 7, 1,
-	38, 1, 39, 39, 1, 39, 39, 7,
-	1, 40, 40, 1, 41, 41, 1, 42,
-	42, 1, 43, 43, 1, 44, 44, 1,
-	45, 45, 1, 46, 46, 1, 47, 47,
-	1, 48, 48, 1, 48, 49, 48, 50,
-	1, 51, 1, 52, 52, 1, 52, 52,
-	50, 1, 53, 54, 53, 55, 1, 56,
-	1, 57, 57, 1, 58, 59, 58, 55,
-	1, 60, 1, 61, 61, 1, 61, 61,
-	62, 1, 62, 63, 64, 65, 62, 66,
-	67, 68, 69, 70, 1, 71, 1, 62,
-	62, 1, 62, 62, 62, 1, 62, 1,
-	66, 1, 67, 1, 68, 1, 69, 1,
-	72, 73, 72, 73, 1, 72, 74, 72,
-	75, 1, 76, 1, 77, 77, 1, 77,
-	77, 75, 1, 75, 78, 75, 79, 79,
-	1, 80, 1, 81, 81, 1, 81, 81,
-	79, 79, 1, 82, 82, 1, 83, 83,
-	1, 84, 84, 1, 85, 85, 1, 86,
-	86, 1, 87, 87, 1, 88, 88, 1,
-	89, 89, 1, 90, 90, 1, 91, 91,
-	1, 91, 92, 91, 93, 1, 94, 1,
-	95, 95, 1, 95, 95, 93, 1, 96,
-	97, 96, 98, 1, 99, 1, 100, 100,
-	1, 101, 102, 101, 98, 1, 103, 1,
-	104, 104, 1, 104, 104, 105, 1, 105,
-	106, 107, 108, 105, 109, 110, 111, 112,
-	113, 1, 114, 1, 105, 105, 1, 105,
-	105, 105, 1, 105, 1, 109, 1, 110,
-	1, 111, 1, 112, 1, 115, 115, 1,
-	116, 116, 1, 117, 117, 1, 118, 118,
-	1, 119, 119, 1, 119, 120, 119, 121,
-	1, 122, 1, 123, 123, 1, 123, 123,
-	121, 1, 124, 125, 124, 126, 1, 127,
-	1, 128, 128, 1, 129, 130, 129, 126,
-	1, 131, 1, 132, 132, 1, 132, 132,
-	133, 1, 133, 134, 135, 136, 133, 137,
-	138, 139, 140, 141, 1, 142, 1, 133,
-	133, 1, 133, 133, 133, 1, 133, 1,
-	137, 1, 138, 1, 139, 1, 140, 1,
-	143, 143, 1, 144, 144, 1, 144, 145,
-	144, 146, 1, 147, 1, 148, 148, 1,
-	148, 148, 146, 1, 146, 149, 146, 150,
-	150, 1, 151, 1, 152, 152, 1, 152,
-	152, 150, 150, 1, 153, 153, 1, 154,
-	154, 1, 155, 155, 1, 156, 156, 1,
-	157, 157, 1, 158, 158, 1, 159, 159,
-	1, 160, 161, 160, 161, 1, 162, 162,
-	1, 163, 163, 1, 163, 164, 163, 165,
-	1, 166, 1, 167, 167, 1, 167, 167,
-	165, 1, 168, 169, 168, 170, 1, 171,
-	1, 172, 172, 1, 173, 174, 173, 170,
-	1, 175, 1, 176, 176, 1, 176, 176,
-	177, 1, 177, 178, 179, 180, 177, 181,
-	182, 183, 184, 185, 1, 186, 1, 177,
-	177, 1, 177, 177, 177, 1, 177, 1,
-	181, 1, 182, 1, 183, 1, 184, 1,
-	187, 187, 1, 188, 188, 1, 189, 189,
-	1, 190, 190, 1, 191, 191, 1, 191,
-	192, 191, 193, 1, 194, 1, 195, 195,
-	1, 195, 195, 193, 1, 193, 196, 193,
-	197, 1, 198, 1, 199, 199, 1, 199,
-	200, 199, 197, 1, 201, 1, 202, 202,
-	1, 202, 202, 197, 1, 203, 203, 1,
-	204, 204, 1, 205, 205, 1, 206, 206,
-	1, 207, 207, 1, 208, 208, 1, 209,
-	209, 1, 210, 210, 1, 211, 211, 1,
-	212, 212, 1, 213, 213, 1, 214, 214,
-	1, 215, 215, 1, 216, 216, 1, 217,
-	217, 1, 218, 218, 1, 219, 219, 1,
-	220, 220, 1, 221, 221, 1, 222, 222,
-	1, 223, 223, 1, 224, 224, 1, 225,
-	225, 1, 226, 226, 1, 227, 227, 1,
-	228, 228, 1, 229, 229, 1, 230, 230,
-	1, 231, 231, 1, 232, 232, 1, 233,
-	233, 1, 234, 234, 1, 235, 1, 236,
-	1, 237, 237, 1, 238, 239, 238, 239,
-	1, 240, 240, 1, 240, 241, 240, 242,
-	1, 243, 1, 244, 244, 1, 244, 244,
-	242, 1, 242, 245, 242, 246, 1, 247,
-	1, 248, 248, 1, 248, 249, 248, 246,
-	1, 250, 1, 251, 251, 1, 251, 251,
-	246, 1, 253, 253, 252, 252, 1, 254,
-	255, 254, 254, 254, 254, 1, 256, 257,
-	258, 256, 256, 256, 256, 256, 256, 256,
-	1, 256, 259, 257, 256, 256, 256, 256,
-	256, 256, 1, 260, 260, 260, 1, 256,
-	256, 256, 1, 256, 259, 257, 261, 256,
-	256, 256, 256, 256, 256, 1, 262, 259,
-	263, 256, 262, 256, 264, 262, 262, 262,
-	262, 262, 1, 262, 259, 263, 265, 262,
-	266, 262, 262, 262, 262, 262, 1, 267,
-	267, 267, 1, 262, 262, 262, 1, 265,
-	259, 268, 256, 265, 256, 266, 265, 265,
-	265, 256, 265, 265, 1, 269, 269, 269,
-	1, 265, 265, 265, 1, 256, 259, 257,
-	256, 264, 256, 256, 256, 256, 256, 1,
-	271, 270, 270, 270, 1, 273, 274, 272,
-	272, 272, 1, 273, 274, 275, 275, 275,
-	1, 273, 274, 276, 276, 276, 1, 273,
-	274, 1, 278, 277, 270, 270, 1, 279,
-	273, 274, 280, 272, 272, 1, 281, 1,
-	282, 283, 1, 284, 1, 285, 286, 1,
-	287, 1, 274, 288, 1, 274, 289, 1,
-	274, 1, 259, 256, 290, 256, 1, 291,
-	1, 259, 256, 256, 292, 1, 259, 256,
-	256, 293, 1, 259, 256, 256, 294, 1,
-	259, 256, 256, 295, 1, 259, 256, 256,
-	1, 285, 296, 1, 285, 1, 282, 297,
-	1, 282, 1, 279, 273, 274, 298, 275,
-	275, 1, 279, 273, 274, 276, 276, 276,
-	1, 300, 274, 299, 299, 299, 1, 302,
-	274, 301, 301, 301, 1, 302, 274, 303,
-	303, 303, 1, 302, 274, 304, 304, 304,
-	1, 302, 274, 1, 305, 299, 299, 1,
-	279, 302, 274, 306, 301, 301, 1, 279,
-	302, 274, 307, 303, 303, 1, 279, 302,
-	274, 304, 304, 304, 1, 308, 1, 279,
-	309, 1, 279, 310, 1, 279, 1, 278,
-	1, 254, 255, 311, 311, 254, 254, 254,
-	254, 1, 254, 255, 312, 312, 254, 254,
-	254, 254, 1, 313, 314, 315, 313, 255,
-	313, 313, 313, 318, 319, 313, 318, 313,
-	313, 315, 316, 317, 317, 1, 313, 314,
-	320, 313, 321, 313, 313, 313, 313, 313,
-	1, 322, 322, 322, 1, 313, 313, 313,
-	1, 320, 323, 320, 321, 320, 320, 320,
-	320, 320, 320, 1, 324, 324, 324, 1,
-	320, 320, 320, 1, 319, 325, 326, 326,
-	1, 327, 328, 329, 330, 330, 1, 327,
-	330, 330, 330, 1, 327, 331, 330, 330,
-	330, 1, 330, 326, 326, 1, 259, 332,
-	333, 334, 335, 336, 326, 326, 326, 1,
-	332, 326, 326, 326, 1, 259, 334, 335,
-	336, 330, 326, 326, 1, 337, 1, 259,
-	335, 336, 338, 1, 259, 335, 336, 339,
-	1, 259, 335, 336, 340, 1, 259, 335,
-	336, 341, 1, 259, 335, 336, 1, 342,
-	343, 344, 345, 346, 342, 342, 344, 345,
-	346, 342, 342, 342, 342, 342, 1, 342,
-	259, 343, 335, 347, 336, 342, 342, 342,
-	342, 342, 342, 342, 1, 348, 348, 348,
-	1, 342, 342, 342, 1, 349, 350, 349,
-	349, 349, 349, 349, 349, 349, 1, 349,
-	259, 350, 335, 336, 349, 349, 349, 349,
-	349, 349, 349, 1, 351, 351, 351, 1,
-	349, 349, 349, 1, 352, 352, 353, 352,
-	352, 352, 352, 352, 352, 352, 352, 1,
-	352, 352, 353, 354, 352, 352, 352, 352,
-	352, 352, 352, 352, 1, 355, 355, 355,
-	1, 352, 352, 352, 1, 354, 259, 356,
-	336, 354, 354, 354, 354, 354, 354, 354,
-	354, 1, 357, 357, 357, 1, 354, 354,
-	354, 1, 342, 259, 343, 335, 347, 336,
-	358, 342, 342, 358, 342, 342, 342, 342,
-	342, 1, 342, 259, 343, 335, 347, 336,
-	359, 342, 342, 359, 342, 342, 342, 342,
-	342, 1, 342, 259, 343, 335, 347, 336,
-	360, 342, 342, 360, 342, 342, 342, 342,
-	342, 1, 342, 259, 343, 335, 347, 336,
-	361, 342, 342, 361, 342, 342, 342, 342,
-	342, 1, 342, 259, 343, 335, 347, 336,
-	362, 342, 342, 362, 342, 342, 342, 342,
-	342, 1, 342, 259, 343, 335, 363, 336,
-	342, 342, 342, 342, 342, 342, 342, 1,
-	364, 365, 364, 349, 349, 349, 349, 366,
-	364, 349, 364, 364, 364, 364, 1, 364,
-	259, 365, 364, 349, 349, 335, 336, 349,
-	349, 366, 364, 349, 364, 364, 364, 364,
-	1, 366, 259, 366, 366, 335, 336, 366,
-	366, 366, 367, 367, 366, 366, 367, 366,
-	1, 366, 259, 366, 366, 335, 336, 366,
-	366, 366, 366, 366, 366, 1, 366, 259,
-	366, 366, 335, 336, 366, 366, 366, 364,
-	364, 366, 366, 364, 366, 1, 342, 259,
-	343, 335, 347, 336, 368, 342, 342, 368,
-	342, 342, 342, 342, 342, 1, 342, 259,
-	343, 335, 347, 336, 369, 342, 342, 369,
-	342, 342, 342, 342, 342, 1, 342, 259,
-	343, 335, 347, 336, 370, 342, 342, 370,
-	342, 342, 342, 342, 342, 1, 342, 259,
-	343, 335, 347, 336, 371, 342, 342, 371,
-	342, 342, 342, 342, 342, 1, 342, 259,
-	343, 335, 347, 336, 372, 342, 342, 372,
-	342, 342, 342, 342, 342, 1, 342, 259,
-	343, 335, 347, 336, 373, 342, 342, 373,
-	342, 342, 342, 342, 342, 1, 342, 259,
-	343, 335, 347, 336, 374, 342, 342, 374,
-	342, 342, 342, 342, 342, 1, 342, 259,
-	343, 335, 347, 336, 362, 342, 342, 362,
-	342, 342, 342, 342, 342, 1, 342, 259,
-	343, 335, 347, 336, 375, 342, 342, 375,
-	342, 342, 342, 342, 342, 1, 342, 259,
-	343, 335, 347, 336, 376, 342, 342, 376,
-	342, 342, 342, 342, 342, 1, 342, 259,
-	343, 335, 347, 336, 362, 342, 342, 362,
-	342, 342, 342, 342, 342, 1, 377, 326,
-	326, 1, 327, 378, 379, 330, 330, 1,
-	380, 326, 326, 1, 327, 381, 382, 330,
-	330, 1, 383, 326, 326, 1, 259, 327,
-	331, 334, 335, 336, 384, 330, 330, 1,
-	259, 327, 331, 334, 335, 336, 385, 330,
-	330, 1, 259, 327, 331, 334, 335, 336,
-	330, 330, 330, 1, 327, 381, 386, 330,
-	330, 1, 327, 381, 330, 330, 330, 1,
-	327, 378, 387, 330, 330, 1, 327, 378,
-	330, 330, 330, 1, 327, 328, 388, 330,
-	330, 1, 327, 328, 330, 330, 330, 1,
-	390, 389, 389, 389, 1, 392, 393, 391,
-	391, 391, 1, 392, 393, 394, 394, 394,
-	1, 392, 393, 395, 395, 395, 1, 392,
-	393, 1, 397, 396, 389, 389, 1, 398,
-	392, 393, 399, 391, 391, 1, 400, 1,
-	401, 402, 1, 403, 1, 404, 405, 1,
-	406, 1, 393, 407, 1, 393, 408, 1,
-	393, 1, 259, 334, 335, 336, 1, 404,
-	409, 1, 404, 1, 401, 410, 1, 401,
-	1, 398, 392, 393, 411, 394, 394, 1,
-	398, 392, 393, 395, 395, 395, 1, 413,
-	393, 412, 412, 412, 1, 415, 393, 414,
-	414, 414, 1, 415, 393, 416, 416, 416,
-	1, 415, 393, 417, 417, 417, 1, 415,
-	393, 1, 418, 412, 412, 1, 398, 415,
-	393, 419, 414, 414, 1, 398, 415, 393,
-	420, 416, 416, 1, 398, 415, 393, 417,
-	417, 417, 1, 421, 1, 398, 422, 1,
-	398, 423, 1, 398, 1, 397, 1, 313,
-	314, 315, 313, 424, 313, 313, 313, 321,
-	313, 313, 313, 315, 315, 315, 1, 425,
-	426, 258, 425, 256, 427, 256, 425, 425,
-	425, 256, 425, 425, 1, 425, 259, 426,
-	256, 425, 256, 427, 425, 425, 425, 256,
-	425, 425, 1, 428, 428, 428, 1, 425,
-	425, 425, 1, 256, 259, 257, 256, 319,
-	256, 256, 256, 429, 256, 256, 430, 430,
-	1, 256, 259, 257, 431, 432, 256, 256,
-	256, 256, 433, 256, 256, 434, 434, 1,
-	256, 259, 257, 431, 256, 256, 256, 256,
-	434, 256, 256, 434, 434, 1, 256, 259,
-	257, 431, 435, 256, 256, 256, 256, 434,
-	256, 256, 434, 434, 1, 256, 259, 257,
-	256, 256, 256, 256, 434, 256, 256, 430,
-	430, 1, 256, 259, 257, 436, 437, 438,
-	439, 256, 440, 256, 256, 256, 256, 430,
-	430, 430, 1, 256, 259, 257, 436, 256,
-	256, 256, 256, 430, 256, 256, 430, 430,
-	1, 256, 259, 257, 438, 439, 256, 440,
-	256, 256, 256, 256, 434, 430, 430, 1,
-	256, 259, 257, 256, 256, 256, 256, 441,
-	256, 256, 256, 1, 256, 259, 257, 256,
-	439, 256, 440, 256, 256, 256, 442, 256,
+	27, 31, 634, 31, 634, 1, 32, 1,
+	33, 33, 1, 33, 33, 31, 634, 31,
+	634, 1, 34, 34, 1, 35, 1, 36,
+	37, 36, 7, 1, 38, 1, 39, 39,
+	1, 39, 39, 7, 1, 40, 40, 1,
+	41, 41, 1, 42, 42, 1, 43, 43,
+	1, 44, 44, 1, 45, 45, 1, 46,
+	46, 1, 47, 47, 1, 48, 48, 1,
+	48, 49, 48, 50, 1, 51, 1, 52,
+	52, 1, 52, 52, 50, 1, 53, 54,
+	53, 55, 1, 56, 1, 57, 57, 1,
+	58, 59, 58, 55, 1, 60, 1, 61,
+	61, 1, 61, 61, 62, 1, 62, 63,
+	64, 65, 62, 66, 67, 68, 69, 70,
+	1, 71, 1, 62, 62, 1, 62, 62,
+	62, 1, 62, 1, 66, 1, 67, 1,
+	68, 1, 69, 1, 72, 73, 72, 73,
+	1, 72, 74, 72, 75, 1, 76, 1,
+	77, 77, 1, 77, 77, 75, 1, 75,
+	78, 75, 79, 79, 1, 80, 1, 81,
+	81, 1, 81, 81, 79, 79, 1, 82,
+	82, 1, 83, 83, 1, 84, 84, 1,
+	85, 85, 1, 86, 86, 1, 87, 87,
+	1, 88, 88, 1, 89, 89, 1, 90,
+	90, 1, 91, 91, 1, 91, 92, 91,
+	93, 1, 94, 1, 95, 95, 1, 95,
+	95, 93, 1, 96, 97, 96, 98, 1,
+	99, 1, 100, 100, 1, 101, 102, 101,
+	98, 1, 103, 1, 104, 104, 1, 104,
+	104, 105, 1, 105, 106, 107, 108, 105,
+	109, 110, 111, 112, 113, 1, 114, 1,
+	105, 105, 1, 105, 105, 105, 1, 105,
+	1, 109, 1, 110, 1, 111, 1, 112,
+	1, 115, 115, 1, 116, 116, 1, 117,
+	117, 1, 118, 118, 1, 119, 119, 1,
+	119, 120, 119, 121, 1, 122, 1, 123,
+	123, 1, 123, 123, 121, 1, 124, 125,
+	124, 126, 1, 127, 1, 128, 128, 1,
+	129, 130, 129, 126, 1, 131, 1, 132,
+	132, 1, 132, 132, 133, 1, 133, 134,
+	135, 136, 133, 137, 138, 139, 140, 141,
+	1, 142, 1, 133, 133, 1, 133, 133,
+	133, 1, 133, 1, 137, 1, 138, 1,
+	139, 1, 140, 1, 143, 143, 1, 144,
+	144, 1, 144, 145, 144, 146, 1, 147,
+	1, 148, 148, 1, 148, 148, 146, 1,
+	146, 149, 146, 150, 150, 1, 151, 1,
+	152, 152, 1, 152, 152, 150, 150, 1,
+	153, 153, 1, 154, 154, 1, 155, 155,
+	1, 156, 156, 1, 157, 157, 1, 158,
+	158, 1, 159, 159, 1, 160, 161, 160,
+	161, 1, 162, 162, 1, 163, 163, 1,
+	163, 164, 163, 165, 1, 166, 1, 167,
+	167, 1, 167, 167, 165, 1, 168, 169,
+	168, 170, 1, 171, 1, 172, 172, 1,
+	173, 174, 173, 170, 1, 175, 1, 176,
+	176, 1, 176, 176, 177, 1, 177, 178,
+	179, 180, 177, 181, 182, 183, 184, 185,
+	1, 186, 1, 177, 177, 1, 177, 177,
+	177, 1, 177, 1, 181, 1, 182, 1,
+	183, 1, 184, 1, 187, 187, 1, 188,
+	188, 1, 189, 189, 1, 190, 190, 1,
+	191, 191, 1, 191, 192, 191, 193, 1,
+	194, 1, 195, 195, 1, 195, 195, 193,
+	1, 193, 196, 193, 197, 1, 198, 1,
+	199, 199, 1, 199, 200, 199, 197, 1,
+	201, 1, 202, 202, 1, 202, 202, 197,
+	1, 203, 203, 1, 204, 204, 1, 205,
+	205, 1, 206, 206, 1, 207, 207, 1,
+	208, 208, 1, 209, 209, 1, 210, 210,
+	1, 211, 211, 1, 212, 212, 1, 213,
+	213, 1, 214, 214, 1, 215, 215, 1,
+	216, 216, 1, 217, 217, 1, 218, 218,
+	1, 219, 219, 1, 220, 220, 1, 221,
+	221, 1, 222, 222, 1, 223, 223, 1,
+	224, 224, 1, 225, 225, 1, 226, 226,
+	1, 227, 227, 1, 228, 228, 1, 229,
+	229, 1, 230, 230, 1, 231, 231, 1,
+	232, 232, 1, 233, 233, 1, 234, 234,
+	1, 235, 635, 635, 1, 236, 1, 237,
+	237, 1, 238, 239, 238, 239, 1, 240,
+	240, 1, 240, 241, 240, 242, 1, 243,
+	1, 244, 244, 1, 244, 244, 242, 1,
+	242, 245, 242, 246, 1, 247, 1, 248,
+	248, 1, 248, 249, 248, 246, 1, 250,
+	1, 251, 251, 1, 251, 251, 246, 1,
+	253, 253, 252, 252, 1, 254, 255, 254,
+	254, 254, 254, 1, 256, 257, 258, 256,
+	256, 256, 256, 256, 256, 256, 1, 256,
+	259, 257, 256, 256, 256, 256, 256, 256,
+	1, 260, 260, 260, 1, 256, 256, 256,
+	1, 256, 259, 257, 261, 256, 256, 256,
+	256, 256, 256, 1, 262, 259, 263, 256,
+	262, 256, 264, 262, 262, 262, 262, 262,
+	1, 262, 259, 263, 265, 262, 266, 262,
+	262, 262, 262, 262, 1, 267, 267, 267,
+	1, 262, 262, 262, 1, 265, 259, 268,
+	256, 265, 256, 266, 265, 265, 265, 256,
+	265, 265, 1, 269, 269, 269, 1, 265,
+	265, 265, 1, 256, 259, 257, 256, 264,
+	256, 256, 256, 256, 256, 1, 271, 270,
+	270, 270, 1, 273, 274, 272, 272, 272,
+	1, 273, 274, 275, 275, 275, 1, 273,
+	274, 276, 276, 276, 1, 273, 274, 1,
+	278, 277, 270, 270, 1, 279, 273, 274,
+	280, 272, 272, 1, 281, 1, 282, 283,
+	1, 284, 1, 285, 286, 1, 287, 1,
+	274, 288, 1, 274, 289, 1, 274, 1,
+	259, 256, 290, 256, 1, 291, 1, 259,
+	256, 256, 292, 1, 259, 256, 256, 293,
+	1, 259, 256, 256, 294, 1, 259, 256,
+	256, 295, 1, 259, 256, 256, 1, 285,
+	296, 1, 285, 1, 282, 297, 1, 282,
+	1, 279, 273, 274, 298, 275, 275, 1,
+	279, 273, 274, 276, 276, 276, 1, 300,
+	274, 299, 299, 299, 1, 302, 274, 301,
+	301, 301, 1, 302, 274, 303, 303, 303,
+	1, 302, 274, 304, 304, 304, 1, 302,
+	274, 1, 305, 299, 299, 1, 279, 302,
+	274, 306, 301, 301, 1, 279, 302, 274,
+	307, 303, 303, 1, 279, 302, 274, 304,
+	304, 304, 1, 308, 1, 279, 309, 1,
+	279, 310, 1, 279, 1, 278, 1, 254,
+	255, 311, 311, 254, 254, 254, 254, 1,
+	254, 255, 312, 312, 254, 254, 254, 254,
+	1, 313, 314, 315, 313, 255, 313, 313,
+	313, 318, 319, 313, 318, 313, 313, 315,
+	316, 317, 317, 1, 313, 314, 320, 313,
+	321, 313, 313, 313, 313, 313, 1, 322,
+	322, 322, 1, 313, 313, 313, 1, 320,
+	323, 320, 321, 320, 320, 320, 320, 320,
+	320, 1, 324, 324, 324, 1, 320, 320,
+	320, 1, 319, 325, 326, 326, 1, 327,
+	328, 329, 330, 330, 1, 327, 330, 330,
+	330, 1, 327, 331, 330, 330, 330, 1,
+	330, 326, 326, 1, 259, 332, 333, 334,
+	335, 336, 326, 326, 326, 1, 332, 326,
+	326, 326, 1, 259, 334, 335, 336, 330,
+	326, 326, 1, 337, 1, 259, 335, 336,
+	338, 1, 259, 335, 336, 339, 1, 259,
+	335, 336, 340, 1, 259, 335, 336, 341,
+	1, 259, 335, 336, 1, 342, 343, 344,
+	345, 346, 342, 342, 344, 345, 346, 342,
+	342, 342, 342, 342, 1, 342, 259, 343,
+	335, 347, 336, 342, 342, 342, 342, 342,
+	342, 342, 1, 348, 348, 348, 1, 342,
+	342, 342, 1, 349, 350, 349, 349, 349,
+	349, 349, 349, 349, 1, 349, 259, 350,
+	335, 336, 349, 349, 349, 349, 349, 349,
+	349, 1, 351, 351, 351, 1, 349, 349,
+	349, 1, 352, 352, 353, 352, 352, 352,
+	352, 352, 352, 352, 352, 1, 352, 352,
+	353, 354, 352, 352, 352, 352, 352, 352,
+	352, 352, 1, 355, 355, 355, 1, 352,
+	352, 352, 1, 354, 259, 356, 336, 354,
+	354, 354, 354, 354, 354, 354, 354, 1,
+	357, 357, 357, 1, 354, 354, 354, 1,
+	342, 259, 343, 335, 347, 336, 358, 342,
+	342, 358, 342, 342, 342, 342, 342, 1,
+	342, 259, 343, 335, 347, 336, 359, 342,
+	342, 359, 342, 342, 342, 342, 342, 1,
+	342, 259, 343, 335, 347, 336, 360, 342,
+	342, 360, 342, 342, 342, 342, 342, 1,
+	342, 259, 343, 335, 347, 336, 361, 342,
+	342, 361, 342, 342, 342, 342, 342, 1,
+	342, 259, 343, 335, 347, 336, 362, 342,
+	342, 362, 342, 342, 342, 342, 342, 1,
+	342, 259, 343, 335, 363, 336, 342, 342,
+	342, 342, 342, 342, 342, 1, 364, 365,
+	364, 349, 349, 349, 349, 366, 364, 349,
+	364, 364, 364, 364, 1, 364, 259, 365,
+	364, 349, 349, 335, 336, 349, 349, 366,
+	364, 349, 364, 364, 364, 364, 1, 366,
+	259, 366, 366, 335, 336, 366, 366, 366,
+	367, 367, 366, 366, 367, 366, 1, 366,
+	259, 366, 366, 335, 336, 366, 366, 366,
+	366, 366, 366, 1, 366, 259, 366, 366,
+	335, 336, 366, 366, 366, 364, 364, 366,
+	366, 364, 366, 1, 342, 259, 343, 335,
+	347, 336, 368, 342, 342, 368, 342, 342,
+	342, 342, 342, 1, 342, 259, 343, 335,
+	347, 336, 369, 342, 342, 369, 342, 342,
+	342, 342, 342, 1, 342, 259, 343, 335,
+	347, 336, 370, 342, 342, 370, 342, 342,
+	342, 342, 342, 1, 342, 259, 343, 335,
+	347, 336, 371, 342, 342, 371, 342, 342,
+	342, 342, 342, 1, 342, 259, 343, 335,
+	347, 336, 372, 342, 342, 372, 342, 342,
+	342, 342, 342, 1, 342, 259, 343, 335,
+	347, 336, 373, 342, 342, 373, 342, 342,
+	342, 342, 342, 1, 342, 259, 343, 335,
+	347, 336, 374, 342, 342, 374, 342, 342,
+	342, 342, 342, 1, 342, 259, 343, 335,
+	347, 336, 362, 342, 342, 362, 342, 342,
+	342, 342, 342, 1, 342, 259, 343, 335,
+	347, 336, 375, 342, 342, 375, 342, 342,
+	342, 342, 342, 1, 342, 259, 343, 335,
+	347, 336, 376, 342, 342, 376, 342, 342,
+	342, 342, 342, 1, 342, 259, 343, 335,
+	347, 336, 362, 342, 342, 362, 342, 342,
+	342, 342, 342, 1, 377, 326, 326, 1,
+	327, 378, 379, 330, 330, 1, 380, 326,
+	326, 1, 327, 381, 382, 330, 330, 1,
+	383, 326, 326, 1, 259, 327, 331, 334,
+	335, 336, 384, 330, 330, 1, 259, 327,
+	331, 334, 335, 336, 385, 330, 330, 1,
+	259, 327, 331, 334, 335, 336, 330, 330,
+	330, 1, 327, 381, 386, 330, 330, 1,
+	327, 381, 330, 330, 330, 1, 327, 378,
+	387, 330, 330, 1, 327, 378, 330, 330,
+	330, 1, 327, 328, 388, 330, 330, 1,
+	327, 328, 330, 330, 330, 1, 390, 389,
+	389, 389, 1, 392, 393, 391, 391, 391,
+	1, 392, 393, 394, 394, 394, 1, 392,
+	393, 395, 395, 395, 1, 392, 393, 1,
+	397, 396, 389, 389, 1, 398, 392, 393,
+	399, 391, 391, 1, 400, 1, 401, 402,
+	1, 403, 1, 404, 405, 1, 406, 1,
+	393, 407, 1, 393, 408, 1, 393, 1,
+	259, 334, 335, 336, 1, 404, 409, 1,
+	404, 1, 401, 410, 1, 401, 1, 398,
+	392, 393, 411, 394, 394, 1, 398, 392,
+	393, 395, 395, 395, 1, 413, 393, 412,
+	412, 412, 1, 415, 393, 414, 414, 414,
+	1, 415, 393, 416, 416, 416, 1, 415,
+	393, 417, 417, 417, 1, 415, 393, 1,
+	418, 412, 412, 1, 398, 415, 393, 419,
+	414, 414, 1, 398, 415, 393, 420, 416,
+	416, 1, 398, 415, 393, 417, 417, 417,
+	1, 421, 1, 398, 422, 1, 398, 423,
+	1, 398, 1, 397, 1, 313, 314, 315,
+	313, 424, 313, 313, 313, 321, 313, 313,
+	313, 315, 315, 315, 1, 425, 426, 258,
+	425, 256, 427, 256, 425, 425, 425, 256,
+	425, 425, 1, 425, 259, 426, 256, 425,
+	256, 427, 425, 425, 425, 256, 425, 425,
+	1, 428, 428, 428, 1, 425, 425, 425,
+	1, 256, 259, 257, 256, 319, 256, 256,
+	256, 429, 256, 256, 430, 430, 1, 256,
+	259, 257, 431, 432, 256, 256, 256, 256,
+	433, 256, 256, 434, 434, 1, 256, 259,
+	257, 431, 256, 256, 256, 256, 434, 256,
+	256, 434, 434, 1, 256, 259, 257, 431,
+	435, 256, 256, 256, 256, 434, 256, 256,
+	434, 434, 1, 256, 259, 257, 256, 256,
+	256, 256, 434, 256, 256, 430, 430, 1,
+	256, 259, 257, 436, 437, 438, 439, 256,
+	440, 256, 256, 256, 256, 430, 430, 430,
+	1, 256, 259, 257, 436, 256, 256, 256,
+	256, 430, 256, 256, 430, 430, 1, 256,
+	259, 257, 438, 439, 256, 440, 256, 256,
+	256, 256, 434, 430, 430, 1, 256, 259,
+	257, 256, 256, 256, 256, 441, 256, 256,
 	256, 1, 256, 259, 257, 256, 439, 256,
-	440, 256, 256, 256, 443, 256, 256, 1,
+	440, 256, 256, 256, 442, 256, 256, 1,
 	256, 259, 257, 256, 439, 256, 440, 256,
-	256, 256, 444, 256, 256, 1, 256, 259,
+	256, 256, 443, 256, 256, 1, 256, 259,
 	257, 256, 439, 256, 440, 256, 256, 256,
-	445, 256, 256, 1, 256, 259, 257, 439,
-	256, 440, 256, 256, 256, 256, 256, 1,
-	446, 259, 447, 256, 256, 256, 448, 449,
-	450, 342, 342, 446, 448, 449, 450, 446,
-	446, 256, 446, 446, 1, 446, 259, 447,
-	256, 439, 451, 440, 256, 342, 342, 446,
-	446, 446, 446, 446, 1, 452, 452, 452,
-	1, 446, 446, 446, 1, 453, 259, 454,
-	256, 256, 256, 349, 349, 453, 453, 453,
-	256, 453, 453, 1, 453, 259, 454, 256,
-	439, 256, 440, 256, 349, 349, 453, 453,
-	453, 453, 453, 1, 455, 455, 455, 1,
-	453, 453, 453, 1, 456, 259, 457, 256,
-	256, 256, 256, 256, 352, 352, 456, 456,
-	456, 456, 456, 1, 456, 259, 457, 256,
-	256, 256, 458, 256, 352, 352, 456, 456,
-	456, 456, 456, 1, 459, 459, 459, 1,
-	456, 456, 456, 1, 458, 259, 460, 440,
-	256, 256, 256, 256, 354, 354, 458, 458,
-	458, 458, 458, 1, 461, 461, 461, 1,
-	458, 458, 458, 1, 446, 259, 447, 256,
-	439, 451, 440, 256, 462, 342, 342, 446,
-	462, 446, 446, 446, 446, 1, 446, 259,
-	447, 256, 439, 451, 440, 256, 463, 342,
-	342, 446, 463, 446, 446, 446, 446, 1,
+	444, 256, 256, 1, 256, 259, 257, 256,
+	439, 256, 440, 256, 256, 256, 445, 256,
+	256, 1, 256, 259, 257, 439, 256, 440,
+	256, 256, 256, 256, 256, 1, 446, 259,
+	447, 256, 256, 256, 448, 449, 450, 342,
+	342, 446, 448, 449, 450, 446, 446, 256,
+	446, 446, 1, 446, 259, 447, 256, 439,
+	451, 440, 256, 342, 342, 446, 446, 446,
+	446, 446, 1, 452, 452, 452, 1, 446,
+	446, 446, 1, 453, 259, 454, 256, 256,
+	256, 349, 349, 453, 453, 453, 256, 453,
+	453, 1, 453, 259, 454, 256, 439, 256,
+	440, 256, 349, 349, 453, 453, 453, 453,
+	453, 1, 455, 455, 455, 1, 453, 453,
+	453, 1, 456, 259, 457, 256, 256, 256,
+	256, 256, 352, 352, 456, 456, 456, 456,
+	456, 1, 456, 259, 457, 256, 256, 256,
+	458, 256, 352, 352, 456, 456, 456, 456,
+	456, 1, 459, 459, 459, 1, 456, 456,
+	456, 1, 458, 259, 460, 440, 256, 256,
+	256, 256, 354, 354, 458, 458, 458, 458,
+	458, 1, 461, 461, 461, 1, 458, 458,
+	458, 1, 446, 259, 447, 256, 439, 451,
+	440, 256, 462, 342, 342, 446, 462, 446,
+	446, 446, 446, 1, 446, 259, 447, 256,
+	439, 451, 440, 256, 463, 342, 342, 446,
+	463, 446, 446, 446, 446, 1, 446, 259,
+	447, 256, 439, 451, 440, 256, 464, 342,
+	342, 446, 464, 446, 446, 446, 446, 1,
 	446, 259, 447, 256, 439, 451, 440, 256,
-	464, 342, 342, 446, 464, 446, 446, 446,
+	465, 342, 342, 446, 465, 446, 446, 446,
 	446, 1, 446, 259, 447, 256, 439, 451,
-	440, 256, 465, 342, 342, 446, 465, 446,
+	440, 256, 466, 342, 342, 446, 466, 446,
 	446, 446, 446, 1, 446, 259, 447, 256,
-	439, 451, 440, 256, 466, 342, 342, 446,
-	466, 446, 446, 446, 446, 1, 446, 259,
-	447, 256, 439, 467, 440, 256, 342, 342,
-	446, 446, 446, 446, 446, 1, 468, 259,
-	469, 468, 256, 453, 453, 256, 256, 349,
-	349, 366, 468, 453, 468, 256, 468, 468,
-	1, 468, 259, 469, 468, 256, 453, 453,
-	439, 256, 440, 256, 349, 349, 366, 468,
-	453, 468, 468, 468, 1, 366, 259, 366,
-	366, 335, 336, 366, 366, 366, 470, 470,
-	366, 366, 470, 366, 1, 366, 259, 366,
-	366, 335, 336, 366, 366, 366, 468, 468,
-	366, 366, 468, 366, 1, 446, 259, 447,
-	256, 439, 451, 440, 256, 471, 342, 342,
-	446, 471, 446, 446, 446, 446, 1, 446,
-	259, 447, 256, 439, 451, 440, 256, 472,
-	342, 342, 446, 472, 446, 446, 446, 446,
-	1, 446, 259, 447, 256, 439, 451, 440,
-	256, 473, 342, 342, 446, 473, 446, 446,
-	446, 446, 1, 446, 259, 447, 256, 439,
-	451, 440, 256, 474, 342, 342, 446, 474,
+	439, 467, 440, 256, 342, 342, 446, 446,
+	446, 446, 446, 1, 468, 259, 469, 468,
+	256, 453, 453, 256, 256, 349, 349, 366,
+	468, 453, 468, 256, 468, 468, 1, 468,
+	259, 469, 468, 256, 453, 453, 439, 256,
+	440, 256, 349, 349, 366, 468, 453, 468,
+	468, 468, 1, 366, 259, 366, 366, 335,
+	336, 366, 366, 366, 470, 470, 366, 366,
+	470, 366, 1, 366, 259, 366, 366, 335,
+	336, 366, 366, 366, 468, 468, 366, 366,
+	468, 366, 1, 446, 259, 447, 256, 439,
+	451, 440, 256, 471, 342, 342, 446, 471,
 	446, 446, 446, 446, 1, 446, 259, 447,
-	256, 439, 451, 440, 256, 475, 342, 342,
-	446, 475, 446, 446, 446, 446, 1, 446,
-	259, 447, 256, 439, 451, 440, 256, 476,
-	342, 342, 446, 476, 446, 446, 446, 446,
+	256, 439, 451, 440, 256, 472, 342, 342,
+	446, 472, 446, 446, 446, 446, 1, 446,
+	259, 447, 256, 439, 451, 440, 256, 473,
+	342, 342, 446, 473, 446, 446, 446, 446,
 	1, 446, 259, 447, 256, 439, 451, 440,
-	256, 477, 342, 342, 446, 477, 446, 446,
+	256, 474, 342, 342, 446, 474, 446, 446,
 	446, 446, 1, 446, 259, 447, 256, 439,
-	451, 440, 256, 466, 342, 342, 446, 466,
+	451, 440, 256, 475, 342, 342, 446, 475,
 	446, 446, 446, 446, 1, 446, 259, 447,
-	256, 439, 451, 440, 256, 478, 342, 342,
-	446, 478, 446, 446, 446, 446, 1, 446,
-	259, 447, 256, 439, 451, 440, 256, 479,
-	342, 342, 446, 479, 446, 446, 446, 446,
+	256, 439, 451, 440, 256, 476, 342, 342,
+	446, 476, 446, 446, 446, 446, 1, 446,
+	259, 447, 256, 439, 451, 440, 256, 477,
+	342, 342, 446, 477, 446, 446, 446, 446,
 	1, 446, 259, 447, 256, 439, 451, 440,
 	256, 466, 342, 342, 446, 466, 446, 446,
-	446, 446, 1, 256, 259, 257, 256, 256,
-	256, 256, 480, 256, 256, 430, 430, 1,
-	256, 259, 257, 431, 481, 256, 256, 256,
-	256, 482, 256, 256, 434, 434, 1, 256,
-	259, 257, 256, 256, 256, 256, 483, 256,
-	256, 430, 430, 1, 256, 259, 257, 431,
-	484, 256, 256, 256, 256, 485, 256, 256,
-	434, 434, 1, 256, 259, 257, 256, 256,
-	256, 256, 486, 256, 256, 430, 430, 1,
+	446, 446, 1, 446, 259, 447, 256, 439,
+	451, 440, 256, 478, 342, 342, 446, 478,
+	446, 446, 446, 446, 1, 446, 259, 447,
+	256, 439, 451, 440, 256, 479, 342, 342,
+	446, 479, 446, 446, 446, 446, 1, 446,
+	259, 447, 256, 439, 451, 440, 256, 466,
+	342, 342, 446, 466, 446, 446, 446, 446,
+	1, 256, 259, 257, 256, 256, 256, 256,
+	480, 256, 256, 430, 430, 1, 256, 259,
+	257, 431, 481, 256, 256, 256, 256, 482,
+	256, 256, 434, 434, 1, 256, 259, 257,
+	256, 256, 256, 256, 483, 256, 256, 430,
+	430, 1, 256, 259, 257, 431, 484, 256,
+	256, 256, 256, 485, 256, 256, 434, 434,
+	1, 256, 259, 257, 256, 256, 256, 256,
+	486, 256, 256, 430, 430, 1, 256, 259,
+	257, 431, 435, 438, 439, 256, 440, 256,
+	256, 256, 256, 487, 434, 434, 1, 256,
+	259, 257, 431, 435, 438, 439, 256, 440,
+	256, 256, 256, 256, 488, 434, 434, 1,
 	256, 259, 257, 431, 435, 438, 439, 256,
-	440, 256, 256, 256, 256, 487, 434, 434,
-	1, 256, 259, 257, 431, 435, 438, 439,
-	256, 440, 256, 256, 256, 256, 488, 434,
-	434, 1, 256, 259, 257, 431, 435, 438,
-	439, 256, 440, 256, 256, 256, 256, 434,
-	434, 434, 1, 256, 259, 257, 431, 484,
-	256, 256, 256, 256, 489, 256, 256, 434,
-	434, 1, 256, 259, 257, 431, 484, 256,
-	256, 256, 256, 434, 256, 256, 434, 434,
-	1, 256, 259, 257, 431, 481, 256, 256,
-	256, 256, 490, 256, 256, 434, 434, 1,
-	256, 259, 257, 431, 481, 256, 256, 256,
+	440, 256, 256, 256, 256, 434, 434, 434,
+	1, 256, 259, 257, 431, 484, 256, 256,
+	256, 256, 489, 256, 256, 434, 434, 1,
+	256, 259, 257, 431, 484, 256, 256, 256,
 	256, 434, 256, 256, 434, 434, 1, 256,
-	259, 257, 431, 432, 256, 256, 256, 256,
-	491, 256, 256, 434, 434, 1, 256, 259,
-	257, 431, 432, 256, 256, 256, 256, 434,
-	256, 256, 434, 434, 1, 313, 314, 315,
-	492, 493, 424, 313, 313, 313, 321, 313,
-	313, 313, 494, 495, 495, 1, 313, 314,
-	315, 492, 315, 424, 313, 313, 313, 321,
-	313, 313, 313, 495, 495, 495, 1, 313,
-	314, 315, 492, 496, 424, 313, 313, 313,
-	321, 313, 313, 313, 495, 495, 495, 1,
-	313, 314, 315, 313, 424, 313, 313, 313,
-	321, 313, 313, 313, 315, 495, 317, 317,
-	1, 313, 259, 314, 315, 497, 498, 499,
-	500, 313, 501, 321, 313, 313, 313, 317,
-	317, 317, 1, 313, 314, 315, 497, 315,
+	259, 257, 431, 481, 256, 256, 256, 256,
+	490, 256, 256, 434, 434, 1, 256, 259,
+	257, 431, 481, 256, 256, 256, 256, 434,
+	256, 256, 434, 434, 1, 256, 259, 257,
+	431, 432, 256, 256, 256, 256, 491, 256,
+	256, 434, 434, 1, 256, 259, 257, 431,
+	432, 256, 256, 256, 256, 434, 256, 256,
+	434, 434, 1, 313, 314, 315, 492, 493,
 	424, 313, 313, 313, 321, 313, 313, 313,
-	317, 317, 317, 1, 313, 259, 314, 315,
-	313, 499, 500, 313, 501, 321, 313, 313,
-	313, 315, 495, 317, 317, 1, 425, 426,
-	258, 425, 256, 427, 256, 425, 425, 425,
-	502, 256, 425, 425, 1, 425, 259, 426,
-	256, 256, 439, 425, 440, 427, 425, 425,
-	425, 503, 425, 425, 1, 425, 259, 426,
-	256, 256, 439, 425, 440, 427, 425, 425,
-	425, 504, 425, 425, 1, 425, 259, 426,
-	256, 256, 439, 425, 440, 427, 425, 425,
-	425, 505, 425, 425, 1, 425, 259, 426,
-	256, 256, 439, 425, 440, 427, 425, 425,
-	425, 506, 425, 425, 1, 425, 259, 426,
-	256, 256, 439, 425, 440, 427, 425, 425,
-	425, 425, 425, 1, 507, 508, 313, 509,
-	313, 313, 313, 321, 510, 511, 512, 342,
-	342, 507, 510, 511, 512, 507, 507, 507,
-	507, 1, 507, 259, 508, 313, 509, 500,
-	513, 501, 321, 342, 342, 507, 507, 507,
-	507, 507, 1, 514, 514, 514, 1, 507,
-	507, 507, 1, 509, 259, 515, 320, 342,
-	342, 335, 516, 336, 321, 342, 342, 509,
-	509, 509, 509, 509, 1, 517, 517, 517,
-	1, 509, 509, 509, 1, 518, 519, 320,
-	349, 349, 320, 321, 349, 349, 518, 518,
-	518, 518, 518, 1, 518, 259, 519, 320,
-	349, 349, 335, 320, 336, 321, 349, 349,
-	518, 518, 518, 518, 518, 1, 520, 520,
-	520, 1, 518, 518, 518, 1, 521, 522,
-	313, 518, 313, 313, 313, 321, 349, 349,
-	521, 521, 521, 521, 521, 1, 521, 259,
-	522, 313, 518, 500, 313, 501, 321, 349,
-	349, 521, 521, 521, 521, 521, 1, 523,
-	523, 523, 1, 521, 521, 521, 1, 524,
-	525, 313, 313, 526, 313, 313, 321, 352,
-	352, 524, 524, 524, 524, 524, 1, 524,
-	525, 313, 313, 526, 313, 527, 321, 352,
-	352, 524, 524, 524, 524, 524, 1, 528,
-	528, 528, 1, 524, 524, 524, 1, 526,
-	529, 320, 320, 352, 352, 530, 352, 321,
-	352, 352, 526, 526, 526, 526, 526, 1,
-	531, 531, 531, 1, 526, 526, 526, 1,
-	530, 259, 532, 533, 320, 354, 354, 320,
-	354, 321, 354, 354, 530, 530, 530, 530,
-	530, 1, 534, 534, 534, 1, 530, 530,
-	530, 1, 526, 529, 320, 320, 352, 352,
-	320, 352, 321, 352, 352, 526, 526, 526,
-	526, 526, 1, 527, 259, 535, 501, 313,
-	530, 313, 313, 321, 354, 354, 527, 527,
-	527, 527, 527, 1, 536, 536, 536, 1,
-	527, 527, 527, 1, 507, 259, 508, 313,
-	509, 500, 513, 501, 321, 537, 342, 342,
-	507, 537, 507, 507, 507, 507, 1, 507,
-	259, 508, 313, 509, 500, 513, 501, 321,
-	538, 342, 342, 507, 538, 507, 507, 507,
-	507, 1, 507, 259, 508, 313, 509, 500,
-	513, 501, 321, 539, 342, 342, 507, 539,
+	494, 495, 495, 1, 313, 314, 315, 492,
+	315, 424, 313, 313, 313, 321, 313, 313,
+	313, 495, 495, 495, 1, 313, 314, 315,
+	492, 496, 424, 313, 313, 313, 321, 313,
+	313, 313, 495, 495, 495, 1, 313, 314,
+	315, 313, 424, 313, 313, 313, 321, 313,
+	313, 313, 315, 495, 317, 317, 1, 313,
+	259, 314, 315, 497, 498, 499, 500, 313,
+	501, 321, 313, 313, 313, 317, 317, 317,
+	1, 313, 314, 315, 497, 315, 424, 313,
+	313, 313, 321, 313, 313, 313, 317, 317,
+	317, 1, 313, 259, 314, 315, 313, 499,
+	500, 313, 501, 321, 313, 313, 313, 315,
+	495, 317, 317, 1, 425, 426, 258, 425,
+	256, 427, 256, 425, 425, 425, 502, 256,
+	425, 425, 1, 425, 259, 426, 256, 256,
+	439, 425, 440, 427, 425, 425, 425, 503,
+	425, 425, 1, 425, 259, 426, 256, 256,
+	439, 425, 440, 427, 425, 425, 425, 504,
+	425, 425, 1, 425, 259, 426, 256, 256,
+	439, 425, 440, 427, 425, 425, 425, 505,
+	425, 425, 1, 425, 259, 426, 256, 256,
+	439, 425, 440, 427, 425, 425, 425, 506,
+	425, 425, 1, 425, 259, 426, 256, 256,
+	439, 425, 440, 427, 425, 425, 425, 425,
+	425, 1, 507, 508, 313, 509, 313, 313,
+	313, 321, 510, 511, 512, 342, 342, 507,
+	510, 511, 512, 507, 507, 507, 507, 1,
+	507, 259, 508, 313, 509, 500, 513, 501,
+	321, 342, 342, 507, 507, 507, 507, 507,
+	1, 514, 514, 514, 1, 507, 507, 507,
+	1, 509, 259, 515, 320, 342, 342, 335,
+	516, 336, 321, 342, 342, 509, 509, 509,
+	509, 509, 1, 517, 517, 517, 1, 509,
+	509, 509, 1, 518, 519, 320, 349, 349,
+	320, 321, 349, 349, 518, 518, 518, 518,
+	518, 1, 518, 259, 519, 320, 349, 349,
+	335, 320, 336, 321, 349, 349, 518, 518,
+	518, 518, 518, 1, 520, 520, 520, 1,
+	518, 518, 518, 1, 521, 522, 313, 518,
+	313, 313, 313, 321, 349, 349, 521, 521,
+	521, 521, 521, 1, 521, 259, 522, 313,
+	518, 500, 313, 501, 321, 349, 349, 521,
+	521, 521, 521, 521, 1, 523, 523, 523,
+	1, 521, 521, 521, 1, 524, 525, 313,
+	313, 526, 313, 313, 321, 352, 352, 524,
+	524, 524, 524, 524, 1, 524, 525, 313,
+	313, 526, 313, 527, 321, 352, 352, 524,
+	524, 524, 524, 524, 1, 528, 528, 528,
+	1, 524, 524, 524, 1, 526, 529, 320,
+	320, 352, 352, 530, 352, 321, 352, 352,
+	526, 526, 526, 526, 526, 1, 531, 531,
+	531, 1, 526, 526, 526, 1, 530, 259,
+	532, 533, 320, 354, 354, 320, 354, 321,
+	354, 354, 530, 530, 530, 530, 530, 1,
+	534, 534, 534, 1, 530, 530, 530, 1,
+	526, 529, 320, 320, 352, 352, 320, 352,
+	321, 352, 352, 526, 526, 526, 526, 526,
+	1, 527, 259, 535, 501, 313, 530, 313,
+	313, 321, 354, 354, 527, 527, 527, 527,
+	527, 1, 536, 536, 536, 1, 527, 527,
+	527, 1, 507, 259, 508, 313, 509, 500,
+	513, 501, 321, 537, 342, 342, 507, 537,
 	507, 507, 507, 507, 1, 507, 259, 508,
-	313, 509, 500, 513, 501, 321, 540, 342,
-	342, 507, 540, 507, 507, 507, 507, 1,
+	313, 509, 500, 513, 501, 321, 538, 342,
+	342, 507, 538, 507, 507, 507, 507, 1,
 	507, 259, 508, 313, 509, 500, 513, 501,
-	321, 541, 342, 342, 507, 541, 507, 507,
+	321, 539, 342, 342, 507, 539, 507, 507,
 	507, 507, 1, 507, 259, 508, 313, 509,
-	500, 542, 501, 321, 342, 342, 507, 507,
-	507, 507, 507, 1, 543, 544, 543, 313,
-	521, 518, 313, 313, 313, 321, 349, 349,
-	366, 543, 521, 543, 543, 543, 1, 543,
-	259, 544, 543, 313, 521, 518, 500, 313,
-	501, 321, 349, 349, 366, 543, 521, 543,
-	543, 543, 1, 366, 259, 366, 366, 335,
-	336, 366, 366, 366, 545, 545, 366, 366,
-	545, 366, 1, 366, 259, 366, 366, 335,
-	336, 366, 366, 366, 543, 543, 366, 366,
-	543, 366, 1, 507, 259, 508, 313, 509,
-	500, 513, 501, 321, 546, 342, 342, 507,
-	546, 507, 507, 507, 507, 1, 507, 259,
-	508, 313, 509, 500, 513, 501, 321, 547,
-	342, 342, 507, 547, 507, 507, 507, 507,
+	500, 513, 501, 321, 540, 342, 342, 507,
+	540, 507, 507, 507, 507, 1, 507, 259,
+	508, 313, 509, 500, 513, 501, 321, 541,
+	342, 342, 507, 541, 507, 507, 507, 507,
+	1, 507, 259, 508, 313, 509, 500, 542,
+	501, 321, 342, 342, 507, 507, 507, 507,
+	507, 1, 543, 544, 543, 313, 521, 518,
+	313, 313, 313, 321, 349, 349, 366, 543,
+	521, 543, 543, 543, 1, 543, 259, 544,
+	543, 313, 521, 518, 500, 313, 501, 321,
+	349, 349, 366, 543, 521, 543, 543, 543,
+	1, 366, 259, 366, 366, 335, 336, 366,
+	366, 366, 545, 545, 366, 366, 545, 366,
+	1, 366, 259, 366, 366, 335, 336, 366,
+	366, 366, 543, 543, 366, 366, 543, 366,
 	1, 507, 259, 508, 313, 509, 500, 513,
-	501, 321, 548, 342, 342, 507, 548, 507,
+	501, 321, 546, 342, 342, 507, 546, 507,
 	507, 507, 507, 1, 507, 259, 508, 313,
-	509, 500, 513, 501, 321, 549, 342, 342,
-	507, 549, 507, 507, 507, 507, 1, 507,
+	509, 500, 513, 501, 321, 547, 342, 342,
+	507, 547, 507, 507, 507, 507, 1, 507,
 	259, 508, 313, 509, 500, 513, 501, 321,
-	550, 342, 342, 507, 550, 507, 507, 507,
+	548, 342, 342, 507, 548, 507, 507, 507,
 	507, 1, 507, 259, 508, 313, 509, 500,
-	513, 501, 321, 551, 342, 342, 507, 551,
+	513, 501, 321, 549, 342, 342, 507, 549,
 	507, 507, 507, 507, 1, 507, 259, 508,
-	313, 509, 500, 513, 501, 321, 552, 342,
-	342, 507, 552, 507, 507, 507, 507, 1,
+	313, 509, 500, 513, 501, 321, 550, 342,
+	342, 507, 550, 507, 507, 507, 507, 1,
 	507, 259, 508, 313, 509, 500, 513, 501,
-	321, 541, 342, 342, 507, 541, 507, 507,
+	321, 551, 342, 342, 507, 551, 507, 507,
 	507, 507, 1, 507, 259, 508, 313, 509,
-	500, 513, 501, 321, 553, 342, 342, 507,
-	553, 507, 507, 507, 507, 1, 507, 259,
-	508, 313, 509, 500, 513, 501, 321, 554,
-	342, 342, 507, 554, 507, 507, 507, 507,
+	500, 513, 501, 321, 552, 342, 342, 507,
+	552, 507, 507, 507, 507, 1, 507, 259,
+	508, 313, 509, 500, 513, 501, 321, 541,
+	342, 342, 507, 541, 507, 507, 507, 507,
 	1, 507, 259, 508, 313, 509, 500, 513,
-	501, 321, 541, 342, 342, 507, 541, 507,
-	507, 507, 507, 1, 313, 314, 315, 313,
+	501, 321, 553, 342, 342, 507, 553, 507,
+	507, 507, 507, 1, 507, 259, 508, 313,
+	509, 500, 513, 501, 321, 554, 342, 342,
+	507, 554, 507, 507, 507, 507, 1, 507,
+	259, 508, 313, 509, 500, 513, 501, 321,
+	541, 342, 342, 507, 541, 507, 507, 507,
+	507, 1, 313, 314, 315, 313, 424, 313,
+	313, 313, 321, 313, 313, 313, 315, 555,
+	317, 317, 1, 313, 314, 315, 492, 556,
+	424, 313, 313, 313, 321, 313, 313, 313,
+	557, 495, 495, 1, 313, 314, 315, 313,
 	424, 313, 313, 313, 321, 313, 313, 313,
-	315, 555, 317, 317, 1, 313, 314, 315,
-	492, 556, 424, 313, 313, 313, 321, 313,
-	313, 313, 557, 495, 495, 1, 313, 314,
+	315, 558, 317, 317, 1, 313, 314, 315,
+	492, 559, 424, 313, 313, 313, 321, 313,
+	313, 313, 560, 495, 495, 1, 313, 314,
 	315, 313, 424, 313, 313, 313, 321, 313,
-	313, 313, 315, 558, 317, 317, 1, 313,
-	314, 315, 492, 559, 424, 313, 313, 313,
-	321, 313, 313, 313, 560, 495, 495, 1,
-	313, 314, 315, 313, 424, 313, 313, 313,
-	321, 313, 313, 313, 315, 561, 317, 317,
+	313, 313, 315, 561, 317, 317, 1, 313,
+	259, 314, 315, 492, 496, 499, 500, 313,
+	501, 321, 313, 313, 313, 562, 495, 495,
 	1, 313, 259, 314, 315, 492, 496, 499,
-	500, 313, 501, 321, 313, 313, 313, 562,
+	500, 313, 501, 321, 313, 313, 313, 563,
 	495, 495, 1, 313, 259, 314, 315, 492,
 	496, 499, 500, 313, 501, 321, 313, 313,
-	313, 563, 495, 495, 1, 313, 259, 314,
-	315, 492, 496, 499, 500, 313, 501, 321,
+	313, 495, 495, 495, 1, 313, 314, 315,
+	492, 559, 424, 313, 313, 313, 321, 313,
+	313, 313, 564, 495, 495, 1, 313, 314,
+	315, 492, 559, 424, 313, 313, 313, 321,
 	313, 313, 313, 495, 495, 495, 1, 313,
-	314, 315, 492, 559, 424, 313, 313, 313,
-	321, 313, 313, 313, 564, 495, 495, 1,
-	313, 314, 315, 492, 559, 424, 313, 313,
+	314, 315, 492, 556, 424, 313, 313, 313,
+	321, 313, 313, 313, 565, 495, 495, 1,
+	313, 314, 315, 492, 556, 424, 313, 313,
 	313, 321, 313, 313, 313, 495, 495, 495,
-	1, 313, 314, 315, 492, 556, 424, 313,
-	313, 313, 321, 313, 313, 313, 565, 495,
-	495, 1, 313, 314, 315, 492, 556, 424,
+	1, 313, 314, 315, 492, 493, 424, 313,
+	313, 313, 321, 313, 313, 313, 566, 495,
+	495, 1, 313, 314, 315, 492, 493, 424,
 	313, 313, 313, 321, 313, 313, 313, 495,
-	495, 495, 1, 313, 314, 315, 492, 493,
-	424, 313, 313, 313, 321, 313, 313, 313,
-	566, 495, 495, 1, 313, 314, 315, 492,
-	493, 424, 313, 313, 313, 321, 313, 313,
-	313, 495, 495, 495, 1, 313, 259, 314,
-	315, 497, 498, 499, 500, 313, 501, 321,
-	319, 313, 313, 313, 567, 317, 317, 1,
-	313, 259, 314, 315, 497, 568, 499, 500,
-	313, 501, 321, 313, 313, 313, 569, 317,
-	317, 1, 313, 259, 314, 315, 313, 499,
-	500, 313, 501, 321, 313, 313, 313, 315,
-	555, 317, 317, 1, 313, 259, 314, 315,
-	497, 568, 499, 500, 313, 501, 321, 313,
-	313, 313, 570, 317, 317, 1, 313, 259,
+	495, 495, 1, 313, 259, 314, 315, 497,
+	498, 499, 500, 313, 501, 321, 319, 313,
+	313, 313, 567, 317, 317, 1, 313, 259,
 	314, 315, 497, 568, 499, 500, 313, 501,
-	321, 313, 313, 313, 317, 317, 317, 1,
-	571, 571, 1, 572, 572, 1, 573, 573,
-	1, 574, 574, 1, 575, 575, 1, 576,
-	576, 1, 576, 577, 576, 578, 1, 579,
-	1, 580, 580, 1, 580, 580, 578, 1,
-	581, 582, 581, 583, 1, 584, 1, 585,
-	585, 1, 586, 587, 586, 583, 1, 588,
-	1, 589, 589, 1, 589, 589, 590, 1,
-	590, 591, 592, 593, 590, 594, 595, 596,
-	597, 598, 1, 599, 1, 590, 590, 1,
-	590, 590, 590, 1, 590, 1, 594, 1,
-	595, 1, 596, 1, 597, 1, 600, 601,
-	600, 602, 603, 1, 604, 605, 604, 606,
-	1, 607, 608, 607, 609, 1, 610, 611,
-	610, 612, 1, 613, 614, 613, 615, 1,
-	616, 617, 616, 618, 1, 619, 620, 619,
-	621, 622, 1, 623, 624, 623, 625, 1,
-	626, 627, 626, 628, 1, 629, 630, 629,
-	7, 1, 237, 37, 237, 7, 1, 631,
-	632, 631, 633, 1,
+	321, 313, 313, 313, 569, 317, 317, 1,
+	313, 259, 314, 315, 313, 499, 500, 313,
+	501, 321, 313, 313, 313, 315, 555, 317,
+	317, 1, 313, 259, 314, 315, 497, 568,
+	499, 500, 313, 501, 321, 313, 313, 313,
+	570, 317, 317, 1, 313, 259, 314, 315,
+	497, 568, 499, 500, 313, 501, 321, 313,
+	313, 313, 317, 317, 317, 1, 571, 571,
+	1, 572, 572, 1, 573, 573, 1, 574,
+	574, 1, 575, 575, 1, 576, 576, 1,
+	576, 577, 576, 578, 1, 579, 1, 580,
+	580, 1, 580, 580, 578, 1, 581, 582,
+	581, 583, 1, 584, 1, 585, 585, 1,
+	586, 587, 586, 583, 1, 588, 1, 589,
+	589, 1, 589, 589, 590, 1, 590, 591,
+	592, 593, 590, 594, 595, 596, 597, 598,
+	1, 599, 1, 590, 590, 1, 590, 590,
+	590, 1, 590, 1, 594, 1, 595, 1,
+	596, 1, 597, 1, 636, 636, 637, 637,
+	638, 639, 640, 641, 642, 643, 644, 645,
+	646, 647, 648, 649, 649, 650, 650, 651,
+	651, 652, 652, 653, 653, 654, 654, 655,
+	655, 656, 656, 657, 657, 658, 658, 659,
+	659, 660, 660, 661, 661, 662, 662, 663,
+	663, 664, 664, 665, 665, 666, 666, 667,
+	667, 668, 668, 669, 669, 670, 670, 671,
+	671, 672, 672, 673, 673, 674, 674, 675,
+	675, 676, 676, 677, 677, 678, 678, 679,
+	679, 680, 680, 681, 681, 682, 682, 683,
+	683, 684, 684, 685, 685, 686, 686, 687,
+	687, 235, 600, 601, 600, 602, 603, 1,
+	604, 605, 604, 606, 1, 607, 608, 607,
+	609, 1, 610, 611, 610, 612, 1, 613,
+	614, 613, 615, 1, 616, 617, 616, 618,
+	1, 619, 620, 619, 621, 622, 1, 623,
+	624, 623, 625, 1, 626, 627, 626, 628,
+	1, 629, 630, 629, 7, 1, 237, 37,
+	237, 7, 1, 631, 632, 631, 633, 1,
+	688, 689, 688, 690, 691, 1, 692, 693,
+	692, 694, 695, 1, 696, 697, 696, 698,
+	1, 699, 700, 699, 701, 1,
 }
 
 var _credentials_trans_targs []int16 = []int16{
@@ -1481,35 +1547,35 @@ var _credentials_trans_targs []int16 = []int16{
 	9, 12, 38, 63, 102, 127, 143, 218,
 	10, 11, 13, 14, 15, 16, 17, 18,
 	19, 20, 21, 24, 22, 23, 25, 28,
-	26, 27, 29, 575, 30, 31, 32, 33,
-	35, 36, 37, 576, 39, 40, 41, 42,
+	26, 27, 29, 627, 30, 31, 32, 33,
+	35, 36, 37, 628, 39, 40, 41, 42,
 	43, 44, 47, 45, 46, 47, 48, 54,
 	49, 50, 50, 51, 52, 53, 54, 55,
-	577, 57, 58, 59, 60, 61, 62, 56,
+	629, 57, 58, 59, 60, 61, 62, 56,
 	64, 79, 65, 68, 66, 67, 69, 72,
 	70, 71, 73, 74, 75, 76, 77, 78,
-	578, 80, 81, 82, 83, 86, 84, 85,
+	630, 80, 81, 82, 83, 86, 84, 85,
 	86, 87, 93, 88, 89, 89, 90, 91,
-	92, 93, 94, 579, 96, 97, 98, 99,
+	92, 93, 94, 631, 96, 97, 98, 99,
 	100, 101, 95, 103, 104, 105, 106, 107,
 	108, 111, 109, 110, 111, 112, 118, 113,
-	114, 114, 115, 116, 117, 118, 119, 580,
+	114, 114, 115, 116, 117, 118, 119, 632,
 	121, 122, 123, 124, 125, 126, 120, 128,
 	129, 130, 133, 131, 132, 134, 137, 135,
-	136, 138, 139, 581, 141, 142, 582, 144,
+	136, 138, 139, 633, 141, 142, 634, 144,
 	145, 167, 146, 147, 148, 151, 149, 150,
 	151, 152, 158, 153, 154, 154, 155, 156,
-	157, 158, 159, 583, 161, 162, 163, 164,
+	157, 158, 159, 635, 161, 162, 163, 164,
 	165, 166, 160, 168, 169, 170, 171, 172,
 	173, 176, 174, 175, 177, 183, 178, 179,
 	180, 181, 182, 184, 185, 186, 187, 188,
 	189, 190, 191, 192, 193, 194, 195, 196,
 	197, 198, 199, 200, 201, 202, 203, 204,
 	205, 206, 207, 208, 209, 210, 211, 212,
-	213, 214, 215, 584, 217, 585, 219, 549,
+	213, 214, 215, 636, 217, 637, 219, 549,
 	220, 221, 224, 222, 223, 225, 231, 226,
 	227, 228, 229, 230, 232, 288, 232, 233,
-	234, 235, 237, 584, 236, 238, 239, 240,
+	234, 235, 237, 636, 236, 238, 239, 240,
 	246, 242, 245, 241, 243, 244, 247, 287,
 	248, 251, 261, 249, 250, 252, 274, 253,
 	272, 254, 255, 270, 256, 257, 268, 258,
@@ -1551,12 +1617,20 @@ var _credentials_trans_targs []int16 = []int16{
 	546, 547, 548, 550, 551, 552, 553, 554,
 	555, 556, 559, 557, 558, 559, 560, 566,
 	561, 562, 562, 563, 564, 565, 566, 567,
-	586, 569, 570, 571, 572, 573, 574, 568,
+	638, 569, 570, 571, 572, 573, 574, 568,
 	30, 31, 8, 34, 30, 31, 8, 30,
 	31, 8, 30, 31, 8, 30, 31, 8,
 	30, 31, 8, 30, 31, 8, 140, 30,
-	31, 8, 30, 31, 8, 584, 216, 30,
-	31, 8,
+	31, 8, 30, 31, 8, 636, 216, 30,
+	31, 8, 575, 595, 576, 577, 578, 579,
+	581, 580, 639, 582, 583, 584, 585, 586,
+	640, 588, 589, 590, 641, 592, 593, 594,
+	642, 596, 597, 598, 599, 600, 601, 602,
+	603, 604, 605, 606, 607, 608, 609, 610,
+	611, 612, 613, 614, 615, 616, 617, 618,
+	619, 620, 621, 622, 623, 624, 625, 626,
+	30, 31, 8, 587, 30, 31, 8, 591,
+	30, 31, 8, 30, 31, 8,
 }
 
 var _credentials_trans_actions []byte = []byte{
@@ -1639,7 +1713,15 @@ var _credentials_trans_actions []byte = []byte{
 	13, 13, 17, 17, 17, 7, 7, 7,
 	15, 15, 15, 19, 19, 19, 0, 21,
 	21, 21, 5, 5, 5, 0, 0, 3,
-	3, 3,
+	3, 3, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0,
+	27, 27, 27, 0, 29, 29, 29, 0,
+	31, 31, 31, 33, 33, 33,
 }
 
 var _credentials_eof_actions []byte = []byte{
@@ -1714,13 +1796,20 @@ var _credentials_eof_actions []byte = []byte{
 	0, 0, 0, 0, 0, 0, 0, 0,
 	0, 0, 0, 0, 0, 0, 0, 0,
 	0, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 0, 0, 0, 0, 0, 23,
-	25, 13, 17, 7, 15, 19, 21, 5,
-	0, 0, 3,
+	0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 23, 25, 13, 17, 7,
+	15, 19, 21, 5, 0, 0, 3, 27,
+	29, 31, 33,
 }
 
 const credentials_start int = 1
-const credentials_first_final int = 575
+const credentials_first_final int = 627
 const credentials_error int = 0
 
 const credentials_en_credentials int = 1
@@ -1733,16 +1822,16 @@ func parseCredentials(data []byte) (*Credentials, error) {
 	var p, m, pe, eof ptr = 0, 0, l, l
 	cr := &Credentials{}
 
-//line parser_cred.rl:54
+//line parser_cred.rl:56
 
-//line parser_cred.go:1745
+//line parser_cred.go:1834
 	{
 		cs = credentials_start
 	}
 
-//line parser_cred.rl:57
+//line parser_cred.rl:59
 
-//line parser_cred.go:1752
+//line parser_cred.go:1841
 	{
 		var _klen int
 		var _trans int
@@ -1867,7 +1956,19 @@ func parseCredentials(data []byte) (*Credentials, error) {
 			case 12:
 //line parser_cred.rl:46
 				cr.algo = AlgoMD5sess
-//line parser_cred.go:1876
+			case 13:
+//line parser_cred.rl:47
+				cr.algo = AlgoSHA256
+			case 14:
+//line parser_cred.rl:47
+				cr.algo = AlgoSHA256sess
+			case 15:
+//line parser_cred.rl:48
+				cr.algo = AlgoSHA512256
+			case 16:
+//line parser_cred.rl:48
+				cr.algo = AlgoSHA512256sess
+//line parser_cred.go:1977
 			}
 		}
 
@@ -1925,7 +2026,19 @@ func parseCredentials(data []byte) (*Credentials, error) {
 				case 12:
 //line parser_cred.rl:46
 					cr.algo = AlgoMD5sess
-//line parser_cred.go:1931
+				case 13:
+//line parser_cred.rl:47
+					cr.algo = AlgoSHA256
+				case 14:
+//line parser_cred.rl:47
+					cr.algo = AlgoSHA256sess
+				case 15:
+//line parser_cred.rl:48
+					cr.algo = AlgoSHA512256
+				case 16:
+//line parser_cred.rl:48
+					cr.algo = AlgoSHA512256sess
+//line parser_cred.go:2044
 				}
 			}
 		}
@@ -1935,7 +2048,7 @@ func parseCredentials(data []byte) (*Credentials, error) {
 		}
 	}
 
-//line parser_cred.rl:58
+//line parser_cred.rl:60
 
 	if cs >= credentials_first_final {
 		return cr, nil
@@ -41,9 +41,11 @@ func parseCredentials (data []byte) (*Credentials, error) {
     digest_uri  = "uri"i EQUAL LDQUOT RequestURI >sm %duri RDQUOT;
     cnonce      = "cnonce"i EQUAL quoted_string >sm %cnonce;
     nonce_count = "nc"i EQUAL LHEX{8} >sm %nc;
-    response    = "response"i EQUAL LDQUOT LHEX{32} >sm %resp RDQUOT;
+    response    = "response"i EQUAL LDQUOT (LHEX{32} | LHEX{64}) >sm %resp RDQUOT;
     algo        = "algorithm"i EQUAL
-                  ( "MD5"i %{cr.algo = AlgoMD5} | "MD5-sess"i %{cr.algo = AlgoMD5sess} );
+                  ( "MD5"i %{cr.algo = AlgoMD5} | "MD5-sess"i %{cr.algo = AlgoMD5sess} |
+                    "SHA-256"i %{cr.algo = AlgoSHA256} | "SHA-256-sess"i %{cr.algo = AlgoSHA256sess} |
+                    "SHA-512-256"i %{cr.algo = AlgoSHA512256} | "SHA-512-256-sess"i %{cr.algo = AlgoSHA512256sess} );
     opaque      = "opaque"i EQUAL quoted_string >sm %opaque;
     qop         = "qop"i EQUAL qopval;
 
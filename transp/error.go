@@ -0,0 +1,23 @@
+package transp
+
+import "fmt"
+
+type transpError struct {
+	s string
+	e string
+}
+
+func errorNew(ctx string) *transpError {
+	return &transpError{s: ctx}
+}
+
+// msg returns new error of the same context. Package errors are
+// shared by transports running concurrently and are not modified.
+func (e *transpError) msg(msg string, args ...interface{}) *transpError {
+	txt := fmt.Sprintf(msg, args...)
+	return &transpError{s: e.s, e: ": " + txt}
+}
+
+func (e *transpError) Error() string {
+	return e.s + e.e
+}
@@ -8,10 +8,15 @@ const (
 	Unknown Proto = iota
 	UDP
 	TCP
+	TLS
+	WS
+	WSS
 )
 
 type Addr struct {
-	addr net.Addr
+	addr   net.Addr
+	proto  Proto
+	domain string
 }
 
 func UDPAddr(address string) *Addr {
@@ -19,10 +24,71 @@ func UDPAddr(address string) *Addr {
 	if err != nil {
 		return nil
 	}
-	return &Addr{addr}
+	return &Addr{addr: addr, domain: hostDomain(address)}
+}
+
+// TCPAddr resolves TCP address
+func TCPAddr(address string) *Addr {
+	addr, err := net.ResolveTCPAddr("tcp", address)
+	if err != nil {
+		return nil
+	}
+	return &Addr{addr: addr, domain: hostDomain(address)}
+}
+
+// TLSAddr resolves TLS address
+func TLSAddr(address string) *Addr {
+	return streamAddr(address, TLS)
+}
+
+// WSAddr resolves WebSocket address
+func WSAddr(address string) *Addr {
+	return streamAddr(address, WS)
+}
+
+// WSSAddr resolves secure WebSocket address
+func WSSAddr(address string) *Addr {
+	return streamAddr(address, WSS)
+}
+
+func streamAddr(address string, proto Proto) *Addr {
+	addr, err := net.ResolveTCPAddr("tcp", address)
+	if err != nil {
+		return nil
+	}
+	return &Addr{addr: addr, proto: proto, domain: hostDomain(address)}
+}
+
+// hostDomain returns host of the address if it is not IP address
+func hostDomain(address string) string {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil || net.ParseIP(host) != nil {
+		return ""
+	}
+	return host
+}
+
+// String returns protocol name as used in Via header transport
+func (p Proto) String() string {
+	switch p {
+	case UDP:
+		return "UDP"
+	case TCP:
+		return "TCP"
+	case TLS:
+		return "TLS"
+	case WS:
+		return "WS"
+	case WSS:
+		return "WSS"
+	}
+	return "UNKNOWN"
 }
 
 func (a Addr) Proto() Proto {
+	if a.proto != Unknown {
+		return a.proto
+	}
 	switch a.addr.Network() {
 	case "udp", "udp4", "udp6":
 		return UDP
@@ -35,3 +101,35 @@ func (a Addr) Proto() Proto {
 
 func (a Addr) IsUDP() bool { return a.Proto() == UDP }
 func (a Addr) IsTCP() bool { return a.Proto() == TCP }
+func (a Addr) IsTLS() bool { return a.Proto() == TLS }
+func (a Addr) IsWS() bool  { return a.Proto() == WS }
+func (a Addr) IsWSS() bool { return a.Proto() == WSS }
+
+// String returns address as "host:port"
+func (a Addr) String() string { return a.addr.String() }
+
+// Domain returns domain name the address is resolved from or empty
+// string. TLS server certificate is verified against it (RFC5922#4).
+func (a Addr) Domain() string { return a.domain }
+
+// IP returns address IP or nil if address is not IP address
+func (a Addr) IP() net.IP {
+	switch addr := a.addr.(type) {
+	case *net.UDPAddr:
+		return addr.IP
+	case *net.TCPAddr:
+		return addr.IP
+	}
+	return nil
+}
+
+// Port returns address port or 0 if address has no port
+func (a Addr) Port() int {
+	switch addr := a.addr.(type) {
+	case *net.UDPAddr:
+		return addr.Port
+	case *net.TCPAddr:
+		return addr.Port
+	}
+	return 0
+}
@@ -0,0 +1,334 @@
+package transp
+
+import (
+	"math/rand"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/staskobzar/gosip/sipmsg"
+)
+
+// ErrorResolver server location error
+var ErrorResolver = errorNew("Resolver")
+
+// NAPTR DNS NAPTR record (RFC3403)
+type NAPTR struct {
+	Order       uint16
+	Preference  uint16
+	Flags       string
+	Service     string
+	Regexp      string
+	Replacement string
+}
+
+// DNSClient DNS lookups used by Resolver
+type DNSClient interface {
+	LookupNAPTR(name string) ([]*NAPTR, error)
+	LookupSRV(name string) ([]*net.SRV, error)
+	LookupHost(host string) ([]string, error)
+}
+
+// systemDNS DNS client that uses system resolver. Standard library
+// does not support NAPTR so lookup returns no records and
+// resolver falls back to SRV queries.
+type systemDNS struct{}
+
+func (systemDNS) LookupNAPTR(name string) ([]*NAPTR, error) { return nil, nil }
+
+func (systemDNS) LookupSRV(name string) ([]*net.SRV, error) {
+	_, srv, err := net.LookupSRV("", "", name)
+	return srv, err
+}
+
+func (systemDNS) LookupHost(host string) ([]string, error) {
+	return net.LookupHost(host)
+}
+
+// NAPTR services and SRV prefixes of supported transports (RFC3263#4.1, RFC7118#5)
+var transports = []struct {
+	proto   Proto
+	service string
+	srv     string
+}{
+	{TLS, "SIPS+D2T", "_sips._tcp."},
+	{TCP, "SIP+D2T", "_sip._tcp."},
+	{UDP, "SIP+D2U", "_sip._udp."},
+	{WSS, "SIPS+D2W", "_sips._ws."},
+	{WS, "SIP+D2W", "_sip._ws."},
+}
+
+// Resolver locates SIP servers for URI (RFC3263#4)
+type Resolver struct {
+	dns DNSClient
+}
+
+// NewResolver creates resolver with DNS client. If client is nil
+// then system resolver is used.
+func NewResolver(dns DNSClient) *Resolver {
+	if dns == nil {
+		dns = systemDNS{}
+	}
+	return &Resolver{dns: dns}
+}
+
+// Resolve returns ordered list of target addresses for the URI.
+// Address protocol defines transport to use for the target.
+// Addresses keep URI host as domain of the target.
+func (r *Resolver) Resolve(uri *sipmsg.URI) ([]*Addr, error) {
+	targets, err := r.resolve(uri)
+	if err != nil {
+		return nil, err
+	}
+	// server identity is the URI domain and not SRV target (RFC5922#4.1)
+	if host := strings.TrimSuffix(uri.Host(), "."); net.ParseIP(strings.Trim(host, "[]")) == nil {
+		for _, addr := range targets {
+			addr.domain = host
+		}
+	}
+	return targets, nil
+}
+
+func (r *Resolver) resolve(uri *sipmsg.URI) ([]*Addr, error) {
+	if uri == nil || (uri.ID() != sipmsg.URIsip && uri.ID() != sipmsg.URIsips) {
+		return nil, ErrorResolver.msg("sip or sips URI expected")
+	}
+	secure := uri.ID() == sipmsg.URIsips
+	host := uri.Host()
+	if maddr, ok := uri.Param("maddr"); ok && maddr != "" {
+		host = maddr
+	}
+	host = strings.TrimSuffix(strings.Trim(host, "[]"), ".")
+	if host == "" {
+		return nil, ErrorResolver.msg("URI has no host")
+	}
+
+	var proto Proto
+	if tp, ok := uri.Param("transport"); ok {
+		proto = transportProto(tp, secure)
+		if proto == Unknown {
+			return nil, ErrorResolver.msg("unsupported transport %q", tp)
+		}
+	}
+
+	port := 0
+	if p := uri.Port(); p != "" {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return nil, ErrorResolver.msg("invalid port %q", p)
+		}
+		port = n
+	}
+
+	// numeric IP or explicit port: no NAPTR and SRV (RFC3263#4.1, #4.2)
+	if ip := net.ParseIP(host); ip != nil || port != 0 {
+		if proto == Unknown {
+			proto = defaultProto(secure)
+		}
+		if port == 0 {
+			port = defaultPort(proto)
+		}
+		return r.lookupHost(host, port, proto)
+	}
+
+	if proto == Unknown {
+		targets, err := r.lookupNAPTR(host, secure)
+		if err != nil || len(targets) > 0 {
+			return targets, err
+		}
+	}
+
+	for _, t := range transports {
+		if (proto != Unknown && t.proto != proto) || (secure && !isSecureProto(t.proto)) {
+			continue
+		}
+		targets, err := r.lookupSRV(t.srv+host, t.proto)
+		if err != nil {
+			return nil, err
+		}
+		if len(targets) > 0 {
+			return targets, nil
+		}
+	}
+
+	if proto == Unknown {
+		proto = defaultProto(secure)
+	}
+	return r.lookupHost(host, defaultPort(proto), proto)
+}
+
+// lookupNAPTR resolves NAPTR records with supported services
+// and follows them to SRV records (RFC3263#4.1)
+func (r *Resolver) lookupNAPTR(host string, secure bool) ([]*Addr, error) {
+	records, err := r.dns.LookupNAPTR(host)
+	if err != nil {
+		return nil, nil
+	}
+	sort.SliceStable(records, func(i, j int) bool {
+		if records[i].Order != records[j].Order {
+			return records[i].Order < records[j].Order
+		}
+		return records[i].Preference < records[j].Preference
+	})
+
+	var targets []*Addr
+	for _, rec := range records {
+		if !strings.EqualFold(rec.Flags, "s") {
+			continue
+		}
+		proto := naptrProto(rec.Service)
+		if proto == Unknown || (secure && !isSecureProto(proto)) {
+			continue
+		}
+		addrs, err := r.lookupSRV(strings.TrimSuffix(rec.Replacement, "."), proto)
+		if err != nil {
+			return nil, err
+		}
+		targets = append(targets, addrs...)
+	}
+	return targets, nil
+}
+
+// lookupSRV resolves SRV records ordered by priority and weight (RFC2782)
+func (r *Resolver) lookupSRV(name string, proto Proto) ([]*Addr, error) {
+	records, err := r.dns.LookupSRV(name)
+	if err != nil || len(records) == 0 {
+		return nil, nil
+	}
+
+	var targets []*Addr
+	for _, srv := range orderSRV(records) {
+		addrs, err := r.lookupHost(strings.TrimSuffix(srv.Target, "."), int(srv.Port), proto)
+		if err != nil {
+			continue
+		}
+		targets = append(targets, addrs...)
+	}
+	if len(targets) == 0 {
+		return nil, ErrorResolver.msg("no address found for SRV %s", name)
+	}
+	return targets, nil
+}
+
+func (r *Resolver) lookupHost(host string, port int, proto Proto) ([]*Addr, error) {
+	var hosts []string
+	if ip := net.ParseIP(host); ip != nil {
+		hosts = []string{host}
+	} else {
+		var err error
+		hosts, err = r.dns.LookupHost(host)
+		if err != nil {
+			return nil, ErrorResolver.msg("failed to resolve %s: %s", host, err)
+		}
+	}
+
+	targets := make([]*Addr, 0, len(hosts))
+	for _, h := range hosts {
+		ip := net.ParseIP(h)
+		if ip == nil {
+			continue
+		}
+		targets = append(targets, newAddr(ip, port, proto))
+	}
+	if len(targets) == 0 {
+		return nil, ErrorResolver.msg("no address found for %s", host)
+	}
+	return targets, nil
+}
+
+// orderSRV sorts records by priority and orders records of the
+// same priority by weighted random selection (RFC2782)
+func orderSRV(records []*net.SRV) []*net.SRV {
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].Priority < records[j].Priority
+	})
+	ordered := make([]*net.SRV, 0, len(records))
+	for i := 0; i < len(records); {
+		j := i
+		for j < len(records) && records[j].Priority == records[i].Priority {
+			j++
+		}
+		group := append([]*net.SRV(nil), records[i:j]...)
+		for len(group) > 0 {
+			total := 0
+			for _, srv := range group {
+				total += int(srv.Weight)
+			}
+			n := 0
+			if total > 0 {
+				sum, pick := 0, rand.Intn(total)+1
+				for n = range group {
+					sum += int(group[n].Weight)
+					if sum >= pick {
+						break
+					}
+				}
+			}
+			ordered = append(ordered, group[n])
+			group = append(group[:n], group[n+1:]...)
+		}
+		i = j
+	}
+	return ordered
+}
+
+func newAddr(ip net.IP, port int, proto Proto) *Addr {
+	if proto == UDP {
+		return &Addr{addr: &net.UDPAddr{IP: ip, Port: port}, proto: UDP}
+	}
+	return &Addr{addr: &net.TCPAddr{IP: ip, Port: port}, proto: proto}
+}
+
+// transportProto returns protocol of URI transport parameter
+func transportProto(transport string, secure bool) Proto {
+	switch strings.ToLower(transport) {
+	case "udp":
+		if !secure {
+			return UDP
+		}
+	case "tcp", "tls":
+		if secure || strings.EqualFold(transport, "tls") {
+			return TLS
+		}
+		return TCP
+	case "ws", "wss":
+		if secure || strings.EqualFold(transport, "wss") {
+			return WSS
+		}
+		return WS
+	}
+	return Unknown
+}
+
+func naptrProto(service string) Proto {
+	for _, t := range transports {
+		if strings.EqualFold(t.service, service) {
+			return t.proto
+		}
+	}
+	return Unknown
+}
+
+func isSecureProto(proto Proto) bool {
+	return proto == TLS || proto == WSS
+}
+
+func defaultProto(secure bool) Proto {
+	if secure {
+		return TLS
+	}
+	return UDP
+}
+
+func defaultPort(proto Proto) int {
+	switch proto {
+	case TLS:
+		return 5061
+	case WS:
+		return 80
+	case WSS:
+		return 443
+	}
+	return 5060
+}
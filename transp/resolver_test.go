@@ -0,0 +1,160 @@
+package transp
+
+import (
+	"net"
+	"testing"
+
+	"github.com/staskobzar/gosip/sipmsg"
+	"github.com/stretchr/testify/assert"
+)
+
+// zone in-memory DNS zone
+type zone struct {
+	naptr map[string][]*NAPTR
+	srv   map[string][]*net.SRV
+	hosts map[string][]string
+}
+
+func (z *zone) LookupNAPTR(name string) ([]*NAPTR, error) {
+	return z.naptr[name], nil
+}
+
+func (z *zone) LookupSRV(name string) ([]*net.SRV, error) {
+	// resolver must not modify zone records
+	return append([]*net.SRV(nil), z.srv[name]...), nil
+}
+
+func (z *zone) LookupHost(host string) ([]string, error) {
+	if addrs, ok := z.hosts[host]; ok {
+		return addrs, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: host}
+}
+
+func testZone() *zone {
+	return &zone{
+		naptr: map[string][]*NAPTR{
+			"atlanta.com": {
+				{Order: 50, Preference: 50, Flags: "s", Service: "SIP+D2U", Replacement: "_sip._udp.atlanta.com."},
+				{Order: 50, Preference: 50, Flags: "s", Service: "SIPS+D2T", Replacement: "_sips._tcp.atlanta.com."},
+				{Order: 90, Preference: 50, Flags: "s", Service: "SIP+D2T", Replacement: "_sip._tcp.atlanta.com."},
+				{Order: 10, Preference: 50, Flags: "", Service: "SIP+D2U", Replacement: "ignored.atlanta.com."},
+			},
+		},
+		srv: map[string][]*net.SRV{
+			"_sip._udp.atlanta.com": {
+				{Target: "backup.atlanta.com.", Port: 5060, Priority: 20, Weight: 0},
+				{Target: "server.atlanta.com.", Port: 5060, Priority: 10, Weight: 0},
+			},
+			"_sips._tcp.atlanta.com": {
+				{Target: "server.atlanta.com.", Port: 5061, Priority: 10},
+			},
+			"_sip._tcp.atlanta.com": {
+				{Target: "server.atlanta.com.", Port: 5070, Priority: 10},
+			},
+			"_sip._tcp.biloxi.com": {
+				{Target: "a.biloxi.com.", Port: 5060, Priority: 0, Weight: 10},
+				{Target: "b.biloxi.com.", Port: 5060, Priority: 0, Weight: 90},
+				{Target: "c.biloxi.com.", Port: 5060, Priority: 1, Weight: 0},
+			},
+			"_sip._ws.biloxi.com": {
+				{Target: "ws.biloxi.com.", Port: 8080, Priority: 0},
+			},
+		},
+		hosts: map[string][]string{
+			"server.atlanta.com": {"192.0.2.10", "192.0.2.11"},
+			"backup.atlanta.com": {"192.0.2.20"},
+			"a.biloxi.com":       {"198.51.100.1"},
+			"b.biloxi.com":       {"198.51.100.2"},
+			"c.biloxi.com":       {"198.51.100.3"},
+			"ws.biloxi.com":      {"198.51.100.4"},
+			"chicago.com":        {"203.0.113.1"},
+		},
+	}
+}
+
+func resolve(t *testing.T, uri string) []string {
+	r := NewResolver(testZone())
+	addrs, err := r.Resolve(sipmsg.URIParse([]byte(uri)))
+	assert.Nil(t, err)
+	targets := make([]string, len(addrs))
+	for i, addr := range addrs {
+		targets[i] = addr.addr.Network() + "/" + protoName(addr.Proto()) + "/" + addr.String()
+	}
+	return targets
+}
+
+func protoName(p Proto) string {
+	return [...]string{"unknown", "udp", "tcp", "tls", "ws", "wss"}[p]
+}
+
+func TestTranspResolveNAPTR(t *testing.T) {
+	assert.Equal(t, []string{
+		"udp/udp/192.0.2.10:5060",
+		"udp/udp/192.0.2.11:5060",
+		"udp/udp/192.0.2.20:5060",
+		"tcp/tls/192.0.2.10:5061",
+		"tcp/tls/192.0.2.11:5061",
+		"tcp/tcp/192.0.2.10:5070",
+		"tcp/tcp/192.0.2.11:5070",
+	}, resolve(t, "sip:alice@atlanta.com"))
+
+	// sips uses only secure transports
+	assert.Equal(t, []string{
+		"tcp/tls/192.0.2.10:5061",
+		"tcp/tls/192.0.2.11:5061",
+	}, resolve(t, "sips:alice@atlanta.com"))
+}
+
+func TestTranspResolveSRV(t *testing.T) {
+	// transport parameter skips NAPTR
+	assert.Equal(t, []string{
+		"tcp/tcp/192.0.2.10:5070",
+		"tcp/tcp/192.0.2.11:5070",
+	}, resolve(t, "sip:alice@atlanta.com;transport=tcp"))
+
+	targets := resolve(t, "sip:bob@biloxi.com")
+	assert.Equal(t, 3, len(targets))
+	assert.ElementsMatch(t, []string{
+		"tcp/tcp/198.51.100.1:5060",
+		"tcp/tcp/198.51.100.2:5060",
+	}, targets[:2])
+	assert.Equal(t, "tcp/tcp/198.51.100.3:5060", targets[2])
+
+	assert.Equal(t, []string{"tcp/ws/198.51.100.4:8080"},
+		resolve(t, "sip:bob@biloxi.com;transport=ws"))
+}
+
+func TestTranspResolveHost(t *testing.T) {
+	// no NAPTR and SRV records
+	assert.Equal(t, []string{"udp/udp/203.0.113.1:5060"}, resolve(t, "sip:carol@chicago.com"))
+	assert.Equal(t, []string{"tcp/tls/203.0.113.1:5061"}, resolve(t, "sips:carol@chicago.com"))
+	assert.Equal(t, []string{"tcp/tcp/203.0.113.1:5060"}, resolve(t, "sip:carol@chicago.com;transport=tcp"))
+	assert.Equal(t, []string{"tcp/tls/203.0.113.1:5061"}, resolve(t, "sips:carol@chicago.com;transport=tcp"))
+
+	// explicit port skips SRV
+	assert.Equal(t, []string{
+		"udp/udp/192.0.2.10:5080",
+		"udp/udp/192.0.2.11:5080",
+	}, resolve(t, "sip:server.atlanta.com:5080"))
+	assert.Equal(t, []string{"udp/udp/192.0.2.1:5060"}, resolve(t, "sip:alice@192.0.2.1"))
+	assert.Equal(t, []string{"tcp/tcp/192.0.2.1:5090"}, resolve(t, "sip:alice@192.0.2.1:5090;transport=tcp"))
+
+	// maddr overrides host
+	assert.Equal(t, []string{"udp/udp/192.0.2.5:5060"}, resolve(t, "sip:alice@atlanta.com;maddr=192.0.2.5"))
+	assert.Equal(t, []string{"tcp/tcp/198.51.100.3:5060"}, resolve(t, "sip:bob@biloxi.com;maddr=c.biloxi.com;transport=tcp"))
+}
+
+func TestTranspResolveError(t *testing.T) {
+	r := NewResolver(testZone())
+	_, err := r.Resolve(nil)
+	assert.NotNil(t, err)
+	_, err = r.Resolve(sipmsg.URIParse([]byte("sip:alice@unknown.com")))
+	assert.NotNil(t, err)
+	_, err = r.Resolve(sipmsg.URIParse([]byte("sips:alice@atlanta.com;transport=udp")))
+	assert.NotNil(t, err)
+	_, err = r.Resolve(sipmsg.URIParse([]byte("sip:alice@atlanta.com;transport=sctp")))
+	assert.NotNil(t, err)
+	_, err = r.Resolve(sipmsg.URIParse([]byte("mailto:alice@atlanta.com")))
+	assert.NotNil(t, err)
+}
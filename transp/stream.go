@@ -0,0 +1,368 @@
+package transp
+
+import (
+	"bufio"
+	"bytes"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/staskobzar/gosip/sipmsg"
+)
+
+// default time after which connection without traffic is closed
+const defaultIdleTimeout = 3 * time.Minute
+
+// max size of SIP message headers on stream transport
+const maxHeaderSize = 65535
+
+// max size of SIP message body on stream transport
+const maxBodySize = 1 << 24
+
+var crlf = []byte("\r\n")
+
+// stream is connection oriented transport with connections pool
+// keyed by remote address. By default messages are framed by
+// Content-Length header (RFC3261#18.3).
+type stream struct {
+	ln      net.Listener
+	dial    func(address, domain string) (net.Conn, error)
+	proto   Proto
+	handler Handler
+	// upgrade is called for accepted connection before it is added to pool
+	upgrade func(conn net.Conn) (net.Conn, error)
+	// read reads next message from connection. Returns nil message on keep-alive.
+	read  func(sc *streamConn, r *bufio.Reader) (*sipmsg.Message, error)
+	idle  time.Duration
+	mux   *sync.Mutex
+	conns map[string]*streamConn
+	// dials in progress keyed by remote address
+	dials map[string]chan struct{}
+	done  chan struct{}
+	once  sync.Once
+}
+
+// streamConn connection in the pool
+type streamConn struct {
+	conn     net.Conn
+	wmux     *sync.Mutex
+	lastUsed time.Time
+}
+
+// newStream creates stream transport. Listener can be nil for
+// client only transport.
+func newStream(proto Proto, ln net.Listener, handler Handler, dial func(address, domain string) (net.Conn, error)) *stream {
+	s := &stream{
+		ln:      ln,
+		dial:    dial,
+		proto:   proto,
+		handler: handler,
+		idle:    defaultIdleTimeout,
+		mux:     &sync.Mutex{},
+		conns:   make(map[string]*streamConn),
+		dials:   make(map[string]chan struct{}),
+		done:    make(chan struct{}),
+	}
+	s.read = s.readStream
+	return s
+}
+
+// listen starts accepting connections
+func (s *stream) listen() *stream {
+	if s.ln != nil {
+		go s.acceptLoop()
+	}
+	return s
+}
+
+// SetIdleTimeout sets time after which connection without
+// traffic is closed
+func (s *stream) SetIdleTimeout(d time.Duration) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.idle = d
+}
+
+// Send serializes SIP message and sends it to address. Connection
+// from the pool is used or new connection is established.
+func (s *stream) Send(msg *sipmsg.Message, addr *Addr) error {
+	if addr == nil {
+		return ErrorTransport.msg("invalid destination address")
+	}
+	sc, err := s.connection(addr.String(), addr.Domain())
+	if err != nil {
+		return err
+	}
+	return sc.write(msg.Bytes())
+}
+
+// LocalAddr returns transport local address or nil if
+// transport is not listening
+func (s *stream) LocalAddr() *Addr {
+	if s.ln == nil {
+		return nil
+	}
+	return &Addr{addr: s.ln.Addr(), proto: s.proto}
+}
+
+// Proto returns transport protocol
+func (s *stream) Proto() Proto { return s.proto }
+
+// Len returns number of connections in the pool
+func (s *stream) Len() int {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	return len(s.conns)
+}
+
+// Close stops listener and closes all connections
+func (s *stream) Close() error {
+	var err error
+	s.once.Do(func() {
+		close(s.done)
+		if s.ln != nil {
+			err = s.ln.Close()
+		}
+		s.mux.Lock()
+		defer s.mux.Unlock()
+		for key, sc := range s.conns {
+			sc.conn.Close()
+			delete(s.conns, key)
+		}
+	})
+	return err
+}
+
+// connection returns connection from the pool or dials new one.
+// Concurrent sends to the same address wait for a single dial.
+func (s *stream) connection(address, domain string) (*streamConn, error) {
+	s.mux.Lock()
+	for {
+		if sc, ok := s.conns[address]; ok {
+			s.mux.Unlock()
+			return sc, nil
+		}
+		wait, ok := s.dials[address]
+		if !ok {
+			break
+		}
+		s.mux.Unlock()
+		<-wait
+		s.mux.Lock()
+	}
+	done := make(chan struct{})
+	s.dials[address] = done
+	s.mux.Unlock()
+
+	defer func() {
+		s.mux.Lock()
+		delete(s.dials, address)
+		s.mux.Unlock()
+		close(done)
+	}()
+	conn, err := s.dial(address, domain)
+	if err != nil {
+		return nil, ErrorTransport.msg("failed to connect %s: %s", address, err)
+	}
+	return s.add(conn), nil
+}
+
+func (s *stream) add(conn net.Conn) *streamConn {
+	sc := &streamConn{
+		conn:     conn,
+		wmux:     &sync.Mutex{},
+		lastUsed: time.Now(),
+	}
+	s.mux.Lock()
+	s.conns[conn.RemoteAddr().String()] = sc
+	s.mux.Unlock()
+	go s.recvLoop(sc)
+	return sc
+}
+
+func (s *stream) remove(sc *streamConn) {
+	s.mux.Lock()
+	key := sc.conn.RemoteAddr().String()
+	if s.conns[key] == sc {
+		delete(s.conns, key)
+	}
+	s.mux.Unlock()
+	sc.conn.Close()
+}
+
+func (s *stream) acceptLoop() {
+	for {
+		conn, err := s.ln.Accept()
+		if err != nil {
+			select {
+			case <-s.done:
+				return
+			default:
+				continue
+			}
+		}
+		go s.accept(conn)
+	}
+}
+
+func (s *stream) accept(conn net.Conn) {
+	if s.upgrade != nil {
+		c, err := s.upgrade(conn)
+		if err != nil {
+			conn.Close()
+			return
+		}
+		conn = c
+	}
+	s.add(conn)
+}
+
+func (s *stream) recvLoop(sc *streamConn) {
+	defer s.remove(sc)
+	r := bufio.NewReader(sc.conn)
+	addr := &Addr{addr: sc.conn.RemoteAddr(), proto: s.proto}
+	for {
+		msg, err := s.read(sc, r)
+		if err != nil {
+			return
+		}
+		if msg == nil {
+			continue
+		}
+		if err := setReceived(msg, addr); err != nil {
+			continue
+		}
+		if s.handler != nil {
+			s.handler(msg, addr)
+		}
+	}
+}
+
+// readStream reads message framed by Content-Length header
+func (s *stream) readStream(sc *streamConn, r *bufio.Reader) (*sipmsg.Message, error) {
+	data, err := s.readHeaders(sc, r)
+	if err != nil || data == nil {
+		return nil, err
+	}
+	msg, err := sipmsg.MsgParse(data)
+	if err != nil {
+		// stream can not be re-synchronized after invalid message
+		return nil, err
+	}
+	if msg.ContentLen > maxBodySize {
+		return nil, ErrorTransport.msg("message body too long")
+	}
+	if msg.ContentLen > 0 {
+		body := make([]byte, msg.ContentLen)
+		if _, err := s.readFull(sc, r, body); err != nil {
+			return nil, err
+		}
+		msg.Body = body
+	}
+	return msg, nil
+}
+
+// readHeaders reads message headers until empty line. Returns nil
+// data on keep-alive CRLF and answers double CRLF ping with single
+// CRLF pong (RFC5626#4.4.1).
+func (s *stream) readHeaders(sc *streamConn, r *bufio.Reader) ([]byte, error) {
+	var buf bytes.Buffer
+	for {
+		line, err := s.readLine(sc, r)
+		if err != nil {
+			return nil, err
+		}
+		if buf.Len() == 0 && bytes.Equal(line, crlf) {
+			next, err := r.Peek(2)
+			if err == nil && bytes.Equal(next, crlf) {
+				r.Discard(2)
+				return nil, sc.write(crlf)
+			}
+			return nil, nil
+		}
+		buf.Write(line)
+		if buf.Len() > maxHeaderSize {
+			return nil, ErrorTransport.msg("message headers too long")
+		}
+		if bytes.Equal(line, crlf) {
+			return buf.Bytes(), nil
+		}
+	}
+}
+
+// readLine reads line keeping the part read before read deadline
+func (s *stream) readLine(sc *streamConn, r *bufio.Reader) ([]byte, error) {
+	var buf []byte
+	for {
+		s.setDeadline(sc)
+		line, err := r.ReadBytes('\n')
+		buf = append(buf, line...)
+		if len(line) > 0 {
+			sc.touch()
+		}
+		if err == nil {
+			return buf, nil
+		}
+		if !s.isIdleTimeout(sc, err) {
+			return nil, err
+		}
+	}
+}
+
+func (s *stream) readFull(sc *streamConn, r *bufio.Reader, buf []byte) (int, error) {
+	n := 0
+	for n < len(buf) {
+		s.setDeadline(sc)
+		m, err := r.Read(buf[n:])
+		n += m
+		if m > 0 {
+			sc.touch()
+		}
+		if err != nil && !s.isIdleTimeout(sc, err) {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+func (s *stream) setDeadline(sc *streamConn) {
+	s.mux.Lock()
+	idle := s.idle
+	s.mux.Unlock()
+	sc.conn.SetReadDeadline(time.Now().Add(idle))
+}
+
+// isIdleTimeout returns true if read timed out but connection
+// was used for sending within idle timeout
+func (s *stream) isIdleTimeout(sc *streamConn, err error) bool {
+	nerr, ok := err.(net.Error)
+	if !ok || !nerr.Timeout() {
+		return false
+	}
+	s.mux.Lock()
+	idle := s.idle
+	s.mux.Unlock()
+	return sc.since() < idle
+}
+
+func (sc *streamConn) write(data []byte) error {
+	sc.wmux.Lock()
+	defer sc.wmux.Unlock()
+	sc.lastUsed = time.Now()
+	if _, err := sc.conn.Write(data); err != nil {
+		return ErrorTransport.msg("failed to send to %s: %s", sc.conn.RemoteAddr(), err)
+	}
+	return nil
+}
+
+func (sc *streamConn) touch() {
+	sc.wmux.Lock()
+	defer sc.wmux.Unlock()
+	sc.lastUsed = time.Now()
+}
+
+func (sc *streamConn) since() time.Duration {
+	sc.wmux.Lock()
+	defer sc.wmux.Unlock()
+	return time.Since(sc.lastUsed)
+}
@@ -0,0 +1,28 @@
+package transp
+
+import (
+	"net"
+	"time"
+)
+
+// timeout of establishing new TCP connection
+const tcpDialTimeout = 10 * time.Second
+
+// TCPTransport TCP transport (RFC3261#18)
+type TCPTransport struct {
+	*stream
+}
+
+// ListenTCP creates TCP transport listening on address. Each received
+// SIP message is passed to handler. Connections are kept in the pool
+// and reused for sending messages to the same remote address.
+func ListenTCP(address string, handler Handler) (*TCPTransport, error) {
+	ln, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, ErrorTransport.msg("failed to listen %s: %s", address, err)
+	}
+	dial := func(address, domain string) (net.Conn, error) {
+		return net.DialTimeout("tcp", address, tcpDialTimeout)
+	}
+	return &TCPTransport{newStream(TCP, ln, handler, dial).listen()}, nil
+}
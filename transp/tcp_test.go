@@ -0,0 +1,218 @@
+package transp
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/staskobzar/gosip/sipmsg"
+	"github.com/stretchr/testify/assert"
+)
+
+var tcpReq = "MESSAGE sip:bob@biloxi.example.com SIP/2.0\r\n" +
+	"Via: SIP/2.0/TCP client.atlanta.example.com:5060;branch=z9hG4bKbf9f44\r\n" +
+	"Max-Forwards: 70\r\n" +
+	"From: Alice <sip:alice@atlanta.example.com>;tag=9fxced76sl\r\n" +
+	"To: Bob <sip:bob@biloxi.example.com>\r\n" +
+	"Call-ID: 2xTb9vxSit55XU7p8@atlanta.example.com\r\n" +
+	"CSeq: 1 MESSAGE\r\n" +
+	"Content-Type: text/plain\r\n" +
+	"Content-Length: 5\r\n\r\n" +
+	"Hello"
+
+func recvMsg(t *testing.T, ch chan *sipmsg.Message) *sipmsg.Message {
+	select {
+	case msg := <-ch:
+		return msg
+	case <-time.After(time.Second):
+		t.Fatal("message was not received")
+	}
+	return nil
+}
+
+func TestTranspTCP(t *testing.T) {
+	recv := make(chan *sipmsg.Message, 2)
+	var from *Addr
+	tcp, err := ListenTCP("127.0.0.1:0", func(msg *sipmsg.Message, addr *Addr) {
+		from = addr
+		recv <- msg
+	})
+	assert.Nil(t, err)
+	defer tcp.Close()
+	assert.True(t, tcp.LocalAddr().IsTCP())
+
+	conn, err := net.Dial("tcp", tcp.LocalAddr().String())
+	assert.Nil(t, err)
+	defer conn.Close()
+
+	// keep-alive, message split in two segments and pipelined messages
+	conn.Write([]byte("\r\n"))
+	conn.Write([]byte(tcpReq[:40]))
+	time.Sleep(10 * time.Millisecond)
+	conn.Write([]byte(tcpReq[40:] + tcpReq))
+
+	for i := 0; i < 2; i++ {
+		msg := recvMsg(t, recv)
+		assert.Equal(t, "MESSAGE", msg.ReqLine.Method())
+		assert.Equal(t, "Hello", string(msg.Body))
+		assert.Equal(t, "127.0.0.1", msg.Vias[0].Received())
+	}
+	assert.Equal(t, conn.LocalAddr().String(), from.String())
+	assert.Equal(t, 1, tcp.Len())
+
+	// response is sent over the same connection
+	msg, _ := sipmsg.MsgParse([]byte(tcpReq))
+	resp, _ := msg.NewResponse(200, "OK")
+	assert.Nil(t, tcp.Send(resp, from))
+
+	r := bufio.NewReader(conn)
+	conn.SetReadDeadline(time.Now().Add(time.Second))
+	line, err := r.ReadString('\n')
+	assert.Nil(t, err)
+	assert.Equal(t, "SIP/2.0 200 OK\r\n", line)
+}
+
+func TestTranspTCPKeepAlive(t *testing.T) {
+	tcp, err := ListenTCP("127.0.0.1:0", nil)
+	assert.Nil(t, err)
+	defer tcp.Close()
+
+	conn, err := net.Dial("tcp", tcp.LocalAddr().String())
+	assert.Nil(t, err)
+	defer conn.Close()
+
+	conn.Write([]byte("\r\n\r\n"))
+	buf := make([]byte, 16)
+	conn.SetReadDeadline(time.Now().Add(time.Second))
+	n, err := conn.Read(buf)
+	assert.Nil(t, err)
+	assert.Equal(t, "\r\n", string(buf[:n]))
+}
+
+func TestTranspTCPDial(t *testing.T) {
+	recv := make(chan *sipmsg.Message, 1)
+	uas, err := ListenTCP("127.0.0.1:0", func(msg *sipmsg.Message, addr *Addr) {
+		recv <- msg
+	})
+	assert.Nil(t, err)
+	defer uas.Close()
+
+	uac, err := ListenTCP("127.0.0.1:0", nil)
+	assert.Nil(t, err)
+	defer uac.Close()
+
+	msg, _ := sipmsg.MsgParse([]byte(tcpReq))
+	assert.Nil(t, uac.Send(msg, uas.LocalAddr()))
+	assert.Nil(t, uac.Send(msg, uas.LocalAddr()))
+	recvMsg(t, recv)
+	recvMsg(t, recv)
+	assert.Equal(t, 1, uac.Len())
+
+	assert.NotNil(t, uac.Send(msg, nil))
+	assert.NotNil(t, uac.Send(msg, TCPAddr("127.0.0.1:1")))
+}
+
+func TestTranspTCPDialConcurrent(t *testing.T) {
+	uas, err := ListenTCP("127.0.0.1:0", nil)
+	assert.Nil(t, err)
+	defer uas.Close()
+
+	uac, err := ListenTCP("127.0.0.1:0", nil)
+	assert.Nil(t, err)
+	defer uac.Close()
+
+	msg, _ := sipmsg.MsgParse([]byte(tcpReq))
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.Nil(t, uac.Send(msg, uas.LocalAddr()))
+		}()
+	}
+	wg.Wait()
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(t, 1, uac.Len())
+	assert.Equal(t, 1, uas.Len())
+}
+
+func TestTranspTCPPartialLine(t *testing.T) {
+	recv := make(chan *sipmsg.Message, 1)
+	tcp, err := ListenTCP("127.0.0.1:0", func(msg *sipmsg.Message, addr *Addr) {
+		recv <- msg
+	})
+	assert.Nil(t, err)
+	defer tcp.Close()
+	tcp.SetIdleTimeout(100 * time.Millisecond)
+
+	conn, err := net.Dial("tcp", tcp.LocalAddr().String())
+	assert.Nil(t, err)
+	defer conn.Close()
+	go func() {
+		for {
+			if _, err := conn.Read(make([]byte, 512)); err != nil {
+				return
+			}
+		}
+	}()
+
+	// read deadline fires in the middle of the line but
+	// connection is in use
+	conn.Write([]byte(tcpReq[:10]))
+	time.Sleep(70 * time.Millisecond)
+	msg, _ := sipmsg.MsgParse([]byte(tcpReq))
+	assert.Nil(t, tcp.Send(msg, TCPAddr(conn.LocalAddr().String())))
+	time.Sleep(70 * time.Millisecond)
+	conn.Write([]byte(tcpReq[10:]))
+
+	msg = recvMsg(t, recv)
+	assert.Equal(t, "MESSAGE", msg.ReqLine.Method())
+	assert.Equal(t, "Hello", string(msg.Body))
+}
+
+func TestTranspTCPBodyTooLong(t *testing.T) {
+	tcp, err := ListenTCP("127.0.0.1:0", nil)
+	assert.Nil(t, err)
+	defer tcp.Close()
+
+	conn, err := net.Dial("tcp", tcp.LocalAddr().String())
+	assert.Nil(t, err)
+	defer conn.Close()
+
+	conn.Write([]byte(strings.Replace(tcpReq, "Content-Length: 5", "Content-Length: 1999999999", 1)))
+	conn.SetReadDeadline(time.Now().Add(time.Second))
+	_, err = conn.Read(make([]byte, 16))
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestTranspTCPIdleTimeout(t *testing.T) {
+	tcp, err := ListenTCP("127.0.0.1:0", nil)
+	assert.Nil(t, err)
+	defer tcp.Close()
+	tcp.SetIdleTimeout(50 * time.Millisecond)
+
+	conn, err := net.Dial("tcp", tcp.LocalAddr().String())
+	assert.Nil(t, err)
+	defer conn.Close()
+
+	conn.SetReadDeadline(time.Now().Add(time.Second))
+	_, err = conn.Read(make([]byte, 16))
+	assert.NotNil(t, err)
+	assert.Equal(t, 0, tcp.Len())
+}
+
+func TestTranspTCPListenError(t *testing.T) {
+	_, err := ListenTCP("invalid:address:0", nil)
+	assert.NotNil(t, err)
+}
+
+func TestTranspTCPCloseTwice(t *testing.T) {
+	tcp, err := ListenTCP("127.0.0.1:0", nil)
+	assert.Nil(t, err)
+	assert.Nil(t, tcp.Close())
+	assert.Nil(t, tcp.Close())
+}
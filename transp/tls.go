@@ -0,0 +1,50 @@
+package transp
+
+import (
+	"crypto/tls"
+	"net"
+)
+
+// TLSTransport TLS transport (RFC3261#26.2)
+type TLSTransport struct {
+	*stream
+}
+
+// ListenTLS creates TLS transport listening on address. Config must
+// contain server certificates and is also used for outgoing connections.
+func ListenTLS(address string, config *tls.Config, handler Handler) (*TLSTransport, error) {
+	if config == nil {
+		return nil, ErrorTransport.msg("TLS config is required")
+	}
+	ln, err := tls.Listen("tcp", address, config)
+	if err != nil {
+		return nil, ErrorTransport.msg("failed to listen %s: %s", address, err)
+	}
+	return &TLSTransport{newStream(TLS, ln, handler, tlsDialer(config)).listen()}, nil
+}
+
+// NewTLSClient creates TLS transport that does not listen and only
+// establishes outgoing connections. Responses and requests received
+// on those connections are passed to handler.
+func NewTLSClient(config *tls.Config, handler Handler) *TLSTransport {
+	return &TLSTransport{newStream(TLS, nil, handler, tlsDialer(config))}
+}
+
+// tlsDialer dials address and verifies server certificate
+// against the target domain if it is known
+func tlsDialer(config *tls.Config) func(string, string) (net.Conn, error) {
+	return func(address, domain string) (net.Conn, error) {
+		dialer := &net.Dialer{Timeout: tcpDialTimeout}
+		return tls.DialWithDialer(dialer, "tcp", address, serverConfig(config, domain))
+	}
+}
+
+// serverConfig returns copy of the config with server name of the domain
+func serverConfig(config *tls.Config, domain string) *tls.Config {
+	if domain == "" {
+		return config
+	}
+	cfg := config.Clone()
+	cfg.ServerName = domain
+	return cfg
+}
@@ -0,0 +1,192 @@
+package transp
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/staskobzar/gosip/sipmsg"
+	"github.com/stretchr/testify/assert"
+)
+
+var tlsReq = "OPTIONS sips:bob@biloxi.example.com SIP/2.0\r\n" +
+	"Via: SIP/2.0/TLS client.atlanta.example.com:5061;branch=z9hG4bKbf9f44\r\n" +
+	"Max-Forwards: 70\r\n" +
+	"From: Alice <sips:alice@atlanta.example.com>;tag=9fxced76sl\r\n" +
+	"To: Bob <sips:bob@biloxi.example.com>\r\n" +
+	"Call-ID: 2xTb9vxSit55XU7p8@atlanta.example.com\r\n" +
+	"CSeq: 1 OPTIONS\r\n" +
+	"Content-Length: 0\r\n\r\n"
+
+// selfSignedConfig creates server and client configs with
+// self-signed certificate for loopback address
+func selfSignedConfig(t *testing.T) (*tls.Config, *tls.Config) {
+	return certConfig(t, []net.IP{net.ParseIP("127.0.0.1")}, nil)
+}
+
+// certConfig creates server and client configs with self-signed
+// certificate for IP addresses and domain names
+func certConfig(t *testing.T, ips []net.IP, names []string) (*tls.Config, *tls.Config) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		IPAddresses:           ips,
+		DNSNames:              names,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	assert.Nil(t, err)
+	cert, err := x509.ParseCertificate(der)
+	assert.Nil(t, err)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+	srv := &tls.Config{
+		Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}},
+	}
+	return srv, &tls.Config{RootCAs: pool}
+}
+
+func TestTranspTLS(t *testing.T) {
+	srvConf, cliConf := selfSignedConfig(t)
+	recv := make(chan *sipmsg.Message, 1)
+	var from *Addr
+	srv, err := ListenTLS("127.0.0.1:0", srvConf, func(msg *sipmsg.Message, addr *Addr) {
+		from = addr
+		recv <- msg
+	})
+	assert.Nil(t, err)
+	defer srv.Close()
+	assert.True(t, srv.LocalAddr().IsTLS())
+
+	cliRecv := make(chan *sipmsg.Message, 1)
+	cli := NewTLSClient(cliConf, func(msg *sipmsg.Message, addr *Addr) {
+		assert.True(t, addr.IsTLS())
+		cliRecv <- msg
+	})
+	defer cli.Close()
+	assert.Nil(t, cli.LocalAddr())
+
+	msg, _ := sipmsg.MsgParse([]byte(tlsReq))
+	assert.Nil(t, cli.Send(msg, srv.LocalAddr()))
+	msg = recvMsg(t, recv)
+	assert.Equal(t, "OPTIONS", msg.ReqLine.Method())
+	assert.True(t, from.IsTLS())
+	assert.Equal(t, "127.0.0.1", msg.Vias[0].Received())
+
+	resp, _ := msg.NewResponse(200, "OK")
+	assert.Nil(t, srv.Send(resp, from))
+	resp = recvMsg(t, cliRecv)
+	assert.Equal(t, 200, resp.Code())
+	assert.Equal(t, 1, cli.Len())
+
+	// certificate is not trusted
+	untrusted := NewTLSClient(&tls.Config{}, nil)
+	defer untrusted.Close()
+	assert.NotNil(t, untrusted.Send(msg, srv.LocalAddr()))
+}
+
+func TestTranspTLSServerName(t *testing.T) {
+	srvConf, cliConf := certConfig(t, nil, []string{"biloxi.example.com"})
+	recv := make(chan *sipmsg.Message, 1)
+	srv, err := ListenTLS("127.0.0.1:0", srvConf, func(msg *sipmsg.Message, addr *Addr) {
+		recv <- msg
+	})
+	assert.Nil(t, err)
+	defer srv.Close()
+
+	cli := NewTLSClient(cliConf, nil)
+	defer cli.Close()
+	msg, _ := sipmsg.MsgParse([]byte(tlsReq))
+	// certificate does not have IP address
+	assert.NotNil(t, cli.Send(msg, srv.LocalAddr()))
+
+	// certificate is verified against domain of the sips URI
+	r := NewResolver(&zone{hosts: map[string][]string{"biloxi.example.com": {"127.0.0.1"}}})
+	uri := fmt.Sprintf("sips:bob@biloxi.example.com:%d", srv.LocalAddr().Port())
+	addrs, err := r.Resolve(sipmsg.URIParse([]byte(uri)))
+	assert.Nil(t, err)
+	assert.Equal(t, "biloxi.example.com", addrs[0].Domain())
+	assert.Nil(t, cli.Send(msg, addrs[0]))
+	assert.Equal(t, "OPTIONS", recvMsg(t, recv).ReqLine.Method())
+	assert.Empty(t, cliConf.ServerName)
+}
+
+func TestTranspTLSListenError(t *testing.T) {
+	_, err := ListenTLS("127.0.0.1:0", nil, nil)
+	assert.NotNil(t, err)
+	srvConf, _ := selfSignedConfig(t)
+	_, err = ListenTLS("invalid:address:0", srvConf, nil)
+	assert.NotNil(t, err)
+}
+
+func TestTranspIsSecure(t *testing.T) {
+	msg, _ := sipmsg.MsgParse([]byte(tlsReq))
+	assert.True(t, IsSecure(msg))
+
+	msg, _ = sipmsg.MsgParse([]byte(udpReq))
+	assert.False(t, IsSecure(msg))
+
+	str := "BYE sip:bob@biloxi.example.com SIP/2.0\r\n" +
+		"Via: SIP/2.0/TLS client.atlanta.example.com;branch=z9hG4bKbf9f44\r\n" +
+		"Route: <sips:ss1.example.com;lr>\r\n" +
+		"CSeq: 2 BYE\r\n\r\n"
+	msg, _ = sipmsg.MsgParse([]byte(str))
+	assert.True(t, IsSecure(msg))
+
+	msg, _ = sipmsg.MsgParse([]byte(tlsReq))
+	resp, _ := msg.NewResponse(200, "OK")
+	assert.False(t, IsSecure(resp))
+}
+
+func TestTranspMux(t *testing.T) {
+	srvConf, cliConf := selfSignedConfig(t)
+	recv := make(chan *sipmsg.Message, 1)
+	handler := func(msg *sipmsg.Message, addr *Addr) { recv <- msg }
+
+	srv, err := ListenTLS("127.0.0.1:0", srvConf, handler)
+	assert.Nil(t, err)
+	defer srv.Close()
+	udp, err := ListenUDP("127.0.0.1:0", handler)
+	assert.Nil(t, err)
+
+	mux := NewMux(udp, NewTLSClient(cliConf, nil))
+	defer mux.Close()
+
+	// plain request goes over UDP
+	msg, _ := sipmsg.MsgParse([]byte(udpReq))
+	assert.Nil(t, mux.Send(msg, udp.LocalAddr()))
+	assert.Equal(t, "OPTIONS", recvMsg(t, recv).ReqLine.Method())
+
+	// sips request goes over TLS even to TCP address
+	msg, _ = sipmsg.MsgParse([]byte(tlsReq))
+	assert.Nil(t, mux.Send(msg, TCPAddr(srv.LocalAddr().String())))
+	assert.Equal(t, "sips:bob@biloxi.example.com", recvMsg(t, recv).ReqLine.RequestURI())
+
+	// default port is replaced with default TLS port
+	addr := tlsAddr(UDPAddr("127.0.0.1:5060"))
+	assert.True(t, addr.IsTLS())
+	assert.Equal(t, "127.0.0.1:5061", addr.String())
+	addr = tlsAddr(TCPAddr("127.0.0.1:5080"))
+	assert.True(t, addr.IsTLS())
+	assert.Equal(t, "127.0.0.1:5080", addr.String())
+	addr = tlsAddr(&Addr{addr: addr.addr, proto: UDP, domain: "biloxi.example.com"})
+	assert.Equal(t, "biloxi.example.com", addr.Domain())
+
+	msg, _ = sipmsg.MsgParse([]byte(udpReq))
+	assert.NotNil(t, mux.Send(msg, TCPAddr("127.0.0.1:5060")))
+	assert.NotNil(t, mux.Send(msg, nil))
+}
@@ -0,0 +1,109 @@
+package transp
+
+import (
+	"net"
+	"strings"
+
+	"github.com/staskobzar/gosip/sipmsg"
+)
+
+// ErrorTransport transport layer error
+var ErrorTransport = errorNew("Transport")
+
+// Handler is called by transport for each received SIP message
+// with the source address of the message
+type Handler func(msg *sipmsg.Message, addr *Addr)
+
+// Transport sends SIP messages to remote address
+type Transport interface {
+	Send(msg *sipmsg.Message, addr *Addr) error
+	Proto() Proto
+	Close() error
+}
+
+// Mux sends SIP messages over the transport that matches
+// destination address protocol
+type Mux struct {
+	transports map[Proto]Transport
+}
+
+// NewMux creates transports multiplexer
+func NewMux(transports ...Transport) *Mux {
+	m := &Mux{transports: make(map[Proto]Transport)}
+	for _, t := range transports {
+		m.transports[t.Proto()] = t
+	}
+	return m
+}
+
+// Send sends message over transport of the address protocol.
+// Requests that require secure transport are sent over TLS
+// unless destination is secure WebSocket.
+func (m *Mux) Send(msg *sipmsg.Message, addr *Addr) error {
+	if addr == nil {
+		return ErrorTransport.msg("invalid destination address")
+	}
+	proto := addr.Proto()
+	if IsSecure(msg) && proto != TLS && proto != WSS {
+		proto = TLS
+		addr = tlsAddr(addr)
+	}
+	t, ok := m.transports[proto]
+	if !ok {
+		return ErrorTransport.msg("no transport for %s", addr)
+	}
+	return t.Send(msg, addr)
+}
+
+// tlsAddr returns TLS address for the same host. Default port
+// of the address is replaced with default TLS port.
+func tlsAddr(addr *Addr) *Addr {
+	if addr.Port() != defaultPort(addr.Proto()) || addr.IP() == nil {
+		return &Addr{addr: addr.addr, proto: TLS, domain: addr.domain}
+	}
+	tcp := &net.TCPAddr{IP: addr.IP(), Port: defaultPort(TLS)}
+	return &Addr{addr: tcp, proto: TLS, domain: addr.domain}
+}
+
+// Close closes all transports
+func (m *Mux) Close() error {
+	var err error
+	for _, t := range m.transports {
+		if e := t.Close(); e != nil {
+			err = e
+		}
+	}
+	return err
+}
+
+// IsSecure returns true if request must be sent over TLS because
+// Request-URI or top Route has sips scheme (RFC3261#26.2.2)
+func IsSecure(msg *sipmsg.Message) bool {
+	if !msg.IsRequest() {
+		return false
+	}
+	if isSIPS(msg.ReqLine.RequestURI()) {
+		return true
+	}
+	return len(msg.Routes) > 0 && isSIPS(msg.Routes[0].Addr())
+}
+
+func isSIPS(uri string) bool {
+	return len(uri) > 5 && strings.EqualFold(uri[:5], "sips:")
+}
+
+// setReceived updates top Via header of the request received from addr
+// with received and rport parameters (RFC3261#18.2.1, RFC3581#4)
+func setReceived(msg *sipmsg.Message, addr *Addr) error {
+	if !msg.IsRequest() {
+		return nil
+	}
+	if msg.Vias.Count() == 0 {
+		return ErrorTransport.msg("request has no Via header")
+	}
+	ip := addr.IP()
+	if ip == nil {
+		return nil
+	}
+	return msg.SetViaReceived(ip.String(), addr.Port())
+}
@@ -0,0 +1,107 @@
+package transp
+
+import (
+	"bytes"
+	"net"
+	"sync"
+
+	"github.com/staskobzar/gosip/sipmsg"
+)
+
+// max UDP datagram size
+const udpBufSize = 65535
+
+// UDPTransport UDP transport (RFC3261#18)
+type UDPTransport struct {
+	conn    *net.UDPConn
+	handler Handler
+	done    chan struct{}
+	once    sync.Once
+}
+
+// ListenUDP creates UDP transport bound to address and starts receiving
+// loop. Each received SIP message is passed to handler.
+func ListenUDP(address string, handler Handler) (*UDPTransport, error) {
+	laddr, err := net.ResolveUDPAddr("udp", address)
+	if err != nil {
+		return nil, ErrorTransport.msg("invalid UDP address %s: %s", address, err)
+	}
+	conn, err := net.ListenUDP("udp", laddr)
+	if err != nil {
+		return nil, ErrorTransport.msg("failed to listen %s: %s", address, err)
+	}
+	u := &UDPTransport{
+		conn:    conn,
+		handler: handler,
+		done:    make(chan struct{}),
+	}
+	go u.recvLoop()
+	return u, nil
+}
+
+// Send serializes SIP message and sends it to address
+func (u *UDPTransport) Send(msg *sipmsg.Message, addr *Addr) error {
+	if addr == nil {
+		return ErrorTransport.msg("invalid destination address")
+	}
+	raddr, ok := addr.addr.(*net.UDPAddr)
+	if !ok {
+		return ErrorTransport.msg("UDP address expected: %s", addr)
+	}
+	if _, err := u.conn.WriteToUDP(msg.Bytes(), raddr); err != nil {
+		return ErrorTransport.msg("failed to send to %s: %s", addr, err)
+	}
+	return nil
+}
+
+// LocalAddr returns transport local address
+func (u *UDPTransport) LocalAddr() *Addr {
+	return &Addr{addr: u.conn.LocalAddr()}
+}
+
+// Proto returns transport protocol
+func (u *UDPTransport) Proto() Proto { return UDP }
+
+// Close stops receiving loop and closes socket
+func (u *UDPTransport) Close() error {
+	var err error
+	u.once.Do(func() {
+		close(u.done)
+		err = u.conn.Close()
+	})
+	return err
+}
+
+func (u *UDPTransport) recvLoop() {
+	buf := make([]byte, udpBufSize)
+	for {
+		n, raddr, err := u.conn.ReadFromUDP(buf)
+		if err != nil {
+			select {
+			case <-u.done:
+				return
+			default:
+				continue
+			}
+		}
+		// keep-alive datagrams
+		if len(bytes.TrimSpace(buf[:n])) == 0 {
+			continue
+		}
+		// parsed message keeps references to the data
+		data := make([]byte, n)
+		copy(data, buf[:n])
+
+		msg, err := sipmsg.MsgParse(data)
+		if err != nil {
+			continue
+		}
+		addr := &Addr{addr: raddr}
+		if err := setReceived(msg, addr); err != nil {
+			continue
+		}
+		if u.handler != nil {
+			u.handler(msg, addr)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package transp
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/staskobzar/gosip/sipmsg"
+	"github.com/stretchr/testify/assert"
+)
+
+var udpReq = "OPTIONS sip:bob@biloxi.example.com SIP/2.0\r\n" +
+	"Via: SIP/2.0/UDP client.atlanta.example.com:5060;branch=z9hG4bKbf9f44;rport\r\n" +
+	"Max-Forwards: 70\r\n" +
+	"From: Alice <sip:alice@atlanta.example.com>;tag=9fxced76sl\r\n" +
+	"To: Bob <sip:bob@biloxi.example.com>\r\n" +
+	"Call-ID: 2xTb9vxSit55XU7p8@atlanta.example.com\r\n" +
+	"CSeq: 1 OPTIONS\r\n" +
+	"Content-Length: 0\r\n\r\n"
+
+func TestTranspSetReceived(t *testing.T) {
+	addr := UDPAddr("192.0.2.1:5062")
+
+	msg, _ := sipmsg.MsgParse([]byte(udpReq))
+	assert.Nil(t, setReceived(msg, addr))
+	assert.Equal(t, "192.0.2.1", msg.Vias[0].Received())
+	rport, _ := msg.Vias[0].Param("rport")
+	assert.Equal(t, "5062", rport)
+
+	str := "BYE sip:bob@biloxi.example.com SIP/2.0\r\n" +
+		"Via: SIP/2.0/UDP 192.0.2.1;branch=z9hG4bKbf9f44\r\n" +
+		"CSeq: 2 BYE\r\n\r\n"
+	msg, _ = sipmsg.MsgParse([]byte(str))
+	assert.Nil(t, setReceived(msg, addr))
+	assert.Equal(t, "", msg.Vias[0].Received())
+
+	msg, _ = sipmsg.MsgParse([]byte(str))
+	assert.Nil(t, setReceived(msg, UDPAddr("10.0.0.1:5060")))
+	assert.Equal(t, "10.0.0.1", msg.Vias[0].Received())
+
+	str = "BYE sip:bob@biloxi.example.com SIP/2.0\r\n" +
+		"CSeq: 2 BYE\r\n\r\n"
+	msg, _ = sipmsg.MsgParse([]byte(str))
+	assert.NotNil(t, setReceived(msg, addr))
+}
+
+func TestTranspUDP(t *testing.T) {
+	recv := make(chan *sipmsg.Message, 1)
+	var from *Addr
+	udp, err := ListenUDP("127.0.0.1:0", func(msg *sipmsg.Message, addr *Addr) {
+		from = addr
+		recv <- msg
+	})
+	assert.Nil(t, err)
+	defer udp.Close()
+	assert.True(t, udp.LocalAddr().IsUDP())
+
+	conn, err := net.DialUDP("udp", nil, udp.conn.LocalAddr().(*net.UDPAddr))
+	assert.Nil(t, err)
+	defer conn.Close()
+
+	// keep-alive and invalid messages are ignored
+	conn.Write([]byte("\r\n\r\n"))
+	conn.Write([]byte("Invalid message\r\n\r\n"))
+	conn.Write([]byte(udpReq))
+
+	var msg *sipmsg.Message
+	select {
+	case msg = <-recv:
+	case <-time.After(time.Second):
+		t.Fatal("message was not received")
+	}
+	assert.Equal(t, "OPTIONS", msg.ReqLine.Method())
+	assert.Equal(t, "127.0.0.1", msg.Vias[0].Received())
+	assert.Equal(t, conn.LocalAddr().String(), from.String())
+
+	resp, _ := msg.NewResponse(200, "OK")
+	assert.Nil(t, udp.Send(resp, from))
+
+	buf := make([]byte, 2048)
+	conn.SetReadDeadline(time.Now().Add(time.Second))
+	n, err := conn.Read(buf)
+	assert.Nil(t, err)
+	resp, err = sipmsg.MsgParse(buf[:n])
+	assert.Nil(t, err)
+	assert.Equal(t, 200, resp.Code())
+	assert.Equal(t, "127.0.0.1", resp.Vias[0].Received())
+
+	assert.NotNil(t, udp.Send(resp, nil))
+}
+
+func TestTranspUDPListenError(t *testing.T) {
+	_, err := ListenUDP("invalid:address:0", nil)
+	assert.NotNil(t, err)
+}
+
+func TestTranspUDPSendBody(t *testing.T) {
+	recv := make(chan *sipmsg.Message, 1)
+	srv, err := ListenUDP("127.0.0.1:0", func(msg *sipmsg.Message, addr *Addr) { recv <- msg })
+	assert.Nil(t, err)
+	defer srv.Close()
+	cl, err := ListenUDP("127.0.0.1:0", nil)
+	assert.Nil(t, err)
+	defer cl.Close()
+
+	str := "MESSAGE sip:bob@biloxi.example.com SIP/2.0\r\n" +
+		"Via: SIP/2.0/UDP client.atlanta.example.com:5060;branch=z9hG4bKbf9f44\r\n" +
+		"Max-Forwards: 70\r\n" +
+		"From: Alice <sip:alice@atlanta.example.com>;tag=9fxced76sl\r\n" +
+		"To: Bob <sip:bob@biloxi.example.com>\r\n" +
+		"Call-ID: 2xTb9vxSit55XU7p8@atlanta.example.com\r\n" +
+		"CSeq: 1 MESSAGE\r\n" +
+		"Content-Type: text/plain\r\n" +
+		"Content-Length: 11\r\n\r\n" +
+		"Hello Alice"
+	msg, err := sipmsg.MsgParse([]byte(str))
+	assert.Nil(t, err)
+	assert.Nil(t, cl.Send(msg, srv.LocalAddr()))
+
+	select {
+	case msg = <-recv:
+	case <-time.After(time.Second):
+		t.Fatal("message was not received")
+	}
+	assert.Equal(t, "Hello Alice", string(msg.Body))
+	assert.EqualValues(t, 11, msg.ContentLen)
+}
+
+func TestTranspUDPCloseTwice(t *testing.T) {
+	udp, err := ListenUDP("127.0.0.1:0", nil)
+	assert.Nil(t, err)
+	assert.Nil(t, udp.Close())
+	assert.Nil(t, udp.Close())
+}
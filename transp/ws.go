@@ -0,0 +1,353 @@
+package transp
+
+import (
+	"bufio"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/staskobzar/gosip/sipmsg"
+)
+
+// WebSocket sub-protocol for SIP (RFC7118#4)
+const wsSubProtocol = "sip"
+
+// GUID used to calculate Sec-WebSocket-Accept (RFC6455#1.3)
+const wsGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// max size of WebSocket message payload
+const wsMaxPayload = 1 << 24
+
+// timeout of WebSocket opening handshake
+const wsHandshakeTimeout = 10 * time.Second
+
+// WebSocket frame opcodes (RFC6455#5.2)
+const (
+	wsOpContinuation byte = 0x0
+	wsOpText         byte = 0x1
+	wsOpBinary       byte = 0x2
+	wsOpClose        byte = 0x8
+	wsOpPing         byte = 0x9
+	wsOpPong         byte = 0xa
+)
+
+// WSTransport WebSocket transport for SIP (RFC7118). Each WebSocket
+// message carries one SIP message.
+type WSTransport struct {
+	*stream
+}
+
+// ListenWS creates WebSocket transport listening on address.
+// If config is not nil then secure WebSocket (WSS) is used.
+func ListenWS(address string, config *tls.Config, handler Handler) (*WSTransport, error) {
+	var ln net.Listener
+	var err error
+	proto := WS
+	if config != nil {
+		proto = WSS
+		ln, err = tls.Listen("tcp", address, config)
+	} else {
+		ln, err = net.Listen("tcp", address)
+	}
+	if err != nil {
+		return nil, ErrorTransport.msg("failed to listen %s: %s", address, err)
+	}
+	s := newWSStream(proto, ln, handler, config)
+	s.upgrade = wsAccept
+	return &WSTransport{s.listen()}, nil
+}
+
+// NewWSClient creates WebSocket transport that does not listen and only
+// establishes outgoing connections. If config is not nil then secure
+// WebSocket (WSS) is used.
+func NewWSClient(config *tls.Config, handler Handler) *WSTransport {
+	proto := WS
+	if config != nil {
+		proto = WSS
+	}
+	return &WSTransport{newWSStream(proto, nil, handler, config)}
+}
+
+func newWSStream(proto Proto, ln net.Listener, handler Handler, config *tls.Config) *stream {
+	dial := func(address, domain string) (net.Conn, error) {
+		var conn net.Conn
+		var err error
+		dialer := &net.Dialer{Timeout: tcpDialTimeout}
+		if config != nil {
+			conn, err = tls.DialWithDialer(dialer, "tcp", address, serverConfig(config, domain))
+		} else {
+			conn, err = dialer.Dial("tcp", address)
+		}
+		if err != nil {
+			return nil, err
+		}
+		ws, err := wsConnect(conn, address)
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+		return ws, nil
+	}
+	s := newStream(proto, ln, handler, dial)
+	s.read = s.readWS
+	return s
+}
+
+// wsConn WebSocket connection. Each Write is sent as one text frame.
+type wsConn struct {
+	net.Conn
+	r      *bufio.Reader
+	client bool
+	wmux   *sync.Mutex
+}
+
+func newWSConn(conn net.Conn, r *bufio.Reader, client bool) *wsConn {
+	return &wsConn{Conn: conn, r: r, client: client, wmux: &sync.Mutex{}}
+}
+
+func (c *wsConn) Read(b []byte) (int, error) {
+	return c.r.Read(b)
+}
+
+func (c *wsConn) Write(b []byte) (int, error) {
+	if err := c.writeFrame(wsOpText, b); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+// writeFrame writes single final frame. Client frames are masked (RFC6455#5.3).
+func (c *wsConn) writeFrame(op byte, payload []byte) error {
+	frame := make([]byte, 2, 14+len(payload))
+	frame[0] = 0x80 | op
+	var mbit byte
+	if c.client {
+		mbit = 0x80
+	}
+	switch n := len(payload); {
+	case n < 126:
+		frame[1] = mbit | byte(n)
+	case n <= 0xffff:
+		frame[1] = mbit | 126
+		frame = append(frame, 0, 0)
+		binary.BigEndian.PutUint16(frame[2:], uint16(n))
+	default:
+		frame[1] = mbit | 127
+		frame = append(frame, 0, 0, 0, 0, 0, 0, 0, 0)
+		binary.BigEndian.PutUint64(frame[2:], uint64(n))
+	}
+	if c.client {
+		var key [4]byte
+		if _, err := rand.Read(key[:]); err != nil {
+			return err
+		}
+		frame = append(frame, key[:]...)
+		start := len(frame)
+		frame = append(frame, payload...)
+		wsMask(frame[start:], key)
+	} else {
+		frame = append(frame, payload...)
+	}
+
+	c.wmux.Lock()
+	defer c.wmux.Unlock()
+	_, err := c.Conn.Write(frame)
+	return err
+}
+
+// readWS reads WebSocket message. Control frames are answered
+// and SIP message is parsed from text or binary message payload.
+func (s *stream) readWS(sc *streamConn, r *bufio.Reader) (*sipmsg.Message, error) {
+	ws, ok := sc.conn.(*wsConn)
+	if !ok {
+		return nil, ErrorTransport.msg("WebSocket connection expected")
+	}
+	var payload []byte
+	for {
+		fin, op, data, err := s.readFrame(sc, r, !ws.client)
+		if err != nil {
+			return nil, err
+		}
+		switch op {
+		case wsOpPing:
+			if err := ws.writeFrame(wsOpPong, data); err != nil {
+				return nil, err
+			}
+			continue
+		case wsOpPong:
+			continue
+		case wsOpClose:
+			ws.writeFrame(wsOpClose, nil)
+			return nil, io.EOF
+		case wsOpText, wsOpBinary, wsOpContinuation:
+			payload = append(payload, data...)
+			if len(payload) > wsMaxPayload {
+				return nil, ErrorTransport.msg("WebSocket message too long")
+			}
+		default:
+			return nil, ErrorTransport.msg("invalid WebSocket opcode %d", op)
+		}
+		if fin {
+			break
+		}
+	}
+	if len(strings.TrimSpace(string(payload))) == 0 {
+		return nil, nil
+	}
+	msg, err := sipmsg.MsgParse(payload)
+	if err != nil {
+		// invalid message is discarded and connection is kept
+		return nil, nil
+	}
+	return msg, nil
+}
+
+// readFrame reads one WebSocket frame and unmasks payload. Server
+// closes connection on frame from client that is not masked (RFC6455#5.1).
+func (s *stream) readFrame(sc *streamConn, r *bufio.Reader, mask bool) (bool, byte, []byte, error) {
+	hdr := make([]byte, 2)
+	if _, err := s.readFull(sc, r, hdr); err != nil {
+		return false, 0, nil, err
+	}
+	fin := hdr[0]&0x80 != 0
+	op := hdr[0] & 0x0f
+	masked := hdr[1]&0x80 != 0
+	if mask && !masked {
+		return false, 0, nil, ErrorTransport.msg("WebSocket frame is not masked")
+	}
+
+	n := uint64(hdr[1] & 0x7f)
+	switch n {
+	case 126:
+		ext := make([]byte, 2)
+		if _, err := s.readFull(sc, r, ext); err != nil {
+			return false, 0, nil, err
+		}
+		n = uint64(binary.BigEndian.Uint16(ext))
+	case 127:
+		ext := make([]byte, 8)
+		if _, err := s.readFull(sc, r, ext); err != nil {
+			return false, 0, nil, err
+		}
+		n = binary.BigEndian.Uint64(ext)
+	}
+	if n > wsMaxPayload {
+		return false, 0, nil, ErrorTransport.msg("WebSocket frame too long")
+	}
+
+	var key [4]byte
+	if masked {
+		if _, err := s.readFull(sc, r, key[:]); err != nil {
+			return false, 0, nil, err
+		}
+	}
+	data := make([]byte, n)
+	if _, err := s.readFull(sc, r, data); err != nil {
+		return false, 0, nil, err
+	}
+	if masked {
+		wsMask(data, key)
+	}
+	return fin, op, data, nil
+}
+
+// wsAccept performs server side opening handshake (RFC6455#4.2)
+func wsAccept(conn net.Conn) (net.Conn, error) {
+	conn.SetDeadline(time.Now().Add(wsHandshakeTimeout))
+	r := bufio.NewReader(conn)
+	req, err := http.ReadRequest(r)
+	if err != nil {
+		return nil, err
+	}
+	key := req.Header.Get("Sec-WebSocket-Key")
+	if req.Method != http.MethodGet ||
+		!headerHasToken(req.Header, "Connection", "upgrade") ||
+		!headerHasToken(req.Header, "Upgrade", "websocket") ||
+		req.Header.Get("Sec-WebSocket-Version") != "13" || key == "" {
+		io.WriteString(conn, "HTTP/1.1 400 Bad Request\r\n\r\n")
+		return nil, ErrorTransport.msg("invalid WebSocket handshake")
+	}
+	if !headerHasToken(req.Header, "Sec-WebSocket-Protocol", wsSubProtocol) {
+		io.WriteString(conn, "HTTP/1.1 400 Bad Request\r\n\r\n")
+		return nil, ErrorTransport.msg("WebSocket sub-protocol sip is required")
+	}
+
+	resp := "HTTP/1.1 101 Switching Protocols\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: Upgrade\r\n" +
+		"Sec-WebSocket-Accept: " + wsAcceptKey(key) + "\r\n" +
+		"Sec-WebSocket-Protocol: " + wsSubProtocol + "\r\n\r\n"
+	if _, err := io.WriteString(conn, resp); err != nil {
+		return nil, err
+	}
+	conn.SetDeadline(time.Time{})
+	return newWSConn(conn, r, false), nil
+}
+
+// wsConnect performs client side opening handshake (RFC6455#4.1)
+func wsConnect(conn net.Conn, host string) (net.Conn, error) {
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	key := base64.StdEncoding.EncodeToString(nonce)
+	req := "GET / HTTP/1.1\r\n" +
+		"Host: " + host + "\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: Upgrade\r\n" +
+		"Sec-WebSocket-Key: " + key + "\r\n" +
+		"Sec-WebSocket-Version: 13\r\n" +
+		"Sec-WebSocket-Protocol: " + wsSubProtocol + "\r\n\r\n"
+	conn.SetDeadline(time.Now().Add(wsHandshakeTimeout))
+	if _, err := io.WriteString(conn, req); err != nil {
+		return nil, err
+	}
+
+	r := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(r, nil)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		return nil, ErrorTransport.msg("WebSocket handshake failed: %s", resp.Status)
+	}
+	if resp.Header.Get("Sec-WebSocket-Accept") != wsAcceptKey(key) {
+		return nil, ErrorTransport.msg("invalid Sec-WebSocket-Accept")
+	}
+	if !headerHasToken(resp.Header, "Sec-WebSocket-Protocol", wsSubProtocol) {
+		return nil, ErrorTransport.msg("WebSocket sub-protocol sip is not accepted")
+	}
+	conn.SetDeadline(time.Time{})
+	return newWSConn(conn, r, true), nil
+}
+
+func wsAcceptKey(key string) string {
+	h := sha1.Sum([]byte(key + wsGUID))
+	return base64.StdEncoding.EncodeToString(h[:])
+}
+
+func wsMask(data []byte, key [4]byte) {
+	for i := range data {
+		data[i] ^= key[i%4]
+	}
+}
+
+// headerHasToken returns true if comma separated header values contain token
+func headerHasToken(h http.Header, name, token string) bool {
+	for _, value := range h[http.CanonicalHeaderKey(name)] {
+		for _, t := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(t), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
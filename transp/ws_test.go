@@ -0,0 +1,165 @@
+package transp
+
+import (
+	"bufio"
+	"crypto/tls"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/staskobzar/gosip/sipmsg"
+	"github.com/stretchr/testify/assert"
+)
+
+var wsReq = "OPTIONS sip:bob@biloxi.example.com SIP/2.0\r\n" +
+	"Via: SIP/2.0/WS df7jal23ls0d.invalid;branch=z9hG4bKbf9f44\r\n" +
+	"Max-Forwards: 70\r\n" +
+	"From: Alice <sip:alice@atlanta.example.com>;tag=9fxced76sl\r\n" +
+	"To: Bob <sip:bob@biloxi.example.com>\r\n" +
+	"Call-ID: 2xTb9vxSit55XU7p8@atlanta.example.com\r\n" +
+	"CSeq: 1 OPTIONS\r\n\r\n"
+
+func TestTranspWS(t *testing.T) {
+	recv := make(chan *sipmsg.Message, 1)
+	var from *Addr
+	srv, err := ListenWS("127.0.0.1:0", nil, func(msg *sipmsg.Message, addr *Addr) {
+		from = addr
+		recv <- msg
+	})
+	assert.Nil(t, err)
+	defer srv.Close()
+	assert.True(t, srv.LocalAddr().IsWS())
+
+	cliRecv := make(chan *sipmsg.Message, 1)
+	cli := NewWSClient(nil, func(msg *sipmsg.Message, addr *Addr) {
+		cliRecv <- msg
+	})
+	defer cli.Close()
+
+	msg, _ := sipmsg.MsgParse([]byte(wsReq))
+	assert.Nil(t, cli.Send(msg, srv.LocalAddr()))
+	msg = recvMsg(t, recv)
+	assert.Equal(t, "WS", msg.Vias[0].Transport())
+	assert.Equal(t, "127.0.0.1", msg.Vias[0].Received())
+	assert.True(t, from.IsWS())
+
+	resp, _ := msg.NewResponse(200, "OK")
+	assert.Nil(t, srv.Send(resp, from))
+	resp = recvMsg(t, cliRecv)
+	assert.Equal(t, 200, resp.Code())
+}
+
+func TestTranspWSS(t *testing.T) {
+	srvConf, cliConf := selfSignedConfig(t)
+	recv := make(chan *sipmsg.Message, 1)
+	srv, err := ListenWS("127.0.0.1:0", srvConf, func(msg *sipmsg.Message, addr *Addr) {
+		recv <- msg
+	})
+	assert.Nil(t, err)
+	defer srv.Close()
+	assert.True(t, srv.LocalAddr().IsWSS())
+
+	cli := NewWSClient(cliConf, nil)
+	defer cli.Close()
+
+	// large message uses extended payload length
+	msg, _ := sipmsg.MsgParse([]byte(wsReq))
+	msg.Body = make([]byte, 70000)
+	assert.Nil(t, cli.Send(msg, srv.LocalAddr()))
+	msg = recvMsg(t, recv)
+	assert.Equal(t, 70000, len(msg.Body))
+}
+
+// wsDial connects to WebSocket server with raw connection
+func wsDial(t *testing.T, addr *Addr, proto string) (net.Conn, *bufio.Reader, *http.Response) {
+	conn, err := net.Dial("tcp", addr.String())
+	assert.Nil(t, err)
+	req := "GET / HTTP/1.1\r\n" +
+		"Host: " + addr.String() + "\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: Upgrade\r\n" +
+		"Sec-WebSocket-Key: dGhlIHNhbXBsZSBub25jZQ==\r\n" +
+		"Sec-WebSocket-Version: 13\r\n"
+	if proto != "" {
+		req += "Sec-WebSocket-Protocol: " + proto + "\r\n"
+	}
+	io.WriteString(conn, req+"\r\n")
+	r := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(r, nil)
+	assert.Nil(t, err)
+	return conn, r, resp
+}
+
+func TestTranspWSHandshake(t *testing.T) {
+	srv, err := ListenWS("127.0.0.1:0", nil, nil)
+	assert.Nil(t, err)
+	defer srv.Close()
+
+	conn, _, resp := wsDial(t, srv.LocalAddr(), "")
+	conn.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	conn, r, resp := wsDial(t, srv.LocalAddr(), "chat, sip")
+	defer conn.Close()
+	assert.Equal(t, http.StatusSwitchingProtocols, resp.StatusCode)
+	assert.Equal(t, "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=", resp.Header.Get("Sec-WebSocket-Accept"))
+	assert.Equal(t, "sip", resp.Header.Get("Sec-WebSocket-Protocol"))
+
+	// masked ping is answered with pong carrying the same payload
+	conn.Write([]byte{0x89, 0x82, 1, 2, 3, 4, 'h' ^ 1, 'i' ^ 2})
+	frame := make([]byte, 4)
+	_, err = io.ReadFull(r, frame)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{0x8a, 0x02, 'h', 'i'}, frame)
+
+	// close frame is echoed
+	conn.Write([]byte{0x88, 0x80, 1, 2, 3, 4})
+	_, err = io.ReadFull(r, frame[:2])
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{0x88, 0x00}, frame[:2])
+}
+
+func TestTranspWSFragmented(t *testing.T) {
+	recv := make(chan *sipmsg.Message, 1)
+	srv, err := ListenWS("127.0.0.1:0", nil, func(msg *sipmsg.Message, addr *Addr) {
+		recv <- msg
+	})
+	assert.Nil(t, err)
+	defer srv.Close()
+
+	conn, _, _ := wsDial(t, srv.LocalAddr(), "sip")
+	defer conn.Close()
+
+	// message in fragments masked with zero key
+	conn.Write(append([]byte{0x01, 0x80 | 100, 0, 0, 0, 0}, wsReq[:100]...))
+	conn.Write(append([]byte{0x00, 0x80 | 100, 0, 0, 0, 0}, wsReq[100:200]...))
+	rest := len(wsReq) - 200
+	conn.Write(append([]byte{0x80, 0x80 | 126, 0, byte(rest), 0, 0, 0, 0}, wsReq[200:]...))
+	msg := recvMsg(t, recv)
+	assert.Equal(t, "OPTIONS", msg.ReqLine.Method())
+	assert.Equal(t, "2xTb9vxSit55XU7p8@atlanta.example.com", msg.CallID)
+}
+
+func TestTranspWSUnmasked(t *testing.T) {
+	srv, err := ListenWS("127.0.0.1:0", nil, nil)
+	assert.Nil(t, err)
+	defer srv.Close()
+
+	conn, r, _ := wsDial(t, srv.LocalAddr(), "sip")
+	defer conn.Close()
+
+	// server closes connection on unmasked client frame
+	conn.Write([]byte{0x89, 0x02, 'h', 'i'})
+	conn.SetReadDeadline(time.Now().Add(time.Second))
+	_, err = r.ReadByte()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestTranspWSListenError(t *testing.T) {
+	_, err := ListenWS("invalid:address:0", nil, nil)
+	assert.NotNil(t, err)
+	_, err = ListenWS("invalid:address:0", &tls.Config{}, nil)
+	assert.NotNil(t, err)
+}
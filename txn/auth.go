@@ -0,0 +1,56 @@
+package txn
+
+import (
+	"github.com/staskobzar/gosip/sipmsg"
+)
+
+// Authorizer re-sends requests challenged with 401 or 407
+// responses with credentials (RFC3261#22.2). Nonce count is
+// tracked per realm to reuse credentials.
+type Authorizer struct {
+	layer   *Layer
+	creds   sipmsg.CredentialsFunc
+	counter *sipmsg.NonceCounter
+}
+
+// NewAuthorizer creates authorizer that sends authorized requests
+// with layer client transactions. Credentials callback returns
+// username and password for the challenge realm.
+func NewAuthorizer(layer *Layer, creds sipmsg.CredentialsFunc) *Authorizer {
+	return &Authorizer{
+		layer:   layer,
+		creds:   creds,
+		counter: sipmsg.NewNonceCounter(),
+	}
+}
+
+// IsChallenge returns true if message is 401 or 407 response
+func IsChallenge(tm *Message) bool {
+	if tm == nil || tm.Msg == nil || !tm.Msg.IsResponse() {
+		return false
+	}
+	code := tm.Msg.Code()
+	return code == 401 || code == 407
+}
+
+// Retry creates authorized request for the challenge response passed
+// to TU and sends it with new client transaction. Request and destination
+// are taken from the client transaction that received the response.
+// Transaction can be already terminated and removed from the layer.
+func (a *Authorizer) Retry(cl *Client, resp *Message) (*Client, error) {
+	if !IsChallenge(resp) {
+		return nil, ErrorTxnClient.msg("401 or 407 response expected")
+	}
+	key, err := txnKey(resp.Msg, false)
+	if err != nil {
+		return nil, err
+	}
+	if ckey, err := txnKey(cl.request, false); err != nil || ckey != key {
+		return nil, ErrorTxnClient.msg("response does not match client transaction")
+	}
+	req, err := cl.request.NewAuthorized(resp.Msg, a.creds, a.counter)
+	if err != nil {
+		return nil, err
+	}
+	return a.layer.Request(&Message{Msg: req, Addr: cl.addr})
+}
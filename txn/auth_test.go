@@ -0,0 +1,68 @@
+package txn
+
+import (
+	"testing"
+
+	"github.com/staskobzar/gosip/sipmsg"
+	"github.com/staskobzar/gosip/transp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTxnAuthorizerRetry(t *testing.T) {
+	tu := make(chan *Message)
+	tr := make(chan *Message)
+	l := NewLayer(tu, tr, nil)
+	defer l.Close()
+
+	srv := sipmsg.NewAuthenticator("biloxi.example.com", []byte("secret"),
+		sipmsg.HA1Func(func(user, realm string, algo sipmsg.AlgoType) (string, bool) {
+			return sipmsg.DigestHA1(algo, user, realm, "zanzibar"), user == "alice"
+		}))
+	a := NewAuthorizer(l, func(realm string) (string, string, bool) {
+		return "alice", "zanzibar", realm == "biloxi.example.com"
+	})
+
+	addr := transp.UDPAddr("10.0.0.1:5060")
+	req := parseMsg(t, layerReq)
+	first, err := l.Request(&Message{req, addr})
+	assert.Nil(t, err)
+	<-tr
+
+	_, resp, err := srv.Authenticate(req)
+	assert.Nil(t, err)
+	assert.Nil(t, l.Recv(&Message{resp, addr}))
+	tm := <-tu
+	assert.True(t, IsChallenge(tm))
+
+	// challenged transaction is removed from the layer
+	first.mux.Lock()
+	first.terminate()
+	first.mux.Unlock()
+	l.sweep()
+	assert.Equal(t, 0, l.Len())
+
+	cl, err := a.Retry(first, tm)
+	assert.Nil(t, err)
+	assert.NotNil(t, cl)
+	tm = <-tr
+	assert.Equal(t, addr, tm.Addr)
+	assert.EqualValues(t, 2, tm.Msg.CSeq.Num)
+	assert.NotEqual(t, req.Vias[0].Branch(), tm.Msg.Vias[0].Branch())
+	cr, _, err := srv.Authenticate(tm.Msg)
+	assert.Nil(t, err)
+	assert.Equal(t, "alice", cr.Username())
+	assert.Equal(t, 1, l.Len())
+
+	// not a challenge
+	ok, _ := req.NewResponse(200, "OK")
+	_, err = a.Retry(first, &Message{ok, addr})
+	assert.NotNil(t, err)
+	assert.False(t, IsChallenge(&Message{ok, addr}))
+
+	// challenge for other transaction
+	other := parseMsg(t, layerReq)
+	other.SetViaParam("branch", "z9hG4bKother")
+	_, resp, _ = srv.Authenticate(other)
+	_, err = a.Retry(first, &Message{resp, addr})
+	assert.NotNil(t, err)
+}
@@ -4,6 +4,7 @@ package txn
 import (
 	"context"
 	"sync"
+	"time"
 
 	"github.com/staskobzar/gosip/sipmsg"
 	"github.com/staskobzar/gosip/transp"
@@ -15,13 +16,15 @@ var ErrorTxnClient = errorNew("Transaction Client")
 // State of transaction state machine
 type State uint8
 
-// Client transaction states
+// Client and server transaction states
 const (
 	Idle State = iota
 	Calling
 	Proceeding
 	Completed
 	Terminated
+	Trying
+	Confirmed
 )
 
 // Message transaction message structure
@@ -37,12 +40,16 @@ type Client struct {
 	state    State
 	request  *sipmsg.Message
 	response *sipmsg.Message
+	ack      *sipmsg.Message
 	addr     *transp.Addr
 	cancel   context.CancelFunc
 	timer    *Timer
 	mux      *sync.Mutex
 	chTU     chan *Message
 	chTransp chan *Message
+	out      queue
+	// CANCEL waiting for provisional response
+	pendCancel func()
 }
 
 // NewClient creates new client transaction RFC3261#17
@@ -85,70 +92,185 @@ func (cl *Client) Recv(tm *Message) error {
 	if tm.Msg.IsRequest() {
 		return ErrorTxnClient.msg("sip response expected")
 	}
+	if code := tm.Msg.Code(); code < 100 || code > 699 {
+		return ErrorTxnClient.msg("invalid response status code")
+	}
+	cl.mux.Lock()
+	defer cl.mux.Unlock()
+	if cl.state == Terminated {
+		return ErrorTxnClient.msg("transaction is terminated")
+	}
+	go cl.handle(tm)
+	return nil
+}
+
+// handle updates state machine with response. Responses are handled
+// concurrently so state is checked again under the lock.
+func (cl *Client) handle(tm *Message) {
+	cl.mux.Lock()
+	var out []delivery
 	switch cl.state {
 	case Calling:
-		cl.smInvCalling(tm)
+		out = cl.smInvCalling(tm)
+	case Trying:
+		out = cl.smTrying(tm)
 	case Proceeding:
-		cl.smInvProceeding(tm)
+		if cl.request.IsInvite() {
+			out = cl.smInvProceeding(tm)
+		} else {
+			out = cl.smProceeding(tm)
+		}
+	case Completed:
+		if cl.request.IsInvite() {
+			out = cl.smInvCompleted(tm)
+		}
+		// retransmissions of the final response to non-INVITE are absorbed
+	}
+	// pending CANCEL is sent on provisional response and
+	// dropped on final response
+	cancel := cl.pendCancel
+	if cl.state != Calling {
+		cl.pendCancel = nil
+	}
+	if cl.state != Proceeding {
+		cancel = nil
+	}
+	send := cl.out.push(out...)
+	cl.mux.Unlock()
+	if cancel != nil {
+		cancel()
+	}
+	send()
+}
+
+// Cancel cancels INVITE transaction (RFC3261#9.1). CANCEL request
+// is passed to send callback that should start new client transaction.
+// If provisional response was not received yet then CANCEL is sent
+// when it arrives. Final response to INVITE (usually 487) is handled
+// by the transaction as any other final response.
+func (cl *Client) Cancel(send func(tm *Message) error) error {
+	if !cl.request.IsInvite() {
+		return ErrorTxnClient.msg("only INVITE transaction can be cancelled")
+	}
+	req, err := cl.request.NewCANCEL()
+	if err != nil {
+		return err
+	}
+	tm := &Message{req, cl.addr}
+
+	cl.mux.Lock()
+	switch cl.state {
+	case Calling:
+		cl.pendCancel = func() { send(tm) }
+		cl.mux.Unlock()
+		return nil
+	case Proceeding:
+		cl.mux.Unlock()
+		return send(tm)
+	}
+	cl.mux.Unlock()
+	return ErrorTxnClient.msg("final response is received")
+}
+
+// Must be called with locked mutex as other state machine handlers.
+// Returns messages to send after mutex is unlocked.
+func (cl *Client) smInvCalling(tm *Message) []delivery {
+	var out []delivery
+	switch code := tm.Msg.Code(); {
+	case code >= 100 && code < 200:
+		cl.state = Proceeding
+	case code >= 200 && code < 300:
+		cl.terminate()
 	default:
-		panic("INVALID STATE")
+		out = append(out, cl.invCompleted(tm)...)
 	}
-	return nil
+	return append(out, delivery{cl.chTU, tm})
 }
 
-func (cl *Client) smInvCalling(tm *Message) {
-	go func() {
-		cl.mux.Lock()
-		defer cl.mux.Unlock()
-		switch code := tm.Msg.Code(); {
-		case code >= 100 && code < 200:
-			cl.state = Proceeding
-		case code >= 200 && code < 300:
-			cl.terminate()
-		case code >= 300 && code <= 699:
-			// completed
-			cl.state = Completed
-			ack, err := cl.request.NewACK(tm.Msg)
-			if err != nil {
-				panic(err)
-			}
-			// send ACK
-			cl.chTransp <- &Message{ack, cl.addr}
-		default:
-			panic("INVALID CODE WHILE CALLING")
-		}
-		cl.chTU <- tm
-	}()
+func (cl *Client) smInvProceeding(tm *Message) []delivery {
+	var out []delivery
+	switch code := tm.Msg.Code(); {
+	case code >= 100 && code < 200:
+	case code >= 200 && code < 300:
+		cl.terminate()
+	default:
+		out = append(out, cl.invCompleted(tm)...)
+	}
+	return append(out, delivery{cl.chTU, tm})
+}
+
+// smInvCompleted re-sends ACK on retransmitted final response.
+// Response is not passed to TU.
+func (cl *Client) smInvCompleted(tm *Message) []delivery {
+	if tm.Msg.Code() < 300 {
+		return nil
+	}
+	return []delivery{{cl.chTransp, &Message{cl.ack, cl.addr}}}
 }
 
-func (cl *Client) smInvProceeding(tm *Message) {
+// invCompleted creates ACK for non-2xx final response and starts
+// timer D (RFC3261#17.1.1.2). Transaction is terminated when ACK
+// can not be created. Must be called with locked mutex.
+func (cl *Client) invCompleted(tm *Message) []delivery {
+	ack, err := cl.request.NewACK(tm.Msg)
+	if err != nil {
+		cl.terminate()
+		return nil
+	}
+	cl.cancel()
+	cl.state = Completed
+	cl.ack = ack
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cl.cancel = cancel
+
+	wait := cl.timer.D
+	if !cl.addr.IsUDP() {
+		wait = 0
+	}
 	go func() {
-		cl.mux.Lock()
-		defer cl.mux.Unlock()
-		switch code := tm.Msg.Code(); {
-		case code >= 100 && code < 200:
-			cl.chTU <- tm
-		case code >= 200 && code < 300:
-			cl.chTU <- tm
-			cl.terminate()
-		case code >= 300 && code <= 699:
-			// completed
-			cl.state = Completed
-			ack, err := cl.request.NewACK(tm.Msg)
-			if err != nil {
-				panic(err)
+		select {
+		case <-ctx.Done():
+		case <-time.After(wait):
+			cl.mux.Lock()
+			defer cl.mux.Unlock()
+			if cl.state == Completed {
+				cl.terminate()
 			}
-			// send ACK
-			cl.chTransp <- &Message{ack, cl.addr}
-		default:
-			panic("INVALID CODE WHILE PROCEEDING")
 		}
 	}()
+	return []delivery{{cl.chTransp, &Message{ack, cl.addr}}}
+}
+
+func (cl *Client) smTrying(tm *Message) []delivery {
+	switch code := tm.Msg.Code(); {
+	case code >= 100 && code < 200:
+		cl.state = Proceeding
+	default:
+		cl.completed()
+	}
+	return []delivery{{cl.chTU, tm}}
+}
+
+func (cl *Client) smProceeding(tm *Message) []delivery {
+	switch code := tm.Msg.Code(); {
+	case code >= 100 && code < 200:
+	default:
+		cl.completed()
+	}
+	return []delivery{{cl.chTU, tm}}
+}
+
+// State returns current state of the transaction
+func (cl *Client) State() State {
+	cl.mux.Lock()
+	defer cl.mux.Unlock()
+	return cl.state
 }
 
 // IsTerminated returns true if Client state is Terminated
 func (cl *Client) IsTerminated() bool {
-	return cl.state == Terminated
+	return cl.State() == Terminated
 }
 
 func (cl *Client) invite() {
@@ -168,7 +290,7 @@ func (cl *Client) calling(ctx context.Context) {
 			case <-ctx.Done():
 				return
 			case <-cl.timer.nextA():
-				if cl.state != Calling {
+				if cl.State() != Calling {
 					return
 				}
 			}
@@ -181,18 +303,105 @@ func (cl *Client) timerB(ctx context.Context) {
 		select {
 		case <-ctx.Done():
 		case <-cl.timer.fireB():
-			cl.terminate()
-			if toutResp, err := cl.request.NewResponse(408, "Request Timeout"); err == nil {
-				cl.chTU <- &Message{toutResp, nil}
-			}
+			cl.timeout(Calling)
 		}
 	}()
 }
 
+// timeout terminates transaction if it is in one of the states
+// and passes locally generated 408 response to TU
+func (cl *Client) timeout(states ...State) {
+	cl.mux.Lock()
+	found := false
+	for _, state := range states {
+		found = found || cl.state == state
+	}
+	if !found {
+		cl.mux.Unlock()
+		return
+	}
+	cl.terminate()
+	var out []delivery
+	if toutResp, err := cl.request.NewResponse(408, "Request Timeout"); err == nil {
+		out = append(out, delivery{cl.chTU, &Message{toutResp, nil}})
+	}
+	send := cl.out.push(out...)
+	cl.mux.Unlock()
+	send()
+}
+
 func (cl *Client) terminate() {
 	cl.cancel()
 	cl.state = Terminated
 }
 
 func (cl *Client) nonInvite() {
+	ctx, cancel := context.WithCancel(context.Background())
+	cl.cancel = cancel
+
+	cl.trying(ctx)
+	cl.timerF(ctx)
+}
+
+// trying sends request and retransmits it with timer E
+// while transaction is in Trying or Proceeding state (RFC3261#17.1.2.2)
+func (cl *Client) trying(ctx context.Context) {
+	cl.state = Trying
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case cl.chTransp <- &Message{cl.request, cl.addr}:
+			}
+			// timer E is set only for unreliable transport
+			if !cl.addr.IsUDP() {
+				return
+			}
+			cl.mux.Lock()
+			fire := cl.timer.nextE(cl.state == Proceeding)
+			cl.mux.Unlock()
+			select {
+			case <-ctx.Done():
+				return
+			case <-fire:
+			}
+		}
+	}()
+}
+
+func (cl *Client) timerF(ctx context.Context) {
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-cl.timer.fireF():
+			cl.timeout(Trying, Proceeding)
+		}
+	}()
+}
+
+// completed stops request retransmissions and starts timer K
+// to absorb response retransmissions. Must be called with locked mutex.
+func (cl *Client) completed() {
+	cl.cancel()
+	cl.state = Completed
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cl.cancel = cancel
+
+	wait := cl.timer.K
+	if !cl.addr.IsUDP() {
+		wait = 0
+	}
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-time.After(wait):
+			cl.mux.Lock()
+			defer cl.mux.Unlock()
+			if cl.state == Completed {
+				cl.terminate()
+			}
+		}
+	}()
 }
@@ -89,7 +89,7 @@ func TestTxnInvClientStateCalling1XXResp(t *testing.T) {
 	tm := <-cl.chTU
 	assert.Equal(t, "180", tm.Msg.StatusLine.Code())
 	assert.False(t, cl.IsTerminated())
-	assert.Equal(t, Proceeding, cl.state)
+	assert.Equal(t, Proceeding, cl.State())
 	cl.terminate()
 }
 
@@ -138,7 +138,7 @@ func TestTxnInvClientStateCalling3XXResp(t *testing.T) {
 	assert.Equal(t, "302", tm.Msg.StatusLine.Code())
 	assert.True(t, ackTm.Msg.IsRequest())
 	assert.Equal(t, "ACK", ackTm.Msg.ReqLine.Method())
-	assert.Equal(t, Completed, cl.state)
+	assert.Equal(t, Completed, cl.State())
 	cl.terminate()
 }
 
@@ -158,21 +158,206 @@ func TestTxnInvClientStateProceeding1XXResp(t *testing.T) {
 	cl.Recv(&Message{resp, cl.addr})
 	tm := <-cl.chTU
 	assert.Equal(t, "100", tm.Msg.StatusLine.Code())
-	assert.Equal(t, Proceeding, cl.state)
+	assert.Equal(t, Proceeding, cl.State())
 
 	resp, err = msg.NewResponse(180, "Ringing")
 	assert.Nil(t, err)
 	cl.Recv(&Message{resp, cl.addr})
 	tm = <-cl.chTU
 	assert.Equal(t, "180", tm.Msg.StatusLine.Code())
-	assert.Equal(t, Proceeding, cl.state)
+	assert.Equal(t, Proceeding, cl.State())
 
 	resp, err = msg.NewResponse(183, "Session progress")
 	assert.Nil(t, err)
 	cl.Recv(&Message{resp, cl.addr})
 	tm = <-cl.chTU
 	assert.Equal(t, "183", tm.Msg.StatusLine.Code())
-	assert.Equal(t, Proceeding, cl.state)
+	assert.Equal(t, Proceeding, cl.State())
 
 	cl.terminate()
 }
+
+func initNonInvite() *sipmsg.Message {
+	from := sipmsg.NewHdrFrom("Bob Smith", "sip:bob@voip.com", nil)
+	to := sipmsg.NewHdrTo("", "sip:bob@voip.com", nil)
+
+	msg, err := sipmsg.NewRequest("REGISTER", "sip:voip.com", nil, to, from, 1, 70)
+	if err != nil {
+		return nil
+	}
+	return msg
+}
+
+func initNonInvClient(t1 time.Duration) *Client {
+	return &Client{
+		request:  initNonInvite(),
+		addr:     transp.UDPAddr("10.0.0.1:5060"),
+		mux:      &sync.Mutex{},
+		chTU:     make(chan *Message),
+		chTransp: make(chan *Message),
+		timer:    initTimer(t1),
+	}
+}
+
+func TestTxnNonInvClientStateTryingRetrans(t *testing.T) {
+	cl := initNonInvClient(5 * time.Millisecond)
+	cl.timer.F = 100 * time.Millisecond
+	cl.nonInvite()
+	assert.Equal(t, Trying, cl.State())
+
+	var retrans int
+	var respCode string
+	var timeout bool
+Loop:
+	for {
+		select {
+		case <-time.After(1000 * time.Millisecond):
+			timeout = true
+			break Loop
+		case tm := <-cl.chTU:
+			respCode = tm.Msg.StatusLine.Code()
+			break Loop
+		case <-cl.chTransp:
+			retrans += 1
+		}
+	}
+	assert.False(t, timeout)
+	assert.Equal(t, "408", respCode)
+	assert.Equal(t, 5, retrans)
+	assert.True(t, cl.IsTerminated())
+}
+
+func TestTxnNonInvClientStateTrying1XXResp(t *testing.T) {
+	cl := initNonInvClient(0)
+	cl.nonInvite()
+	<-cl.chTransp
+
+	resp, err := cl.request.NewResponse(100, "Trying")
+	assert.Nil(t, err)
+	cl.Recv(&Message{resp, cl.addr})
+	tm := <-cl.chTU
+	assert.Equal(t, "100", tm.Msg.StatusLine.Code())
+	assert.Equal(t, Proceeding, cl.State())
+
+	resp, err = cl.request.NewResponse(100, "Trying")
+	assert.Nil(t, err)
+	cl.Recv(&Message{resp, cl.addr})
+	tm = <-cl.chTU
+	assert.Equal(t, "100", tm.Msg.StatusLine.Code())
+	assert.Equal(t, Proceeding, cl.State())
+	cl.terminate()
+}
+
+func TestTxnNonInvClientStateTryingFinalResp(t *testing.T) {
+	cl := initNonInvClient(0)
+	cl.timer.K = 10 * time.Millisecond
+	cl.nonInvite()
+	<-cl.chTransp
+
+	resp, err := cl.request.NewResponse(200, "OK")
+	assert.Nil(t, err)
+	cl.Recv(&Message{resp, cl.addr})
+	tm := <-cl.chTU
+	assert.Equal(t, "200", tm.Msg.StatusLine.Code())
+	assert.Equal(t, Completed, cl.State())
+
+	// retransmitted response is absorbed
+	err = cl.Recv(&Message{resp, cl.addr})
+	assert.Nil(t, err)
+
+	<-time.After(50 * time.Millisecond)
+	assert.True(t, cl.IsTerminated())
+
+	err = cl.Recv(&Message{resp, cl.addr})
+	assert.NotNil(t, err)
+}
+
+func TestTxnNonInvClientStateProceedingFinalResp(t *testing.T) {
+	cl := initNonInvClient(0)
+	cl.timer.K = 10 * time.Millisecond
+	cl.nonInvite()
+	<-cl.chTransp
+
+	resp, err := cl.request.NewResponse(180, "Ringing")
+	assert.Nil(t, err)
+	cl.Recv(&Message{resp, cl.addr})
+	tm := <-cl.chTU
+	assert.Equal(t, Proceeding, cl.State())
+
+	resp, err = cl.request.NewResponse(404, "Not Found")
+	assert.Nil(t, err)
+	cl.Recv(&Message{resp, cl.addr})
+	tm = <-cl.chTU
+	assert.Equal(t, "404", tm.Msg.StatusLine.Code())
+	assert.Equal(t, Completed, cl.State())
+
+	<-time.After(50 * time.Millisecond)
+	assert.True(t, cl.IsTerminated())
+}
+
+func TestTxnNonInvClientTimerE(t *testing.T) {
+	tm := initTimer(5 * time.Millisecond)
+	<-tm.nextE(false)
+	assert.Equal(t, 10*time.Millisecond, tm.E)
+	tm.E = 3 * time.Second
+	tm.T2 = 4 * time.Second
+	tm.nextE(false)
+	assert.Equal(t, 4*time.Second, tm.E)
+	tm.E = 0
+	tm.nextE(true)
+	assert.Equal(t, 4*time.Second, tm.E)
+}
+
+func TestTxnInvClientStateCompletedRetrans(t *testing.T) {
+	msg := initInvite()
+	cl := &Client{
+		request:  msg,
+		addr:     transp.UDPAddr("10.0.0.1:5060"),
+		mux:      &sync.Mutex{},
+		chTU:     make(chan *Message),
+		chTransp: make(chan *Message),
+		timer:    initTimer(0),
+	}
+	cl.timer.D = 10 * time.Millisecond
+	cl.invite()
+	<-cl.chTransp
+
+	resp, err := msg.NewResponse(486, "Busy Here")
+	assert.Nil(t, err)
+	resp.AddToTag()
+	cl.Recv(&Message{resp, cl.addr})
+	<-cl.chTransp
+	<-cl.chTU
+	assert.Equal(t, Completed, cl.State())
+
+	// retransmitted final response re-sends ACK
+	cl.Recv(&Message{resp, cl.addr})
+	ackTm := <-cl.chTransp
+	assert.Equal(t, "ACK", ackTm.Msg.ReqLine.Method())
+
+	<-time.After(50 * time.Millisecond)
+	assert.True(t, cl.IsTerminated())
+}
+
+func TestTxnClientInvalidRespCode(t *testing.T) {
+	msg := initInvite()
+	chTransp := make(chan *Message, 10)
+	cl, err := NewClient(&Message{msg, transp.UDPAddr("10.0.0.1:5060")}, make(chan *Message), chTransp)
+	assert.Nil(t, err)
+
+	resp, err := sipmsg.MsgParse([]byte("SIP/2.0 799 Foo\r\n" +
+		"Via: SIP/2.0/UDP pc33.atlanta.com;branch=z9hG4bK776asdhds\r\n" +
+		"To: <sip:alice@atlanta.com>\r\n" +
+		"From: <sip:bob@voip.com>;tag=1928301774\r\n" +
+		"Call-ID: a84b4c76e66710\r\n" +
+		"CSeq: 102 INVITE\r\n" +
+		"Content-Length: 0\r\n\r\n"))
+	assert.Nil(t, err)
+	err = cl.Recv(&Message{resp, transp.UDPAddr("10.0.0.1:5060")})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "invalid response status code")
+	assert.Equal(t, Calling, cl.State())
+	cl.mux.Lock()
+	cl.terminate()
+	cl.mux.Unlock()
+}
@@ -0,0 +1,261 @@
+package txn
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/staskobzar/gosip/sipmsg"
+	"github.com/staskobzar/gosip/transp"
+)
+
+// ErrorTxnLayer transaction layer error
+var ErrorTxnLayer = errorNew("Transaction Layer")
+
+// interval of removing terminated transactions from layer
+const gcInterval = time.Second
+
+// Layer transaction layer that owns client and server transactions
+// and matches messages to them (RFC3261#17.1.3 and #17.2.3)
+type Layer struct {
+	mux       *sync.Mutex
+	clients   map[string]*Client
+	servers   map[string]*Server
+	chTU      chan *Message
+	chTransp  chan *Message
+	unmatched func(tm *Message)
+	done      chan struct{}
+}
+
+// NewLayer creates transaction layer. Transactions pass messages
+// to TU and transport channels. Responses that do not match any
+// client transaction and ACKs that do not match server transaction
+// are passed to unmatched callback.
+func NewLayer(tu chan *Message, transp chan *Message, unmatched func(tm *Message)) *Layer {
+	l := &Layer{
+		mux:       &sync.Mutex{},
+		clients:   make(map[string]*Client),
+		servers:   make(map[string]*Server),
+		chTU:      tu,
+		chTransp:  transp,
+		unmatched: unmatched,
+		done:      make(chan struct{}),
+	}
+	go l.gc()
+	return l
+}
+
+// Request creates new client transaction for the request from TU
+func (l *Layer) Request(tm *Message) (*Client, error) {
+	if tm.Msg == nil || !tm.Msg.IsRequest() {
+		return nil, ErrorTxnLayer.msg("sip request expected")
+	}
+	key, err := txnKey(tm.Msg, false)
+	if err != nil {
+		return nil, err
+	}
+
+	l.mux.Lock()
+	defer l.mux.Unlock()
+	if cl, ok := l.clients[key]; ok && !cl.IsTerminated() {
+		return nil, ErrorTxnLayer.msg("client transaction exists")
+	}
+
+	cl, err := NewClient(tm, l.chTU, l.chTransp)
+	if err != nil {
+		return nil, err
+	}
+	l.clients[key] = cl
+	return cl, nil
+}
+
+// Cancel cancels INVITE client transaction (RFC3261#9.1). CANCEL
+// client transaction is created when provisional response is received.
+func (l *Layer) Cancel(cl *Client) error {
+	return cl.Cancel(func(tm *Message) error {
+		_, err := l.Request(tm)
+		return err
+	})
+}
+
+// Respond passes response from TU to server transaction of the request.
+// Server transaction is matched by request because response to RFC2543
+// request does not have Request-URI and To tag the request is matched with.
+func (l *Layer) Respond(req *sipmsg.Message, tm *Message) error {
+	if req == nil || !req.IsRequest() {
+		return ErrorTxnLayer.msg("sip request expected")
+	}
+	if tm.Msg == nil || !tm.Msg.IsResponse() {
+		return ErrorTxnLayer.msg("sip response expected")
+	}
+	key, err := txnKey(req, true)
+	if err != nil {
+		return err
+	}
+
+	l.mux.Lock()
+	srv, ok := l.servers[key]
+	l.mux.Unlock()
+
+	if !ok || srv.IsTerminated() {
+		return ErrorTxnLayer.msg("server transaction not found")
+	}
+	return srv.Send(tm)
+}
+
+// Recv matches message received from transport to transaction.
+// Request that does not match any server transaction creates
+// new server transaction except ACK that is passed to unmatched callback.
+func (l *Layer) Recv(tm *Message) error {
+	if tm.Msg == nil {
+		return ErrorTxnLayer.msg("invalid sip message")
+	}
+	key, err := txnKey(tm.Msg, tm.Msg.IsRequest())
+	if err != nil {
+		return err
+	}
+
+	if tm.Msg.IsResponse() {
+		l.mux.Lock()
+		cl, ok := l.clients[key]
+		l.mux.Unlock()
+		if !ok || cl.IsTerminated() {
+			l.passUnmatched(tm)
+			return nil
+		}
+		return cl.Recv(tm)
+	}
+
+	l.mux.Lock()
+	srv, ok := l.servers[key]
+	if ok && isACK(tm.Msg) && rfc2543(tm.Msg) && !srv.matchACK(tm.Msg) {
+		ok = false
+	}
+	if !ok || srv.IsTerminated() {
+		if isACK(tm.Msg) {
+			l.mux.Unlock()
+			l.passUnmatched(tm)
+			return nil
+		}
+		srv, err = NewServer(tm, l.chTU, l.chTransp)
+		if err == nil {
+			l.servers[key] = srv
+		}
+		l.mux.Unlock()
+		return err
+	}
+	l.mux.Unlock()
+	return srv.Recv(tm)
+}
+
+// Handle passes message received by transport to the layer.
+// Can be used as transport handler (transp.Handler).
+func (l *Layer) Handle(msg *sipmsg.Message, addr *transp.Addr) {
+	l.Recv(&Message{msg, addr})
+}
+
+// Len returns number of client and server transactions
+func (l *Layer) Len() int {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+	return len(l.clients) + len(l.servers)
+}
+
+// Close stops transactions garbage collector
+func (l *Layer) Close() {
+	close(l.done)
+}
+
+func (l *Layer) passUnmatched(tm *Message) {
+	if l.unmatched != nil {
+		l.unmatched(tm)
+	}
+}
+
+func (l *Layer) gc() {
+	ticker := time.NewTicker(gcInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-l.done:
+			return
+		case <-ticker.C:
+			l.sweep()
+		}
+	}
+}
+
+// sweep removes terminated transactions
+func (l *Layer) sweep() {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+	for key, cl := range l.clients {
+		if cl.IsTerminated() {
+			delete(l.clients, key)
+		}
+	}
+	for key, srv := range l.servers {
+		if srv.IsTerminated() {
+			delete(l.servers, key)
+		}
+	}
+}
+
+// txnKey builds transaction matching key from top Via branch,
+// sent-by and CSeq method. ACK on server side matches INVITE transaction.
+// If branch does not have magic cookie then RFC2543 matching is
+// used with Call-ID, From tag, CSeq and top Via. Server side requests
+// also add Request-URI and To tag except INVITE and ACK which To tag
+// is the tag of the response (RFC3261#17.2.3).
+func txnKey(msg *sipmsg.Message, server bool) (string, error) {
+	if msg.Vias.Count() == 0 {
+		return "", ErrorTxnLayer.msg("missing Via header")
+	}
+	if msg.CSeq == nil {
+		return "", ErrorTxnLayer.msg("missing CSeq header")
+	}
+	via := msg.Vias[0]
+	method := strings.ToUpper(msg.CSeq.Method)
+	if server && method == "ACK" {
+		method = "INVITE"
+	}
+
+	var key strings.Builder
+	if !rfc2543(msg) {
+		key.WriteString(via.Branch())
+	} else {
+		if msg.From == nil {
+			return "", ErrorTxnLayer.msg("missing From header")
+		}
+		key.WriteString(msg.CallID)
+		key.WriteByte('|')
+		key.WriteString(msg.From.Tag())
+		key.WriteByte('|')
+		key.WriteString(strconv.Itoa(int(msg.CSeq.Num)))
+	}
+	key.WriteByte('|')
+	key.WriteString(strings.ToLower(via.Host()))
+	key.WriteByte(':')
+	if port := via.Port(); port != "" {
+		key.WriteString(port)
+	} else {
+		key.WriteString("5060")
+	}
+	key.WriteByte('|')
+	key.WriteString(method)
+	if server && msg.IsRequest() && rfc2543(msg) {
+		key.WriteByte('|')
+		key.WriteString(msg.ReqLine.RequestURI())
+		if method != "INVITE" && msg.To != nil {
+			key.WriteByte('|')
+			key.WriteString(msg.To.Tag())
+		}
+	}
+	return key.String(), nil
+}
+
+// rfc2543 returns true if top Via branch does not have magic cookie
+func rfc2543(msg *sipmsg.Message) bool {
+	return !strings.HasPrefix(msg.Vias[0].Branch(), sipmsg.BranchCookie)
+}
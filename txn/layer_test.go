@@ -0,0 +1,265 @@
+package txn
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/staskobzar/gosip/sipmsg"
+	"github.com/staskobzar/gosip/transp"
+	"github.com/stretchr/testify/assert"
+)
+
+func parseMsg(t *testing.T, str string) *sipmsg.Message {
+	msg, err := sipmsg.MsgParse([]byte(str))
+	if err != nil {
+		t.Fatalf("failed to parse message: %s", err)
+	}
+	return msg
+}
+
+var layerReq = "OPTIONS sip:bob@biloxi.example.com SIP/2.0\r\n" +
+	"Via: SIP/2.0/UDP client.atlanta.example.com:5060;branch=z9hG4bKbf9f44\r\n" +
+	"Max-Forwards: 70\r\n" +
+	"From: Alice <sip:alice@atlanta.example.com>;tag=9fxced76sl\r\n" +
+	"To: Bob <sip:bob@biloxi.example.com>\r\n" +
+	"Call-ID: 2xTb9vxSit55XU7p8@atlanta.example.com\r\n" +
+	"CSeq: 1 OPTIONS\r\n" +
+	"Content-Length: 0\r\n\r\n"
+
+func TestTxnLayerKey(t *testing.T) {
+	req := parseMsg(t, layerReq)
+	key, err := txnKey(req, false)
+	assert.Nil(t, err)
+	assert.Equal(t, "z9hG4bKbf9f44|client.atlanta.example.com:5060|OPTIONS", key)
+
+	resp, _ := req.NewResponse(200, "OK")
+	rkey, err := txnKey(resp, false)
+	assert.Nil(t, err)
+	assert.Equal(t, key, rkey)
+
+	ack := parseMsg(t, "ACK sip:bob@biloxi.example.com SIP/2.0\r\n"+
+		"Via: SIP/2.0/UDP client.atlanta.example.com;branch=z9hG4bKbf9f44\r\n"+
+		"From: Alice <sip:alice@atlanta.example.com>;tag=9fxced76sl\r\n"+
+		"To: Bob <sip:bob@biloxi.example.com>;tag=314159\r\n"+
+		"Call-ID: 2xTb9vxSit55XU7p8@atlanta.example.com\r\n"+
+		"CSeq: 1 ACK\r\n\r\n")
+	key, err = txnKey(ack, true)
+	assert.Nil(t, err)
+	assert.Equal(t, "z9hG4bKbf9f44|client.atlanta.example.com:5060|INVITE", key)
+	key, err = txnKey(ack, false)
+	assert.Nil(t, err)
+	assert.Equal(t, "z9hG4bKbf9f44|client.atlanta.example.com:5060|ACK", key)
+
+	// RFC2543 branch
+	req = parseMsg(t, "BYE sip:bob@biloxi.example.com SIP/2.0\r\n"+
+		"Via: SIP/2.0/UDP 10.0.0.1:5062;branch=1234abcd\r\n"+
+		"From: Alice <sip:alice@atlanta.example.com>;tag=9fxced76sl\r\n"+
+		"To: Bob <sip:bob@biloxi.example.com>;tag=314159\r\n"+
+		"Call-ID: 2xTb9vxSit55XU7p8@atlanta.example.com\r\n"+
+		"CSeq: 2 BYE\r\n\r\n")
+	key, err = txnKey(req, true)
+	assert.Nil(t, err)
+	assert.Equal(t, "2xTb9vxSit55XU7p8@atlanta.example.com|9fxced76sl|2|10.0.0.1:5062|BYE|sip:bob@biloxi.example.com|314159", key)
+	resp, _ = req.NewResponse(200, "OK")
+	key, err = txnKey(resp, true)
+	assert.Nil(t, err)
+	assert.Equal(t, "2xTb9vxSit55XU7p8@atlanta.example.com|9fxced76sl|2|10.0.0.1:5062|BYE", key)
+
+	// no Via header
+	req = initInvite()
+	_, err = txnKey(req, false)
+	assert.NotNil(t, err)
+}
+
+func TestTxnLayerClient(t *testing.T) {
+	tu := make(chan *Message)
+	tr := make(chan *Message)
+	var stray *Message
+	l := NewLayer(tu, tr, func(tm *Message) { stray = tm })
+	defer l.Close()
+
+	addr := transp.UDPAddr("10.0.0.1:5060")
+	req := parseMsg(t, layerReq)
+	cl, err := l.Request(&Message{req, addr})
+	assert.Nil(t, err)
+	assert.NotNil(t, cl)
+	<-tr
+
+	_, err = l.Request(&Message{req, addr})
+	assert.NotNil(t, err)
+
+	resp, _ := req.NewResponse(200, "OK")
+	assert.Nil(t, l.Recv(&Message{resp, addr}))
+	tm := <-tu
+	assert.Equal(t, 200, tm.Msg.Code())
+	assert.Nil(t, stray)
+
+	// response to unknown transaction
+	other := parseMsg(t, layerReq)
+	other.Vias = nil
+	via, _ := sipmsg.NewHdrVia("UDP", "client.atlanta.example.com", 5060, nil)
+	other.Vias = append(other.Vias, via)
+	resp, _ = other.NewResponse(200, "OK")
+	assert.Nil(t, l.Recv(&Message{resp, addr}))
+	assert.NotNil(t, stray)
+	assert.Equal(t, 1, l.Len())
+
+	cl.terminate()
+	l.sweep()
+	assert.Equal(t, 0, l.Len())
+}
+
+func TestTxnLayerServer(t *testing.T) {
+	tu := make(chan *Message)
+	tr := make(chan *Message)
+	var stray *Message
+	l := NewLayer(tu, tr, func(tm *Message) { stray = tm })
+	defer l.Close()
+
+	addr := transp.UDPAddr("10.0.0.1:5060")
+	req := parseMsg(t, layerReq)
+	assert.Nil(t, l.Recv(&Message{req, addr}))
+	tm := <-tu
+	assert.Equal(t, "OPTIONS", tm.Msg.ReqLine.Method())
+	assert.Equal(t, 1, l.Len())
+
+	// retransmission is absorbed by server transaction
+	assert.Nil(t, l.Recv(&Message{parseMsg(t, layerReq), addr}))
+	assert.Equal(t, 1, l.Len())
+
+	resp, _ := req.NewResponse(200, "OK")
+	assert.NotNil(t, l.Respond(resp, &Message{resp, nil}))
+	go l.Respond(req, &Message{resp, nil})
+	tm = <-tr
+	assert.Equal(t, 200, tm.Msg.Code())
+
+	// retransmission in completed state re-sends response
+	go l.Recv(&Message{parseMsg(t, layerReq), addr})
+	tm = <-tr
+	assert.Equal(t, 200, tm.Msg.Code())
+
+	// response without server transaction
+	other := initInvite()
+	via, _ := sipmsg.NewHdrVia("UDP", "10.0.0.2", 0, nil)
+	other.Vias = append(other.Vias, via)
+	resp, _ = other.NewResponse(200, "OK")
+	assert.NotNil(t, l.Respond(other, &Message{resp, nil}))
+
+	// ACK without server transaction
+	resp, _ = other.NewResponse(200, "OK")
+	ack, _ := other.NewACK(resp)
+	assert.Nil(t, l.Recv(&Message{ack, addr}))
+	assert.NotNil(t, stray)
+	assert.Equal(t, "ACK", stray.Msg.ReqLine.Method())
+}
+
+func TestTxnLayerServerRFC2543(t *testing.T) {
+	tu := make(chan *Message)
+	tr := make(chan *Message)
+	var stray *Message
+	l := NewLayer(tu, tr, func(tm *Message) { stray = tm })
+	defer l.Close()
+
+	addr := transp.TCPAddr("10.0.0.1:5060")
+	invite := "INVITE sip:bob@biloxi.example.com SIP/2.0\r\n" +
+		"Via: SIP/2.0/TCP 10.0.0.1:5062;branch=1234abcd\r\n" +
+		"From: Alice <sip:alice@atlanta.example.com>;tag=9fxced76sl\r\n" +
+		"To: Bob <sip:bob@biloxi.example.com>\r\n" +
+		"Call-ID: 3848276298220188511@atlanta.example.com\r\n" +
+		"CSeq: 1 INVITE\r\n\r\n"
+	req := parseMsg(t, invite)
+	assert.Nil(t, l.Recv(&Message{req, addr}))
+	<-tu
+
+	// same request to other Request-URI is new transaction
+	other := parseMsg(t, strings.Replace(invite, "sip:bob@biloxi", "sip:carol@biloxi", 1))
+	assert.Nil(t, l.Recv(&Message{other, addr}))
+	<-tu
+	assert.Equal(t, 2, l.Len())
+
+	// response is passed to the transaction of the request
+	resp, _ := req.NewResponse(486, "Busy Here")
+	resp.AddToTag()
+	go l.Respond(req, &Message{resp, nil})
+	assert.Equal(t, 486, (<-tr).Msg.Code())
+	l.mux.Lock()
+	for _, srv := range l.servers {
+		if srv.request == req {
+			assert.Equal(t, Completed, srv.State())
+		} else {
+			assert.Equal(t, Proceeding, srv.State())
+		}
+	}
+	l.mux.Unlock()
+
+	// ACK with To tag other than response tag does not match
+	other, _ = req.NewResponse(486, "Busy Here")
+	other.AddToTag()
+	ack, _ := req.NewACK(other)
+	assert.Nil(t, l.Recv(&Message{ack, addr}))
+	assert.NotNil(t, stray)
+
+	stray = nil
+	ack, _ = req.NewACK(resp)
+	assert.Nil(t, l.Recv(&Message{ack, addr}))
+	assert.Nil(t, stray)
+}
+
+func TestTxnLayerCancel(t *testing.T) {
+	tu := make(chan *Message)
+	tr := make(chan *Message)
+	l := NewLayer(tu, tr, nil)
+	defer l.Close()
+
+	addr := transp.TCPAddr("10.0.0.1:5060")
+	req := parseMsg(t, "INVITE sip:bob@biloxi.example.com SIP/2.0\r\n"+
+		"Via: SIP/2.0/TCP client.atlanta.example.com:5060;branch=z9hG4bK74bf9\r\n"+
+		"From: Alice <sip:alice@atlanta.example.com>;tag=9fxced76sl\r\n"+
+		"To: Bob <sip:bob@biloxi.example.com>\r\n"+
+		"Call-ID: 3848276298220188511@atlanta.example.com\r\n"+
+		"CSeq: 1 INVITE\r\n\r\n")
+	cl, err := l.Request(&Message{req, addr})
+	assert.Nil(t, err)
+	assert.Equal(t, "INVITE", (<-tr).Msg.ReqLine.Method())
+
+	// CANCEL waits for provisional response
+	assert.Nil(t, l.Cancel(cl))
+	assert.Equal(t, 1, l.Len())
+
+	resp, _ := req.NewResponse(180, "Ringing")
+	resp.AddToTag()
+	assert.Nil(t, l.Recv(&Message{resp, addr}))
+	var cancel *Message
+	for i := 0; i < 2; i++ {
+		select {
+		case tm := <-tu:
+			assert.Equal(t, 180, tm.Msg.Code())
+		case cancel = <-tr:
+		}
+	}
+	assert.NotNil(t, cancel)
+	assert.Equal(t, "CANCEL", cancel.Msg.ReqLine.Method())
+	assert.Equal(t, "z9hG4bK74bf9", cancel.Msg.Vias[0].Branch())
+	assert.Equal(t, 2, l.Len())
+
+	// 200 to CANCEL and 487 to INVITE
+	ok, _ := cancel.Msg.NewResponse(200, "OK")
+	assert.Nil(t, l.Recv(&Message{ok, addr}))
+	tm := <-tu
+	assert.Equal(t, "CANCEL", tm.Msg.CSeq.Method)
+
+	terminated, _ := req.NewResponse(487, "Request Terminated")
+	terminated.AddToTag()
+	assert.Nil(t, l.Recv(&Message{terminated, addr}))
+	assert.Equal(t, "ACK", (<-tr).Msg.ReqLine.Method())
+	assert.Equal(t, 487, (<-tu).Msg.Code())
+
+	// final response received
+	assert.NotNil(t, l.Cancel(cl))
+
+	// non-INVITE transaction
+	cl, err = l.Request(&Message{parseMsg(t, layerReq), addr})
+	assert.Nil(t, err)
+	<-tr
+	assert.NotNil(t, l.Cancel(cl))
+}
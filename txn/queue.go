@@ -0,0 +1,30 @@
+package txn
+
+// delivery message and channel it is sent to
+type delivery struct {
+	ch chan *Message
+	tm *Message
+}
+
+// queue keeps order of messages sent by transaction state machine
+// without holding transaction mutex while channel is blocked
+type queue struct {
+	last chan struct{}
+}
+
+// push must be called with locked transaction mutex. Returned function
+// sends messages after the messages pushed before and must be called
+// when mutex is unlocked.
+func (q *queue) push(out ...delivery) func() {
+	prev, done := q.last, make(chan struct{})
+	q.last = done
+	return func() {
+		if prev != nil {
+			<-prev
+		}
+		for _, d := range out {
+			d.ch <- d.tm
+		}
+		close(done)
+	}
+}
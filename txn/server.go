@@ -0,0 +1,339 @@
+package txn
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/staskobzar/gosip/sipmsg"
+	"github.com/staskobzar/gosip/transp"
+)
+
+// ErrorTxnServer transaction server error
+var ErrorTxnServer = errorNew("Transaction Server")
+
+// delay before INVITE server transaction generates 100 Trying
+// if TU does not send any provisional response (RFC3261#17.2.1)
+const tryingDelay = 200 * time.Millisecond
+
+// Server transaction structure (RFC3261#17.2)
+type Server struct {
+	state    State
+	request  *sipmsg.Message
+	response *sipmsg.Message
+	addr     *transp.Addr
+	cancel   context.CancelFunc
+	timer    *Timer
+	mux      *sync.Mutex
+	chTU     chan *Message
+	chTransp chan *Message
+	out      queue
+}
+
+// NewServer creates new server transaction RFC3261#17.2
+// If method is INVITE then creates INVITE transaction,
+// non-INVITE otherwise. Request is passed to TU channel.
+// Address is a source address of the request where responses are sent.
+// When INVITE transaction does not receive ACK (timer H) then locally
+// generated 408 response with nil address is passed to TU.
+func NewServer(tm *Message, tu chan *Message, transp chan *Message) (*Server, error) {
+	if tm.Msg == nil {
+		return nil, ErrorTxnServer.msg("invalid sip message")
+	}
+	if tm.Addr == nil {
+		return nil, ErrorTxnServer.msg("invalid transport address")
+	}
+	if !tm.Msg.IsRequest() {
+		return nil, ErrorTxnServer.msg("sip request expected")
+	}
+	if isACK(tm.Msg) {
+		return nil, ErrorTxnServer.msg("ACK can not create server transaction")
+	}
+
+	server := &Server{
+		request:  tm.Msg,
+		addr:     tm.Addr,
+		mux:      &sync.Mutex{},
+		timer:    initTimer(0),
+		chTU:     tu,
+		chTransp: transp,
+	}
+	if server.request.IsInvite() {
+		server.invite()
+	} else {
+		server.nonInvite()
+	}
+
+	return server, nil
+}
+
+// Recv update server transaction with request retransmission or ACK
+// received from transport layer.
+func (srv *Server) Recv(tm *Message) error {
+	if tm.Msg == nil {
+		return ErrorTxnServer.msg("invalid sip message")
+	}
+	if !tm.Msg.IsRequest() {
+		return ErrorTxnServer.msg("sip request expected")
+	}
+
+	srv.mux.Lock()
+
+	if isACK(tm.Msg) {
+		defer srv.mux.Unlock()
+		if !srv.request.IsInvite() {
+			return ErrorTxnServer.msg("unexpected ACK for non-INVITE transaction")
+		}
+		if srv.state == Completed {
+			srv.confirmed()
+		}
+		// ACKs in Confirmed state are absorbed
+		return nil
+	}
+
+	var out []delivery
+	switch srv.state {
+	case Trying, Confirmed:
+		// request retransmission is discarded
+	case Proceeding, Completed:
+		if srv.response != nil {
+			out = append(out, srv.toTransp())
+		}
+	case Terminated:
+		srv.mux.Unlock()
+		return ErrorTxnServer.msg("transaction is terminated")
+	}
+	srv.unlock(out...)
+	return nil
+}
+
+// Send sends response from TU to transport layer and update
+// server transaction state
+func (srv *Server) Send(tm *Message) error {
+	if tm.Msg == nil {
+		return ErrorTxnServer.msg("invalid sip message")
+	}
+	if !tm.Msg.IsResponse() {
+		return ErrorTxnServer.msg("sip response expected")
+	}
+	code := tm.Msg.Code()
+	if code < 100 || code > 699 {
+		return ErrorTxnServer.msg("invalid response code %d", code)
+	}
+
+	srv.mux.Lock()
+
+	if srv.state != Trying && srv.state != Proceeding {
+		srv.mux.Unlock()
+		return ErrorTxnServer.msg("final response already sent")
+	}
+
+	srv.response = tm.Msg
+	out := srv.toTransp()
+	if srv.request.IsInvite() {
+		srv.smInvProceeding(code)
+	} else {
+		srv.smProceeding(code)
+	}
+	srv.unlock(out)
+	return nil
+}
+
+// State returns current state of the transaction
+func (srv *Server) State() State {
+	srv.mux.Lock()
+	defer srv.mux.Unlock()
+	return srv.state
+}
+
+// IsTerminated returns true if Server state is Terminated
+func (srv *Server) IsTerminated() bool {
+	return srv.State() == Terminated
+}
+
+// matchACK returns true if ACK To tag is the tag of the response
+// sent by transaction (RFC3261#17.2.3)
+func (srv *Server) matchACK(ack *sipmsg.Message) bool {
+	srv.mux.Lock()
+	defer srv.mux.Unlock()
+	if srv.response == nil || srv.response.To == nil || ack.To == nil {
+		return false
+	}
+	return srv.response.To.Tag() == ack.To.Tag()
+}
+
+func (srv *Server) smInvProceeding(code int) {
+	switch {
+	case code < 200:
+	case code < 300:
+		// 2xx retransmissions are handled by TU
+		srv.terminate()
+	default:
+		srv.cancel()
+		srv.state = Completed
+
+		ctx, cancel := context.WithCancel(context.Background())
+		srv.cancel = cancel
+		srv.timerG(ctx)
+		srv.timerH(ctx)
+	}
+}
+
+func (srv *Server) smProceeding(code int) {
+	if code < 200 {
+		srv.state = Proceeding
+		return
+	}
+	srv.state = Completed
+
+	ctx, cancel := context.WithCancel(context.Background())
+	srv.cancel = cancel
+	srv.timerJ(ctx)
+}
+
+func (srv *Server) invite() {
+	ctx, cancel := context.WithCancel(context.Background())
+	srv.cancel = cancel
+	srv.state = Proceeding
+
+	go func() {
+		srv.chTU <- &Message{srv.request, srv.addr}
+	}()
+
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-time.After(tryingDelay):
+			srv.mux.Lock()
+			if srv.state != Proceeding || srv.response != nil {
+				srv.mux.Unlock()
+				return
+			}
+			var out []delivery
+			if trying, err := srv.request.NewResponse(100, "Trying"); err == nil {
+				srv.response = trying
+				out = append(out, srv.toTransp())
+			}
+			srv.unlock(out...)
+		}
+	}()
+}
+
+func (srv *Server) nonInvite() {
+	srv.cancel = func() {}
+	srv.state = Trying
+
+	go func() {
+		srv.chTU <- &Message{srv.request, srv.addr}
+	}()
+}
+
+// timerG retransmits final response to INVITE over unreliable
+// transport until ACK received (RFC3261#17.2.1)
+func (srv *Server) timerG(ctx context.Context) {
+	if !srv.addr.IsUDP() {
+		return
+	}
+	go func() {
+		for {
+			srv.mux.Lock()
+			fire := srv.timer.nextG()
+			srv.mux.Unlock()
+			select {
+			case <-ctx.Done():
+				return
+			case <-fire:
+				srv.mux.Lock()
+				var out []delivery
+				if srv.state == Completed {
+					out = append(out, srv.toTransp())
+				}
+				srv.unlock(out...)
+			}
+		}
+	}()
+}
+
+// timerH terminates INVITE transaction when ACK was never received
+// and informs TU about timeout
+func (srv *Server) timerH(ctx context.Context) {
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-srv.timer.fireH():
+			srv.mux.Lock()
+			if srv.state != Completed {
+				srv.mux.Unlock()
+				return
+			}
+			srv.terminate()
+			var out []delivery
+			if toutResp, err := srv.request.NewResponse(408, "Request Timeout"); err == nil {
+				out = append(out, delivery{srv.chTU, &Message{toutResp, nil}})
+			}
+			srv.unlock(out...)
+		}
+	}()
+}
+
+// timerJ absorbs non-INVITE request retransmissions
+// in Completed state
+func (srv *Server) timerJ(ctx context.Context) {
+	wait := srv.timer.J
+	if !srv.addr.IsUDP() {
+		wait = 0
+	}
+	srv.waitTerminate(ctx, wait)
+}
+
+// confirmed stops response retransmissions and starts timer I
+// to absorb ACK retransmissions. Must be called with locked mutex.
+func (srv *Server) confirmed() {
+	srv.cancel()
+	srv.state = Confirmed
+
+	ctx, cancel := context.WithCancel(context.Background())
+	srv.cancel = cancel
+
+	wait := srv.timer.I
+	if !srv.addr.IsUDP() {
+		wait = 0
+	}
+	srv.waitTerminate(ctx, wait)
+}
+
+func (srv *Server) waitTerminate(ctx context.Context, wait time.Duration) {
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-time.After(wait):
+			srv.mux.Lock()
+			defer srv.mux.Unlock()
+			srv.terminate()
+		}
+	}()
+}
+
+// toTransp returns last response for transport layer.
+// Must be called with locked mutex.
+func (srv *Server) toTransp() delivery {
+	return delivery{srv.chTransp, &Message{srv.response, srv.addr}}
+}
+
+// unlock unlocks mutex and sends messages in order
+// they were created by state machine
+func (srv *Server) unlock(out ...delivery) {
+	send := srv.out.push(out...)
+	srv.mux.Unlock()
+	send()
+}
+
+func (srv *Server) terminate() {
+	srv.cancel()
+	srv.state = Terminated
+}
+
+func isACK(msg *sipmsg.Message) bool {
+	return msg.IsRequest() && strings.EqualFold(msg.ReqLine.Method(), "ACK")
+}
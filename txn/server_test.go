@@ -0,0 +1,206 @@
+package txn
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/staskobzar/gosip/sipmsg"
+	"github.com/staskobzar/gosip/transp"
+	"github.com/stretchr/testify/assert"
+)
+
+func initServer(req *sipmsg.Message, t1 time.Duration) *Server {
+	return &Server{
+		request:  req,
+		addr:     transp.UDPAddr("10.0.0.1:5060"),
+		mux:      &sync.Mutex{},
+		chTU:     make(chan *Message),
+		chTransp: make(chan *Message),
+		timer:    initTimer(t1),
+	}
+}
+
+func TestTxnServerInvalidReq(t *testing.T) {
+	txn, err := NewServer(&Message{nil, transp.UDPAddr("192.168.0.1:5060")}, nil, nil)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "invalid sip message")
+	assert.Nil(t, txn)
+
+	msg := initInvite()
+	txn, err = NewServer(&Message{msg, nil}, nil, nil)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "invalid transport address")
+	assert.Nil(t, txn)
+
+	resp, _ := msg.NewResponse(100, "Trying")
+	txn, err = NewServer(&Message{resp, transp.UDPAddr("10.0.0.1:5060")}, nil, nil)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "sip request expected")
+	assert.Nil(t, txn)
+
+	resp, _ = msg.NewResponse(404, "Not Found")
+	ack, _ := msg.NewACK(resp)
+	txn, err = NewServer(&Message{ack, transp.UDPAddr("10.0.0.1:5060")}, nil, nil)
+	assert.NotNil(t, err)
+	assert.Nil(t, txn)
+}
+
+func TestTxnInvServerAutoTrying(t *testing.T) {
+	tu := make(chan *Message)
+	tr := make(chan *Message)
+	srv, err := NewServer(&Message{initInvite(), transp.UDPAddr("10.0.0.1:5060")}, tu, tr)
+	assert.Nil(t, err)
+
+	tm := <-tu
+	assert.True(t, tm.Msg.IsInvite())
+	assert.Equal(t, Proceeding, srv.State())
+
+	start := time.Now()
+	tm = <-tr
+	assert.Equal(t, 100, tm.Msg.Code())
+	assert.True(t, time.Since(start) > 150*time.Millisecond)
+
+	// retransmission of INVITE resends last provisional response
+	go srv.Recv(&Message{srv.request, srv.addr})
+	tm = <-tr
+	assert.Equal(t, 100, tm.Msg.Code())
+	srv.terminate()
+}
+
+func TestTxnInvServerProceeding(t *testing.T) {
+	srv := initServer(initInvite(), 0)
+	srv.invite()
+	<-srv.chTU
+
+	resp, _ := srv.request.NewResponse(180, "Ringing")
+	go srv.Send(&Message{resp, nil})
+	tm := <-srv.chTransp
+	assert.Equal(t, 180, tm.Msg.Code())
+	assert.Equal(t, Proceeding, srv.State())
+
+	// no automatic 100 Trying after provisional response from TU
+	select {
+	case tm = <-srv.chTransp:
+		t.Errorf("unexpected response %d", tm.Msg.Code())
+	case <-time.After(300 * time.Millisecond):
+	}
+
+	resp, _ = srv.request.NewResponse(200, "OK")
+	go srv.Send(&Message{resp, nil})
+	tm = <-srv.chTransp
+	assert.Equal(t, 200, tm.Msg.Code())
+	<-time.After(10 * time.Millisecond)
+	assert.True(t, srv.IsTerminated())
+
+	err := srv.Send(&Message{resp, nil})
+	assert.NotNil(t, err)
+}
+
+func TestTxnInvServerCompletedACK(t *testing.T) {
+	srv := initServer(initInvite(), 5*time.Millisecond)
+	srv.timer.I = 10 * time.Millisecond
+	srv.invite()
+	<-srv.chTU
+
+	resp, _ := srv.request.NewResponse(486, "Busy Here")
+	go srv.Send(&Message{resp, nil})
+
+	// initial response and retransmissions by timer G
+	for i := 0; i < 3; i++ {
+		tm := <-srv.chTransp
+		assert.Equal(t, 486, tm.Msg.Code())
+	}
+	assert.Equal(t, Completed, srv.State())
+
+	// drain retransmissions sent before ACK received
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			select {
+			case <-srv.chTransp:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	ack, _ := srv.request.NewACK(resp)
+	assert.Nil(t, srv.Recv(&Message{ack, srv.addr}))
+	assert.Equal(t, Confirmed, srv.State())
+
+	// ACK retransmission is absorbed
+	assert.Nil(t, srv.Recv(&Message{ack, srv.addr}))
+
+	<-time.After(50 * time.Millisecond)
+	assert.True(t, srv.IsTerminated())
+}
+
+func TestTxnInvServerTimerH(t *testing.T) {
+	srv := initServer(initInvite(), 5*time.Millisecond)
+	srv.timer.H = 100 * time.Millisecond
+	srv.invite()
+	<-srv.chTU
+
+	resp, _ := srv.request.NewResponse(603, "Decline")
+	go srv.Send(&Message{resp, nil})
+
+	var retrans int
+	var timeout bool
+Loop:
+	for {
+		select {
+		case <-time.After(1000 * time.Millisecond):
+			timeout = true
+			break Loop
+		case <-srv.chTransp:
+			retrans++
+		case <-time.After(50 * time.Millisecond):
+			if srv.IsTerminated() {
+				break Loop
+			}
+		}
+	}
+	assert.False(t, timeout)
+	// response and retransmissions at 5, 15, 35, 75 ms
+	// until timer H fires at 100ms
+	assert.Equal(t, 5, retrans)
+
+	tm := <-srv.chTU
+	assert.Equal(t, 408, tm.Msg.Code())
+	assert.Nil(t, tm.Addr)
+}
+
+func TestTxnNonInvServer(t *testing.T) {
+	srv := initServer(initNonInvite(), 0)
+	srv.timer.J = 10 * time.Millisecond
+	srv.nonInvite()
+	tm := <-srv.chTU
+	assert.Equal(t, "REGISTER", tm.Msg.ReqLine.Method())
+	assert.Equal(t, Trying, srv.State())
+
+	// retransmission in Trying state is discarded
+	assert.Nil(t, srv.Recv(&Message{srv.request, srv.addr}))
+
+	resp, _ := srv.request.NewResponse(100, "Trying")
+	go srv.Send(&Message{resp, nil})
+	tm = <-srv.chTransp
+	assert.Equal(t, 100, tm.Msg.Code())
+
+	resp, _ = srv.request.NewResponse(200, "OK")
+	go srv.Send(&Message{resp, nil})
+	tm = <-srv.chTransp
+	assert.Equal(t, 200, tm.Msg.Code())
+
+	assert.Equal(t, Completed, srv.State())
+
+	// retransmission in Completed state resends final response
+	go srv.Recv(&Message{srv.request, srv.addr})
+	tm = <-srv.chTransp
+	assert.Equal(t, 200, tm.Msg.Code())
+
+	<-time.After(50 * time.Millisecond)
+	assert.True(t, srv.IsTerminated())
+	assert.NotNil(t, srv.Recv(&Message{srv.request, srv.addr}))
+}
@@ -53,11 +53,9 @@ func initTimer(t1 time.Duration) *Timer {
 	// B timer 64*T1 INVITE transaction timeout timer
 	t.B = 64 * t.T1
 	// C timer > 3min; proxy INVITE transaction timeout
-	// TODO: update later to correct value or document here
-	t.C = 0
+	t.C = 3*time.Minute + 30*time.Second
 	// D timer > 32s for UDP, 0s for TCP/SCTP; Wait time for response retransmits
-	// TODO: update later to correct value or document here
-	t.D = 0
+	t.D = 32 * time.Second
 	// E timer initially T1; non-INVITE request retransmit interval, UDP only
 	t.E = t.T1
 	// F timer 64*T1; non-INVITE transaction timeout timer
@@ -67,8 +65,7 @@ func initTimer(t1 time.Duration) *Timer {
 	// H timer H  64*T1; Wait time for ACK receipt
 	t.H = 64 * t.T1
 	// I timer T4 for UDP 0s for TCP/SCTP; Wait time for ACK retransmits
-	// TODO: update later to correct value or document here
-	t.I = 0
+	t.I = t.T4
 	// J timer 64*T1 for UDP 0s for TCP/SCTP; Wait time for non-INVITE request retransmits
 	// TODO: update later to correct value or document here
 	t.J = 64 * t.T1
@@ -97,3 +94,56 @@ func (t *Timer) fireB() <-chan struct{} {
 	}()
 	return ch
 }
+
+// nextE returns channel that fires when timer E expires and
+// doubles timer E up to T2. In Proceeding state timer E is T2.
+func (t *Timer) nextE(proceeding bool) <-chan struct{} {
+	if proceeding {
+		t.E = t.T2
+	}
+	wait := t.E
+	t.E = t.E * 2
+	if t.E > t.T2 {
+		t.E = t.T2
+	}
+	ch := make(chan struct{})
+	go func() {
+		defer close(ch)
+		<-time.After(wait)
+	}()
+	return ch
+}
+
+func (t *Timer) fireF() <-chan struct{} {
+	ch := make(chan struct{})
+	go func() {
+		defer close(ch)
+		<-time.After(t.F)
+	}()
+	return ch
+}
+
+// nextG returns channel that fires when timer G expires and
+// doubles timer G up to T2.
+func (t *Timer) nextG() <-chan struct{} {
+	wait := t.G
+	t.G = t.G * 2
+	if t.G > t.T2 {
+		t.G = t.T2
+	}
+	ch := make(chan struct{})
+	go func() {
+		defer close(ch)
+		<-time.After(wait)
+	}()
+	return ch
+}
+
+func (t *Timer) fireH() <-chan struct{} {
+	ch := make(chan struct{})
+	go func() {
+		defer close(ch)
+		<-time.After(t.H)
+	}()
+	return ch
+}